@@ -0,0 +1,146 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// completionScripts are the completion scripts of the supported shells,
+// keyed by shell. The {{...}} placeholders are replaced with the words of
+// mirip: {{flags}} with its flags, {{value_flags}} with those taking a
+// value and {{commands}} with its subcommands. Interface names are
+// completed with mirip list on the source directory.
+var completionScripts = map[string]string{
+	"bash": `# bash completion for mirip, from: source <(mirip completion bash)
+_mirip() {
+	local cur=${COMP_WORDS[COMP_CWORD]} prev=${COMP_WORDS[COMP_CWORD-1]}
+	local value_flags=" {{value_flags}} " dir i
+	COMPREPLY=()
+	for ((i = 1; i < COMP_CWORD; i++)); do
+		case ${COMP_WORDS[i]} in
+		-*) [[ $value_flags == *" ${COMP_WORDS[i]} "* ]] && i=$((i + 1)) ;;
+		*) dir=${COMP_WORDS[i]}; break ;;
+		esac
+	done
+
+	if [[ $cur == -* ]]; then
+		COMPREPLY=($(compgen -W "{{flags}}" -- "$cur"))
+	elif [[ $value_flags == *" $prev "* ]]; then
+		COMPREPLY=($(compgen -f -- "$cur"))
+	elif [[ -z $dir ]]; then
+		COMPREPLY=($(compgen -W "{{commands}}" -- "$cur") $(compgen -d -- "$cur"))
+	elif [[ " {{commands}} " != *" $dir "* ]]; then
+		COMPREPLY=($(compgen -W "$(mirip list "$dir" 2>/dev/null)" -- "$cur"))
+	fi
+}
+complete -o default -F _mirip mirip
+`,
+	"zsh": `#compdef mirip
+# zsh completion for mirip, from: source <(mirip completion zsh)
+_mirip() {
+	local -a flags=({{flags}}) value_flags=({{value_flags}}) commands=({{commands}})
+	local dir i
+	for ((i = 2; i < CURRENT; i++)); do
+		case $words[i] in
+		-*) (( ${value_flags[(Ie)$words[i]]} )) && ((i++)) ;;
+		*) dir=$words[i]; break ;;
+		esac
+	done
+
+	if [[ $PREFIX == -* ]]; then
+		compadd -- $flags
+	elif (( ${value_flags[(Ie)$words[CURRENT-1]]} )); then
+		_files
+	elif [[ -z $dir ]]; then
+		compadd -- $commands
+		_directories
+	elif (( ! ${commands[(Ie)$dir]} )); then
+		compadd -- ${(f)"$(mirip list $dir 2>/dev/null)"}
+	else
+		_files
+	fi
+}
+compdef _mirip mirip
+`,
+	"fish": `# fish completion for mirip, from: mirip completion fish | source
+function __mirip_dir
+	set -l words (commandline -opc)
+	set -e words[1]
+	set -l skip
+	for w in $words
+		if set -q skip[1]
+			set -e skip
+			continue
+		end
+		switch $w
+			case '-*'
+				contains -- $w {{value_flags}}; and set skip 1
+			case '*'
+				echo $w
+				return 0
+		end
+	end
+	return 1
+end
+
+complete -c mirip -f
+complete -c mirip -n 'not __mirip_dir >/dev/null' -a '{{commands}}'
+complete -c mirip -n 'not __mirip_dir >/dev/null' -a '(__fish_complete_directories)'
+complete -c mirip -n 'set -l dir (__mirip_dir); and not contains -- $dir {{commands}}' -a '(mirip list (__mirip_dir) 2>/dev/null)'
+{{fish_flags}}`,
+}
+
+func runCompletion(args []string) error {
+	fs := flag.NewFlagSet("completion", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`mirip completion bash|zsh|fish`)
+		fmt.Println(`Prints the completion script of the shell, completing interface names with mirip list.`)
+		fmt.Println(`Ex: source <(mirip completion bash)`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 || completionScripts[fs.Arg(0)] == "" {
+		fs.Usage()
+		return usageError{"expected a shell: bash, zsh or fish"}
+	}
+
+	var flags userFlags
+	mainFlags := flag.NewFlagSet("mirip", flag.ContinueOnError)
+	flags.register(mainFlags)
+
+	var names, valueNames, fishFlags []string
+	mainFlags.VisitAll(func(f *flag.Flag) {
+		if hiddenFlags[f.Name] {
+			return
+		}
+		names = append(names, "-"+f.Name)
+
+		fish := "complete -c mirip -o " + f.Name + " -d " + fishQuote(f.Usage)
+		if b, ok := f.Value.(interface{ IsBoolFlag() bool }); !ok || !b.IsBoolFlag() {
+			valueNames = append(valueNames, "-"+f.Name)
+			fish += " -r -F"
+		}
+		fishFlags = append(fishFlags, fish+"\n")
+	})
+
+	var commandNames []string
+	for name := range commands {
+		commandNames = append(commandNames, name)
+	}
+	sort.Strings(commandNames)
+
+	fmt.Print(strings.NewReplacer(
+		"{{flags}}", strings.Join(names, " "),
+		"{{value_flags}}", strings.Join(valueNames, " "),
+		"{{commands}}", strings.Join(commandNames, " "),
+		"{{fish_flags}}", strings.Join(fishFlags, ""),
+	).Replace(completionScripts[fs.Arg(0)]))
+	return nil
+}
+
+// fishQuote quotes s as a single-quoted fish string.
+func fishQuote(s string) string {
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(s) + "'"
+}
@@ -0,0 +1,228 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// configFile is the file of -config, listing the interfaces to mock with
+// the flags of the file, which the flags of each interface override, so
+// that one file generates mocks of different styles. Profiles are sets of
+// flags keyed by name, of which -profile selects one, defaultProfile by
+// default, overriding the flags of the file, so that its interfaces are
+// generated in the style of each layer of tests.
+type configFile struct {
+	Flags      configFlags            `json:"flags"`
+	Profiles   map[string]configFlags `json:"profiles"`
+	Interfaces []configInterface      `json:"interfaces"`
+}
+
+// defaultProfile is the profile of a config file without -profile.
+const defaultProfile = "default"
+
+// configInterface is an interface of the config file, in the format
+// 'interface' or 'interface:alias', declared in the source dir Src,
+// relative to the file, along with the flags overriding those of the
+// file, and the flags of its profiles overriding those of the profiles of
+// the file.
+type configInterface struct {
+	Src       string                 `json:"src"`
+	Interface string                 `json:"interface"`
+	Flags     configFlags            `json:"flags"`
+	Profiles  map[string]configFlags `json:"profiles"`
+}
+
+// configFlags are flags of mirip keyed by name, without the dash, with a
+// string, boolean or number value, or a list of those for the repeatable
+// flags.
+type configFlags map[string]interface{}
+
+// configRun is a run of mirip for the interfaces of a source dir sharing
+// the same flags.
+type configRun struct {
+	src       string
+	flags     []string
+	namePairs []string
+}
+
+// runConfig generates the mocks of the interfaces of the config file of
+// -config, from its directory, with a run of mirip for each source dir and
+// flags. The other flags of mirip, such as -check, override those of the
+// file. The lock file of -lock records the command with -config, for
+// mirip verify to regenerate the mocks of the file as it changes.
+func runConfig(flags userFlags) error {
+	if len(flags.args) != 0 || len(flags.sources) != 0 {
+		return usageError{"-config and the sources are exclusive"}
+	}
+	name := flags.config
+	runs, err := readConfig(name, flags.configProfile)
+	if err != nil {
+		return err
+	}
+
+	args := withoutFlags(flags.argv, "config", "lock", "profile")
+	all := make(map[string][]string)
+	for _, r := range runs {
+		var argv []string
+		argv = append(argv, r.flags...)
+		argv = append(argv, args...)
+		argv = append(argv, r.src)
+		argv = append(argv, r.namePairs...)
+		err := inDir(filepath.Dir(name), func() error {
+			flags, err := parseArgs(argv)
+			if err != nil {
+				return fmt.Errorf("%s: %w", name, err)
+			}
+			return run(flags)
+		})
+		if err != nil {
+			return err
+		}
+		for outFile, interfaces := range outputs {
+			all[outFile] = append(all[outFile], interfaces...)
+		}
+	}
+
+	if flags.lock == "" {
+		return nil
+	}
+	outputs = all
+	return updateLock(flags.lock, flags.argv)
+}
+
+// readConfig reads the config file of the given name into its runs with
+// the given profile, in the order of their first interface.
+func readConfig(name, profile string) ([]configRun, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return nil, err
+	}
+	dec := json.NewDecoder(bytes.NewReader(content))
+	dec.DisallowUnknownFields()
+	var cfg configFile
+	if err := dec.Decode(&cfg); err != nil {
+		return nil, usageError{fmt.Sprintf("invalid config file %s: %s", name, err)}
+	}
+	if len(cfg.Interfaces) == 0 {
+		return nil, usageError{fmt.Sprintf("config file %s lists no interfaces", name)}
+	}
+	if profile == "" {
+		profile = defaultProfile
+	} else if !cfg.hasProfile(profile) {
+		return nil, usageError{fmt.Sprintf("config file %s has no profile %s", name, profile)}
+	}
+
+	var runs []configRun
+	byKey := make(map[string]int)
+	for i, iface := range cfg.Interfaces {
+		if iface.Src == "" || iface.Interface == "" {
+			return nil, usageError{fmt.Sprintf("interface %d of config file %s requires src and interface", i+1, name)}
+		}
+		flags := make(configFlags)
+		for _, override := range []configFlags{cfg.Flags, cfg.Profiles[profile], iface.Flags, iface.Profiles[profile]} {
+			for k, v := range override {
+				flags[k] = v
+			}
+		}
+		args, err := flags.args()
+		if err != nil {
+			return nil, usageError{fmt.Sprintf("config file %s: %s", name, err)}
+		}
+
+		key := iface.Src + "\x00" + strings.Join(args, "\x00")
+		if j, ok := byKey[key]; ok {
+			runs[j].namePairs = append(runs[j].namePairs, iface.Interface)
+			continue
+		}
+		byKey[key] = len(runs)
+		runs = append(runs, configRun{src: iface.Src, flags: args, namePairs: []string{iface.Interface}})
+	}
+	return runs, nil
+}
+
+// hasProfile returns whether the file or one of its interfaces has the
+// profile of the given name.
+func (f configFile) hasProfile(name string) bool {
+	if _, ok := f.Profiles[name]; ok {
+		return true
+	}
+	for _, iface := range f.Interfaces {
+		if _, ok := iface.Profiles[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// args returns the flags as arguments of mirip, sorted by name.
+func (f configFlags) args() ([]string, error) {
+	names := make([]string, 0, len(f))
+	for name := range f {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var args []string
+	for _, name := range names {
+		if name == "config" || name == "src" || name == "lock" || name == "profile" || name == "C" {
+			return nil, fmt.Errorf("flag %s can't be set in a config file", name)
+		}
+		values, ok := f[name].([]interface{})
+		if !ok {
+			values = []interface{}{f[name]}
+		}
+		for _, v := range values {
+			value, ok := flagValue(v)
+			if !ok {
+				return nil, fmt.Errorf("invalid value of flag %s: %v", name, v)
+			}
+			args = append(args, "-"+name+"="+value)
+		}
+	}
+	return args, nil
+}
+
+// flagValue returns the JSON value of a flag as an argument.
+func flagValue(v interface{}) (string, bool) {
+	switch v := v.(type) {
+	case string:
+		return v, true
+	case bool:
+		return strconv.FormatBool(v), true
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64), true
+	}
+	return "", false
+}
+
+// withoutFlags returns the arguments without the flags of the given names,
+// which take a value, and their values.
+func withoutFlags(args []string, names ...string) []string {
+	var kept []string
+	for i := 0; i < len(args); i++ {
+		name, _, hasValue := strings.Cut(strings.TrimLeft(args[i], "-"), "=")
+		if strings.HasPrefix(args[i], "-") && isOneOf(name, names) {
+			if !hasValue {
+				i++
+			}
+			continue
+		}
+		kept = append(kept, args[i])
+	}
+	return kept
+}
+
+func isOneOf(name string, names []string) bool {
+	for _, n := range names {
+		if n == name {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/coverage"
+)
+
+func runCoverage(args []string) error {
+	fs := flag.NewFlagSet("coverage", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`mirip coverage coverage-file`)
+		fmt.Println(`Reports the mocked methods which were never called, from the file written by mocks`)
+		fmt.Println(`generated with -coverage when $MIRIP_COVERAGE is set.`)
+		fmt.Println(`Ex: MIRIP_COVERAGE=$PWD/mirip.cov go test ./... && mirip coverage mirip.cov`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return usageError{"expected a coverage file"}
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	report, err := coverage.Read(f)
+	if err != nil {
+		return fmt.Errorf("%s: %s", fs.Arg(0), err)
+	}
+
+	for _, method := range report.Uncalled {
+		fmt.Println(method)
+	}
+	fmt.Printf("%d of %d mocked methods called\n", report.Methods-len(report.Uncalled), report.Methods)
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runDeps(args []string) error {
+	fs := flag.NewFlagSet("deps", flag.ExitOnError)
+	outFile := fs.String("out", "", "output file (default stdout)")
+	prefix := fs.String("prefix", "", "prefix of the mock names")
+	suffix := fs.String("suffix", "", "suffix of the mock names (default Mock, unless -prefix is set)")
+	aliasFile := fs.String("aliases", "", "file mapping interfaces to mock names, one 'Interface=Alias' per line")
+	pkgName := fs.String("pkg", "", "package name (default will infer)")
+	fs.Usage = func() {
+		fmt.Println(`mirip deps [flags] source-dir type`)
+		fs.PrintDefaults()
+		fmt.Println(`Generates mocks for the interfaces among the fields of the type and the parameters of its constructors.`)
+		fmt.Println(`Ex: mirip deps -out mocks_test.go . Service`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return usageError{"expected a source dir and a type"}
+	}
+
+	aliases, err := readAliases(*aliasFile)
+	if err != nil {
+		return err
+	}
+
+	m, err := mirip.New(mirip.Config{
+		SrcDir:  fs.Arg(0),
+		PkgName: *pkgName,
+		OutFile: *outFile,
+		Prefix:  *prefix,
+		Suffix:  *suffix,
+		Aliases: aliases,
+		Warn:    warn,
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		out = &buf
+	}
+
+	if err := m.Dependencies(out, fs.Arg(1)); err != nil {
+		return err
+	}
+
+	if *outFile == "" {
+		return nil
+	}
+
+	return writeFile(*outFile, buf.Bytes())
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/gmhafiz/mirip/internal/suggest"
+)
+
+func runDirectives(args []string) error {
+	fs := flag.NewFlagSet("directives", flag.ExitOnError)
+	fix := fs.Bool("fix", false, "insert the missing go:generate directives into the files declaring the interfaces")
+	fs.Usage = func() {
+		fmt.Println(`mirip directives [flags] [module-dir]`)
+		fs.PrintDefaults()
+		fmt.Println(`Reports the interfaces mocked by files generated by mirip which no go:generate directive regenerates,`)
+		fmt.Println(`along with the directives to insert into the files declaring them.`)
+		fmt.Println(`Ex: mirip directives -fix .`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return usageError{"expected at most a module dir"}
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	undirected, err := suggest.FindUndirected(dir)
+	if err != nil {
+		return err
+	}
+	for _, u := range undirected {
+		fmt.Printf("%s:%d: %s.%s is mocked in %s without a go:generate directive\n",
+			shownPath(root, u.Pos.Filename), u.Pos.Line, u.Interface.Pkg().Path(), u.Interface.Name(),
+			shownPath(root, u.MockFile))
+	}
+
+	fixes := suggest.Fixes(undirected)
+	for _, f := range fixes {
+		fmt.Printf("%s: %s\n", shownPath(root, f.File), f.Directive)
+	}
+	if !*fix {
+		if len(undirected) != 0 {
+			return fmt.Errorf("%d mocked interfaces lack a go:generate directive", len(undirected))
+		}
+		return nil
+	}
+
+	for _, f := range fixes {
+		content, err := os.ReadFile(osPath(f.File))
+		if err != nil {
+			return err
+		}
+		if content, err = f.Apply(content); err != nil {
+			return err
+		}
+		if err := writeFile(f.File, content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// shownPath returns the path of the file relative to root to print it,
+// or the path itself if it can't be made relative.
+func shownPath(root, name string) string {
+	if rel, err := relPath(root, name); err == nil {
+		return rel
+	}
+	return name
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// doc is the doc.go file of a new mocks package, written by the mocker
+// of the first source mocked into it.
+type doc struct {
+	file      string
+	mocker    *mirip.Mocker
+	namePairs []string
+}
+
+// newPackageDocs returns the doc.go files of the output directories which
+// are not a source dir and hold no Go file yet, before any mock is
+// written.
+func newPackageDocs(mockers []*mirip.Mocker, srcs sources) []doc {
+	var docs []doc
+	byDir := make(map[string]int)
+	for i, m := range mockers {
+		for _, np := range srcs[i].namePairs {
+			dir := filepath.Dir(m.OutFile(np))
+			if j, ok := byDir[dir]; ok {
+				docs[j].namePairs = append(docs[j].namePairs, np)
+				continue
+			}
+			if sameDir(dir, srcs[i].dir) || holdsGoFiles(dir) {
+				continue
+			}
+			byDir[dir] = len(docs)
+			docs = append(docs, doc{file: filepath.Join(dir, "doc.go"), mocker: m, namePairs: []string{np}})
+		}
+	}
+	return docs
+}
+
+// writeDocs writes the doc.go files, with the command line of mirip.
+func writeDocs(docs []doc) error {
+	command := make([]string, len(os.Args))
+	command[0] = "mirip"
+	for i, arg := range os.Args[1:] {
+		command[i+1] = shellQuote(slashed(arg))
+	}
+
+	for _, d := range docs {
+		var buf bytes.Buffer
+		if err := d.mocker.Doc(&buf, strings.Join(command, " "), d.namePairs...); err != nil {
+			return err
+		}
+		status, err := writeOutput(d.file, buf.Bytes())
+		recordResults(d.namePairs, d.file, status, written(status, buf.Len()))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// holdsGoFiles returns whether dir holds a Go file.
+func holdsGoFiles(dir string) bool {
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	return len(matches) != 0
+}
+
+// sameDir returns whether both paths are of the same directory.
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// shellQuote quotes the argument for a POSIX shell, if needed.
+func shellQuote(arg string) string {
+	if arg != "" && strings.Trim(arg, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_=./:,+@%") == "" {
+		return arg
+	}
+	return "'" + strings.ReplaceAll(arg, "'", `'\''`) + "'"
+}
@@ -0,0 +1,172 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	htmltemplate "html/template"
+	"io"
+	"os"
+	"path/filepath"
+	"text/template"
+
+	"github.com/gmhafiz/mirip/internal/suggest"
+)
+
+// Formats of mirip docs.
+const (
+	docsMarkdown = "markdown"
+	docsHTML     = "html"
+)
+
+// docsInterface is a mocked interface of mirip docs, with the paths
+// relative to the module dir.
+type docsInterface struct {
+	Interface string
+	Pos       string
+	Methods   []string
+	Mocks     []docsMock
+}
+
+// docsMock is a mock of a docsInterface.
+type docsMock struct {
+	Name   string
+	File   string
+	UsedBy []string
+}
+
+// language=GoTemplate
+const docsMarkdownTemplate = `# Mocked Interfaces
+{{range .}}
+## {{.Interface}}
+{{if .Pos}}
+Declared in ` + "`{{.Pos}}`" + `.
+{{- end}}
+{{if .Methods}}
+Methods:
+{{range .Methods}}
+- ` + "`{{.}}`" + `
+{{- end}}
+{{end}}
+Mocks:
+{{range .Mocks}}
+- ` + "`{{.Name}}` in `{{.File}}`" + `
+{{- if .UsedBy}}, used by:
+{{- range .UsedBy}}
+  - ` + "`{{.}}`" + `
+{{- end}}
+{{- else}}, used by no test
+{{- end}}
+{{- else}}
+- none found
+{{- end}}
+{{end -}}
+`
+
+// language=GoTemplate
+const docsHTMLTemplate = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>Mocked Interfaces</title>
+</head>
+<body>
+<h1>Mocked Interfaces</h1>
+{{- range .}}
+<h2 id="{{.Interface}}">{{.Interface}}</h2>
+{{- if .Pos}}
+<p>Declared in <code>{{.Pos}}</code>.</p>
+{{- end}}
+{{- if .Methods}}
+<p>Methods:</p>
+<ul>
+{{- range .Methods}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- end}}
+<p>Mocks:</p>
+<ul>
+{{- range .Mocks}}
+<li><code>{{.Name}}</code> in <code>{{.File}}</code>
+{{- if .UsedBy}}, used by:
+<ul>
+{{- range .UsedBy}}
+<li><code>{{.}}</code></li>
+{{- end}}
+</ul>
+{{- else}}, used by no test
+{{- end}}
+</li>
+{{- else}}
+<li>none found</li>
+{{- end}}
+</ul>
+{{- end}}
+</body>
+</html>
+`
+
+func runDocs(args []string) error {
+	fs := flag.NewFlagSet("docs", flag.ExitOnError)
+	format := fs.String("format", docsMarkdown, "format of the summary: markdown or html")
+	outFile := fs.String("out", "", "output file (default stdout)")
+	fs.Usage = func() {
+		fmt.Println(`mirip docs [flags] [module-dir]`)
+		fs.PrintDefaults()
+		fmt.Println(`Summarises the interfaces mocked by files generated by mirip in the module: their methods,`)
+		fmt.Println(`the files of their mocks and the test files using these.`)
+		fmt.Println(`Ex: mirip docs -format html -out mocks.html .`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return usageError{"expected at most a module dir"}
+	}
+	if *format != docsMarkdown && *format != docsHTML {
+		return usageError{"unknown format: " + *format}
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	mocked, err := suggest.Inventory(dir)
+	if err != nil {
+		return err
+	}
+	ifaces := make([]docsInterface, len(mocked))
+	for i, m := range mocked {
+		ifaces[i] = docsInterface{Interface: m.Interface, Methods: m.Methods}
+		if m.Pos.IsValid() {
+			ifaces[i].Pos = fmt.Sprintf("%s:%d", filepath.ToSlash(shownPath(root, m.Pos.Filename)), m.Pos.Line)
+		}
+		for _, mock := range m.Mocks {
+			d := docsMock{Name: mock.Name, File: filepath.ToSlash(shownPath(root, mock.File))}
+			for _, name := range mock.UsedBy {
+				d.UsedBy = append(d.UsedBy, filepath.ToSlash(shownPath(root, name)))
+			}
+			ifaces[i].Mocks = append(ifaces[i].Mocks, d)
+		}
+	}
+
+	var buf bytes.Buffer
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		out = &buf
+	}
+	if *format == docsHTML {
+		err = htmltemplate.Must(htmltemplate.New("docs").Parse(docsHTMLTemplate)).Execute(out, ifaces)
+	} else {
+		err = template.Must(template.New("docs").Parse(docsMarkdownTemplate)).Execute(out, ifaces)
+	}
+	if err != nil || *outFile == "" {
+		return err
+	}
+	return writeFile(*outFile, buf.Bytes())
+}
@@ -0,0 +1,89 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// Exit codes of mirip, by category of failure, for scripts to branch on.
+const (
+	exitError    = 1 // any other failure, such as generated code not formatting
+	exitUsage    = 2 // invalid arguments or flags
+	exitLoad     = 3 // the source package couldn't be loaded
+	exitNotFound = 4 // an interface, type or function wasn't found
+	exitWrite    = 5 // an output file couldn't be written
+	exitDrift    = 6 // -check found an output file out of date
+	exitBuild    = 7 // -verify-build found compiler errors in the output files
+)
+
+// usageError is an error in the arguments given to mirip.
+type usageError struct {
+	msg string
+}
+
+func (e usageError) Error() string {
+	return e.msg
+}
+
+// writeError is a failure to write or remove an output file.
+type writeError struct {
+	err error
+}
+
+func (e writeError) Error() string {
+	return e.err.Error()
+}
+
+func (e writeError) Unwrap() error {
+	return e.err
+}
+
+// driftError is returned by -check for an output file which isn't up to
+// date, along with the version skew of its generation, if any.
+type driftError struct {
+	name string
+	skew string
+}
+
+func (e driftError) Error() string {
+	if e.skew != "" {
+		return fmt.Sprintf("%s is out of date, %s", e.name, e.skew)
+	}
+	return fmt.Sprintf("%s is out of date", e.name)
+}
+
+// exitCode returns the exit code for the category of err.
+func exitCode(err error) int {
+	var failuresErr failuresError
+	if errors.As(err, &failuresErr) {
+		return failuresErr.exitCode()
+	}
+
+	var (
+		usageErr    usageError
+		configErr   *mirip.ConfigError
+		loadErr     *registry.LoadError
+		notFoundErr *registry.NotFoundError
+		writeErr    writeError
+		driftErr    driftError
+		buildErr    *registry.BuildError
+	)
+	switch {
+	case errors.As(err, &usageErr), errors.As(err, &configErr):
+		return exitUsage
+	case errors.As(err, &loadErr):
+		return exitLoad
+	case errors.As(err, &notFoundErr):
+		return exitNotFound
+	case errors.As(err, &writeErr):
+		return exitWrite
+	case errors.As(err, &driftErr):
+		return exitDrift
+	case errors.As(err, &buildErr):
+		return exitBuild
+	}
+	return exitError
+}
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// expandArgs replaces the references to environment variables in the
+// arguments, in the format $VAR or ${VAR}, with their values, such as the
+// $GOFILE, $GOPACKAGE and $GOLINE of go generate or the paths given by CI,
+// for the arguments of config files or run outside of go generate. $$ is
+// a $. Referring to a variable which isn't set is an error.
+func expandArgs(args []string) ([]string, error) {
+	expanded := make([]string, len(args))
+	for i, arg := range args {
+		var undefined []string
+		expanded[i] = os.Expand(arg, func(name string) string {
+			if name == "$" {
+				return "$"
+			}
+			value, ok := os.LookupEnv(name)
+			if !ok {
+				undefined = append(undefined, name)
+			}
+			return value
+		})
+		if len(undefined) != 0 {
+			return nil, usageError{fmt.Sprintf("undefined variable %s in %s", undefined[0], arg)}
+		}
+	}
+	return expanded, nil
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// output is an output file rendered, to be written once every output file
+// of the run was rendered.
+type output struct {
+	namePairs []string
+	outFile   string
+	content   []byte
+	remove    bool
+}
+
+// failure is the failure to generate the output file of interfaces, in
+// the format 'interface' or 'interface:alias'.
+type failure struct {
+	namePairs []string
+	pos       string
+	err       error
+}
+
+// failuresError reports every output file which failed in a run, once
+// all of them were generated.
+type failuresError []failure
+
+func (e failuresError) Error() string {
+	if len(e) == 1 {
+		return e[0].err.Error()
+	}
+
+	lines := make([]string, 0, len(e)+1)
+	for _, f := range e {
+		names := strings.Join(f.namePairs, ", ")
+		if f.pos != "" {
+			names = f.pos + ": " + names
+		}
+		lines = append(lines, fmt.Sprintf("%s: %v", names, f.err))
+	}
+	lines = append(lines, fmt.Sprintf("%d output files failed", len(e)))
+	return strings.Join(lines, "\n")
+}
+
+// exitCode returns the exit code of the failures, the one of their
+// category when they all have the same, and exitError otherwise.
+func (e failuresError) exitCode() int {
+	code := exitCode(e[0].err)
+	for _, f := range e[1:] {
+		if exitCode(f.err) != code {
+			return exitError
+		}
+	}
+	return code
+}
+
+// generation collects the output files rendered in a run and the
+// failures of the others, so that a failure doesn't stop the generation
+// of the other output files.
+type generation struct {
+	files    []output
+	failures failuresError
+}
+
+// add adds the output file of the interfaces, stdout if outFile is empty.
+// It is removed before being written if remove is set.
+func (g *generation) add(namePairs []string, outFile string, content []byte, remove bool) {
+	g.files = append(g.files, output{namePairs: namePairs, outFile: outFile, content: content, remove: remove})
+}
+
+// fail records the failure of the output file of the interfaces of the
+// mocker, along with the position of the first interface.
+func (g *generation) fail(m *mirip.Mocker, namePairs []string, outFile string, err error) {
+	var pos string
+	name, _, _ := mirip.ParseNamePair(namePairs[0])
+	if p, ok := m.InterfacePos(name); ok {
+		if wd, err := os.Getwd(); err == nil {
+			p.Filename = shownPath(wd, p.Filename)
+		}
+		pos = p.String()
+	}
+	g.failures = append(g.failures, failure{namePairs: namePairs, pos: pos, err: err})
+	recordFailure(namePairs, outFile, err)
+}
+
+// write writes the output files, even if others failed, and returns the
+// failures. With -atomic-run, the files are only staged, and discarded
+// along with the others on failure.
+func (g *generation) write() error {
+	for _, out := range g.files {
+		if out.outFile == "" {
+			if _, err := os.Stdout.Write(out.content); err != nil {
+				return writeError{err}
+			}
+			continue
+		}
+		// The staged files are renamed over the output files.
+		if out.remove && writesFiles() && staged == nil {
+			if err := os.Remove(osPath(out.outFile)); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return writeError{err}
+			}
+		}
+		status, err := writeOutput(out.outFile, out.content)
+		recordResults(out.namePairs, out.outFile, status, written(status, len(out.content)))
+		if err != nil {
+			g.failures = append(g.failures, failure{namePairs: out.namePairs, err: err})
+		}
+	}
+	if len(g.failures) != 0 {
+		return g.failures
+	}
+	return nil
+}
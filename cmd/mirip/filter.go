@@ -0,0 +1,113 @@
+package main
+
+import (
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// globs are the patterns of a repeatable flag, in the syntax of
+// path.Match.
+type globs []string
+
+func (g globs) String() string {
+	return strings.Join(g, ",")
+}
+
+func (g *globs) Set(value string) error {
+	if _, err := path.Match(strings.TrimSuffix(value, "/..."), ""); err != nil {
+		return err
+	}
+	*g = append(*g, value)
+	return nil
+}
+
+// filter selects the interfaces discovered in the packages matched by
+// package patterns, with the globs of -include and -exclude. A glob is
+// matched against the name of the interface, the name of the file
+// declaring it when it ends with .go, or the source dir, relative and
+// slash-separated, when it holds a slash, a trailing '/...' matching the
+// directories under it too. An interface is kept if it matches none of
+// the excluded globs and, for each kind of glob included, one of those.
+type filter struct {
+	include, exclude globs
+}
+
+// isEmpty returns whether the filter keeps every interface.
+func (f filter) isEmpty() bool {
+	return len(f.include) == 0 && len(f.exclude) == 0
+}
+
+// keepsDir returns whether the interfaces of the source dir may be kept,
+// before its package is loaded.
+func (f filter) keepsDir(dir string) bool {
+	dir = strings.TrimPrefix(filepath.ToSlash(filepath.Clean(dir)), "./")
+	return f.keeps(isDirGlob, func(glob string) bool {
+		return matchDir(glob, dir)
+	})
+}
+
+// keepsInterface returns whether the interface of the given name,
+// declared in the given file, is kept.
+func (f filter) keepsInterface(name, file string) bool {
+	file = filepath.Base(file)
+	return f.keeps(isFileGlob, func(glob string) bool {
+		ok, _ := path.Match(glob, file)
+		return ok
+	}) && f.keeps(isNameGlob, func(glob string) bool {
+		ok, _ := path.Match(glob, name)
+		return ok
+	})
+}
+
+// keeps returns whether something matching the globs of the given kind
+// with match is kept.
+func (f filter) keeps(kind func(string) bool, match func(string) bool) bool {
+	for _, glob := range f.exclude {
+		if kind(glob) && match(glob) {
+			return false
+		}
+	}
+
+	included := true
+	for _, glob := range f.include {
+		if !kind(glob) {
+			continue
+		}
+		if match(glob) {
+			return true
+		}
+		included = false
+	}
+	return included
+}
+
+func isDirGlob(glob string) bool {
+	return strings.Contains(filepath.ToSlash(glob), "/")
+}
+
+func isFileGlob(glob string) bool {
+	return !isDirGlob(glob) && strings.HasSuffix(glob, ".go")
+}
+
+func isNameGlob(glob string) bool {
+	return !isDirGlob(glob) && !isFileGlob(glob)
+}
+
+// matchDir returns whether the slash-separated dir matches the glob, or
+// one of its parents does when the glob ends with '/...'. The glob may be
+// separated by backslashes on Windows.
+func matchDir(glob, dir string) bool {
+	glob = strings.TrimPrefix(filepath.ToSlash(glob), "./")
+	prefix := strings.TrimSuffix(glob, "/...")
+	recursive := prefix != glob
+	for {
+		if ok, _ := path.Match(prefix, dir); ok {
+			return true
+		}
+		if !recursive || dir == "." || dir == "/" {
+			return false
+		}
+		dir = path.Dir(dir)
+	}
+}
@@ -0,0 +1,106 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/module"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runFix(args []string) error {
+	fs := flag.NewFlagSet("fix", flag.ExitOnError)
+	from := fs.String("from", "", "import path of the package before its move")
+	to := fs.String("to", "", "import path of the package after its move")
+	fs.Usage = func() {
+		fmt.Println(`mirip fix -from old/pkg -to new/pkg [dir [dir2 [...]]]`)
+		fs.PrintDefaults()
+		fmt.Println(`Rewrites the files generated by mirip in the directories, ./... by default, for the packages of -from,`)
+		fmt.Println(`and those nested in it, moved to -to: their imports and the interfaces recorded in the stamps.`)
+		fmt.Println(`A directory ending in /... includes its subdirectories.`)
+		fmt.Println(`Ex: mirip fix -from example.com/app/store -to example.com/app/internal/store ./...`)
+	}
+	_ = fs.Parse(args)
+
+	if *from == "" || *to == "" {
+		fs.Usage()
+		return usageError{"-from and -to are required"}
+	}
+	for _, p := range []string{*from, *to} {
+		if err := module.CheckImportPath(p); err != nil {
+			return usageError{err.Error()}
+		}
+	}
+
+	dirs := fs.Args()
+	if len(dirs) == 0 {
+		dirs = []string{"./..."}
+	}
+	var files []string
+	for _, dir := range dirs {
+		found, err := generatedIn(dir)
+		if err != nil {
+			return err
+		}
+		files = append(files, found...)
+	}
+
+	fixed := 0
+	for _, name := range files {
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		moved, changed, err := mirip.MovePackage(content, *from, *to)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		if !changed {
+			continue
+		}
+		if err := writeFile(name, moved); err != nil {
+			return err
+		}
+		fmt.Printf("fixed %s\n", name)
+		fixed++
+	}
+	fmt.Printf("%d files generated by mirip fixed for the move of %s to %s\n", fixed, *from, *to)
+	return nil
+}
+
+// generatedIn returns the files generated by mirip in dir, and in its
+// subdirectories if it ends in /..., like the patterns of the go command.
+func generatedIn(dir string) ([]string, error) {
+	if root := strings.TrimSuffix(filepath.ToSlash(dir), "/..."); root != filepath.ToSlash(dir) {
+		abs, err := filepath.Abs(osPath(root))
+		if err != nil {
+			return nil, err
+		}
+		rels, err := generatedFiles(abs)
+		if err != nil {
+			return nil, err
+		}
+		files := make([]string, len(rels))
+		for i, rel := range rels {
+			files[i] = filepath.Join(filepath.FromSlash(root), filepath.FromSlash(rel))
+		}
+		return files, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	var files []string
+	for _, e := range entries {
+		name := filepath.Join(dir, e.Name())
+		if !e.IsDir() && strings.HasSuffix(name, ".go") && isGenerated(name) {
+			files = append(files, name)
+		}
+	}
+	return files, nil
+}
@@ -0,0 +1,51 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runIface(args []string) error {
+	fs := flag.NewFlagSet("iface", flag.ExitOnError)
+	outFile := fs.String("out", "", "output file (default stdout)")
+	pkgName := fs.String("pkg", "", "package name (default will infer)")
+	fs.Usage = func() {
+		fmt.Println(`mirip iface [flags] source-dir type [type2 [type3 [...]]]`)
+		fs.PrintDefaults()
+		fmt.Println(`Generates an interface of the exported methods of each type.`)
+		fmt.Println(`Naming the interface is also supported with the format 'type:interface'`)
+		fmt.Println(`Ex: mirip iface -pkg service . Store:Storer`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() < 2 {
+		fs.Usage()
+		return usageError{"not enough arguments"}
+	}
+
+	m, err := mirip.New(mirip.Config{SrcDir: fs.Arg(0), PkgName: *pkgName})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		out = &buf
+	}
+
+	if err := m.Interface(out, fs.Args()[1:]...); err != nil {
+		return err
+	}
+
+	if *outFile == "" {
+		return nil
+	}
+
+	return writeFile(*outFile, buf.Bytes())
+}
@@ -0,0 +1,46 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// isolatedEnv returns the environment of the go command loading the
+// packages with its build and module caches in a new temporary directory,
+// for the sandboxes of CI whose default caches are read-only or shared
+// across jobs, along with the function removing the directory. The caches
+// given by -env are kept. The modules are downloaded writable, with the
+// -modcacherw flag of GOFLAGS, for the directory to be removable.
+func isolatedEnv(env map[string]string) (map[string]string, func(), error) {
+	dir, err := os.MkdirTemp("", "mirip-isolated-")
+	if err != nil {
+		return nil, nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	isolated := make(map[string]string, len(env)+3)
+	for name, value := range env {
+		isolated[name] = value
+	}
+	for _, name := range []string{"GOCACHE", "GOMODCACHE"} {
+		if _, ok := env[name]; ok {
+			continue
+		}
+		isolated[name] = filepath.Join(dir, strings.ToLower(strings.TrimPrefix(name, "GO")))
+		if err := os.Mkdir(isolated[name], 0700); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+	if _, ok := env["GOMODCACHE"]; !ok {
+		goflags, ok := env["GOFLAGS"]
+		if !ok {
+			goflags = os.Getenv("GOFLAGS")
+		}
+		isolated["GOFLAGS"] = strings.TrimSpace(goflags + " -modcacherw")
+	}
+	return isolated, cleanup, nil
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+	"encoding/json"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// Statuses of output files.
+const (
+	statusWritten   = "written"
+	statusUpToDate  = "up-to-date"
+	statusOutOfDate = "out-of-date"
+	statusFailed    = "failed"
+)
+
+// jsonResults collects the results of the output files with -json, to be
+// printed instead of the human-readable output. It is nil otherwise.
+var jsonResults *[]result
+
+// warnings are the warnings of interfaces with -json, keyed by interface
+// name, to be attached to their results.
+var warnings = make(map[string][]string)
+
+// result is the outcome of generating the code for an interface.
+type result struct {
+	Interface string   `json:"interface"`
+	OutFile   string   `json:"out_file"`
+	Status    string   `json:"status"`
+	Bytes     int      `json:"bytes"`
+	Warnings  []string `json:"warnings,omitempty"`
+	Error     string   `json:"error,omitempty"`
+}
+
+// report is the output of mirip with -json.
+type report struct {
+	Results  []result  `json:"results"`
+	Stats    *runStats `json:"stats,omitempty"`
+	Error    string    `json:"error,omitempty"`
+	ExitCode int       `json:"exit_code"`
+}
+
+// recordResults records the outcome of the output file generated for the
+// given interfaces, in the format 'interface' or 'interface:alias'.
+func recordResults(namePairs []string, outFile, status string, written int) {
+	recordOutput(namePairs, outFile)
+	stats.addFile(namePairs, status)
+	if jsonResults == nil {
+		return
+	}
+
+	for _, np := range namePairs {
+		name, _, _ := mirip.ParseNamePair(np)
+		*jsonResults = append(*jsonResults, result{
+			Interface: name,
+			OutFile:   outFile,
+			Status:    status,
+			Bytes:     written,
+			Warnings:  warnings[name],
+		})
+	}
+}
+
+// recordFailure records the failure of the output file generated for the
+// given interfaces, which isn't written.
+func recordFailure(namePairs []string, outFile string, err error) {
+	stats.addFile(namePairs, statusFailed)
+	if jsonResults == nil {
+		return
+	}
+
+	for _, np := range namePairs {
+		name, _, _ := mirip.ParseNamePair(np)
+		*jsonResults = append(*jsonResults, result{
+			Interface: name,
+			OutFile:   outFile,
+			Status:    statusFailed,
+			Warnings:  warnings[name],
+			Error:     err.Error(),
+		})
+	}
+}
+
+// printReport prints the recorded results along with the error, if any,
+// as JSON on stdout.
+func printReport(err error) {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	_ = enc.Encode(newReport(err))
+}
+
+// newReport returns the report of the recorded results along with the
+// error, if any.
+func newReport(err error) report {
+	r := report{Results: *jsonResults, Stats: stats}
+	if r.Results == nil {
+		r.Results = []result{}
+	}
+	if err != nil {
+		r.Error = err.Error()
+		r.ExitCode = exitCode(err)
+	}
+	return r
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// parseKinds returns the kinds of -kinds, separated by commas, checking
+// that each output file of a kind is its own.
+func parseKinds(flags userFlags) ([]string, error) {
+	switch {
+	case flags.kind != mirip.KindMock:
+		return nil, usageError{"-kind and -kinds are exclusive"}
+	case flags.outDir == "" && !strings.Contains(flags.outFile, mirip.KindPlaceholder):
+		return nil, usageError{"-kinds requires -out-dir or -out with " + mirip.KindPlaceholder}
+	case flags.registry:
+		return nil, usageError{"-registry can't be used with -kinds"}
+	}
+
+	kinds := strings.Split(flags.kinds, ",")
+	seen := make(map[string]bool, len(kinds))
+	for _, kind := range kinds {
+		if seen[kind] {
+			return nil, usageError{fmt.Sprintf("kind %s is given twice to -kinds", kind)}
+		}
+		seen[kind] = true
+	}
+	return kinds, nil
+}
+
+// withKinds returns a config of each of the given kinds for each source
+// dir, along with the source dir of each config. The mocks of each kind
+// written to the output directory are in '<interface>_<kind>.go' files.
+func withKinds(cfgs []mirip.Config, srcs sources, kinds []string) ([]mirip.Config, sources) {
+	kindCfgs := make([]mirip.Config, 0, len(cfgs)*len(kinds))
+	kindSrcs := make(sources, 0, len(cfgs)*len(kinds))
+	for i, cfg := range cfgs {
+		for _, kind := range kinds {
+			cfg.Kind = kind
+			if cfg.OutDir != "" {
+				cfg.OutFile = mirip.InterfacePlaceholder + "_" + mirip.KindPlaceholder + ".go"
+			}
+			kindCfgs = append(kindCfgs, cfg)
+			kindSrcs = append(kindSrcs, srcs[i])
+		}
+	}
+	return kindCfgs, kindSrcs
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runList(args []string) error {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	cacheDir := fs.String("cache", os.Getenv("MIRIP_CACHE"), "directory caching the type information of source packages between runs (default $MIRIP_CACHE)")
+	fs.Usage = func() {
+		fmt.Println(`mirip list [flags] [source-dir]`)
+		fs.PrintDefaults()
+		fmt.Println(`Lists the interfaces which can be mocked in the package, in the current directory by default.`)
+		fmt.Println(`Ex: mirip list ./store`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return usageError{"expected at most one source directory"}
+	}
+
+	srcDir := "."
+	if fs.NArg() == 1 {
+		srcDir = fs.Arg(0)
+	}
+
+	m, err := mirip.New(mirip.Config{SrcDir: srcDir, CacheDir: *cacheDir})
+	if err != nil {
+		return err
+	}
+
+	for _, name := range m.Interfaces() {
+		fmt.Println(name)
+	}
+	return nil
+}
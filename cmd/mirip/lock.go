@@ -0,0 +1,140 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// lockFile records the output files generated by mirip, for mirip verify
+// to detect the missing, stale and orphaned ones.
+type lockFile struct {
+	Files []lockEntry `json:"files"`
+}
+
+// lockEntry is an output file, relative to the directory of the lock
+// file, along with the command which generated it: its arguments when run
+// from Dir, relative to the directory of the lock file too. Hash is the
+// hash of the content of the file.
+type lockEntry struct {
+	File       string   `json:"file"`
+	Interfaces []string `json:"interfaces"`
+	Dir        string   `json:"dir"`
+	Args       []string `json:"args"`
+	Hash       string   `json:"hash"`
+}
+
+// outputs are the interfaces of the output files of the run, keyed by
+// absolute output file, for the lock file.
+var outputs = make(map[string][]string)
+
+// recordOutput records the output file generated for the given
+// interfaces, in the format 'interface' or 'interface:alias'.
+func recordOutput(namePairs []string, outFile string) {
+	if abs, err := filepath.Abs(outFile); err == nil {
+		outFile = abs
+	}
+	for _, np := range namePairs {
+		name, _, _ := mirip.ParseNamePair(np)
+		outputs[outFile] = append(outputs[outFile], name)
+	}
+}
+
+// updateLock records the output files of the run of the given arguments
+// in the lock file of the given name, replacing the entries of previous
+// runs with the same arguments from the same directory.
+func updateLock(name string, args []string) error {
+	// The lock file is shared by the machines checking the mocks, which may
+	// run other systems than the one generating them.
+	slashedArgs := make([]string, len(args))
+	for i, arg := range args {
+		slashedArgs[i] = slashed(arg)
+	}
+	args = slashedArgs
+
+	lock, err := readLock(name)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+
+	root, err := filepath.Abs(filepath.Dir(name))
+	if err != nil {
+		return err
+	}
+	dir, err := relPath(root, ".")
+	if err != nil {
+		return err
+	}
+	files := lock.Files[:0]
+	for _, e := range lock.Files {
+		if e.Dir != dir || strings.Join(e.Args, "\x00") != strings.Join(args, "\x00") {
+			files = append(files, e)
+		}
+	}
+	for outFile, interfaces := range outputs {
+		file, err := relPath(root, outFile)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFile(outFile)
+		if err != nil {
+			return err
+		}
+		files = append(files, lockEntry{File: file, Interfaces: interfaces, Dir: dir, Args: args, Hash: hash})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].File < files[j].File
+	})
+	lock.Files = files
+
+	content, err := json.MarshalIndent(lock, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(name, append(content, '\n'))
+}
+
+// readLock reads the lock file of the given name.
+func readLock(name string) (lockFile, error) {
+	var lock lockFile
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return lock, err
+	}
+	if err := json.Unmarshal(content, &lock); err != nil {
+		return lock, fmt.Errorf("invalid lock file %s: %w", name, err)
+	}
+	return lock, nil
+}
+
+// relPath returns the path relative to root, with slashes.
+func relPath(root, path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return "", err
+	}
+	return filepath.ToSlash(rel), nil
+}
+
+// hashFile returns the hash of the content of the file, in the format
+// 'sha256:hex'.
+func hashFile(name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+	"time"
+)
+
+// runLog is the log file of -log-file, nil without it. Its methods are
+// no-ops on nil.
+var runLog *logFile
+
+// logFile writes the structured logs of the run to a file as JSON lines,
+// at the debug level whatever the verbosity of the console, for users to
+// attach a complete trace to bug reports.
+type logFile struct {
+	mu  sync.Mutex
+	f   *os.File
+	enc *json.Encoder
+}
+
+// openLog creates the log file of the given name, replacing any previous
+// one, and logs the start of the run.
+func openLog(name string) (*logFile, error) {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	l := &logFile{f: f, enc: json.NewEncoder(f)}
+	wd, _ := os.Getwd()
+	l.log("info", "mirip version "+Version, map[string]interface{}{
+		"args": os.Args[1:],
+		"dir":  wd,
+		"go":   runtime.Version(),
+		"os":   runtime.GOOS + "/" + runtime.GOARCH,
+	})
+	return l, nil
+}
+
+// log writes an entry of the given level and message, along with the
+// given fields, on a line.
+func (l *logFile) log(level, msg string, fields map[string]interface{}) {
+	if l == nil {
+		return
+	}
+	entry := map[string]interface{}{
+		"time":  time.Now().Format(time.RFC3339Nano),
+		"level": level,
+		"msg":   msg,
+	}
+	for k, v := range fields {
+		entry[k] = v
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_ = l.enc.Encode(entry)
+}
+
+// debugf logs a formatted message at the debug level.
+func (l *logFile) debugf(format string, args ...interface{}) {
+	l.log("debug", fmt.Sprintf(format, args...), nil)
+}
+
+// close logs the end of the run, with its error if any, and closes the
+// file.
+func (l *logFile) close(err error) error {
+	if l == nil {
+		return nil
+	}
+	if err != nil {
+		l.log("error", err.Error(), map[string]interface{}{"exit": exitCode(err)})
+	} else {
+		l.log("info", "done", nil)
+	}
+	return l.f.Close()
+}
@@ -5,10 +5,13 @@ import (
 	"errors"
 	"flag"
 	"fmt"
-	"io"
 	"os"
 	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
 
+	"github.com/gmhafiz/mirip/internal/diff"
 	"github.com/gmhafiz/mirip/internal/mirip"
 )
 
@@ -16,88 +19,1153 @@ import (
 var Version = "dev"
 
 type userFlags struct {
-	outFile    string
-	pkgName    string
-	formatter  string
-	stubImpl   bool
-	skipEnsure bool
-	remove     bool
-	args       []string
+	outFile        string
+	outDir         string
+	outModule      string
+	sources        sources
+	fileCase       string
+	example        string
+	conformance    string
+	bench          string
+	benchImpl      string
+	streams        bool
+	verifyBuild    bool
+	template       string
+	config         string
+	configProfile  string
+	pkgName        string
+	prefix         string
+	suffix         string
+	aliases        string
+	qualifiers     string
+	importAliases  keyValues
+	srcAlias       string
+	runtimeAlias   string
+	paramNames     string
+	typeNames      keyValues
+	conflictSuffix string
+	conflicts      string
+	receiver       string
+	callsField     string
+	lockField      string
+	kind           string
+	kinds          string
+	provider       string
+	flavor         string
+	formatter      string
+	stub           string
+	coverage       bool
+	fluent         bool
+	clock          bool
+	notImplemented bool
+	report         bool
+	lockPerMethod  bool
+	light          bool
+	recordSwitch   bool
+	builder        bool
+	verifyAll      bool
+	builderSpec    string
+	golden         bool
+	callTypes      bool
+	registry       bool
+	shared         bool
+	assert         string
+	assertContext  bool
+	honorContext   bool
+	contextKeys    stringList
+	methods        stringList
+	calledBy       string
+	skipEnsure     bool
+	ensureForm     string
+	ensureAliases  bool
+	ensureFile     string
+	noStamp        bool
+	anonymousTypes bool
+	cacheDir       string
+	allowErrs      bool
+	strict         bool
+	goos           string
+	env            keyValues
+	modFile        string
+	tags           string
+	isolated       bool
+	chdir          string
+	goarch         string
+	variants       string
+	overlay        string
+	remove         bool
+	atomicRun      bool
+	doc            bool
+	lock           string
+	stdout         string
+	jobs           int
+	json           bool
+	stats          bool
+	verbose        bool
+	logFile        string
+	version        bool
+	include        globs
+	exclude        globs
+	args           []string
+	profile        profileFlags
+
+	// argv are the arguments of the command, and cmdline those recorded
+	// in the stamp of the mocks.
+	argv    []string
+	cmdline []string
+}
+
+// register defines the flags of the main command on fs.
+func (f *userFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.outFile, "out", "", "output file (default stdout), with "+mirip.InterfacePlaceholder+" for one file per interface or "+mirip.FilePlaceholder+" per source file")
+	fs.StringVar(&f.config, "config", "", "JSON file of the interfaces to mock along with their flags, overriding its global ones, instead of the arguments")
+	fs.StringVar(&f.configProfile, "profile", "", "profile of the -config file overriding its flags (default \"default\", if any)")
+	fs.Var(&f.sources, "src", "source dir and its interfaces in the format 'dir=Interface,Interface2' instead of the arguments, may be repeated")
+	fs.Var(&f.include, "include", "glob of the interfaces, their files (*.go) or their packages (with a /) to mock in package patterns, may be repeated")
+	fs.Var(&f.exclude, "exclude", "glob of the interfaces, their files (*.go) or their packages (with a /) not to mock in package patterns, may be repeated")
+	fs.StringVar(&f.outDir, "out-dir", "", "output directory, writing one "+mirip.InterfacePlaceholder+"_mock.go file per interface in the package of the directory")
+	fs.StringVar(&f.outModule, "out-module", "", "directory of a dedicated module of the output files, whose go.mod requiring the parent module is written if missing")
+	fs.StringVar(&f.fileCase, "file-case", mirip.FileCaseSnake, "case of the interface names in output files: snake, kebab or lower")
+	fs.StringVar(&f.example, "example", "", "also write Example functions for the mocks to the given _test.go file")
+	fs.StringVar(&f.conformance, "conformance", "", "also write a TestXConformance function for each interface to the given file, for verifying implementations")
+	fs.BoolVar(&f.skipEnsure, "skip-ensure", false, "don't assert that the mocks implement their interface")
+	fs.StringVar(&f.ensureForm, "ensure-form", mirip.EnsureAddr, "value asserted to implement the interface: addr (&XMock{}) or nil ((*XMock)(nil))")
+	fs.BoolVar(&f.ensureAliases, "ensure-aliases", false, "also assert that the mocks implement the aliases of their interface declared in its package")
+	fs.StringVar(&f.ensureFile, "ensure-file", "", "write the assertions that the mocks implement their interface to the given file, such as a _test.go file, instead")
+	fs.StringVar(&f.bench, "bench", "", "also write benchmarks of the methods of the mocks to the given _test.go file")
+	fs.StringVar(&f.benchImpl, "bench-impl", "", "function of the source package returning an implementation of the interface, which -bench also measures")
+	fs.BoolVar(&f.verifyBuild, "verify-build", false, "type-check the output files in their packages, failing with the compiler errors in them")
+	fs.BoolVar(&f.streams, "streams", false, "also mock the gRPC stream interfaces the methods of the interfaces return or take")
+	fs.StringVar(&f.pkgName, "pkg", "", "package name (default will infer)")
+	fs.StringVar(&f.prefix, "prefix", "", "prefix of the mock names")
+	fs.StringVar(&f.suffix, "suffix", "", "suffix of the mock names (default per kind, unless -prefix is set)")
+	fs.StringVar(&f.aliases, "aliases", "", "file mapping interfaces to mock names, one 'Interface=Alias' per line")
+	fs.StringVar(&f.qualifiers, "qualifiers", mirip.QualifierPath, "naming of the imports whose names collide: path (v2client) or number (client2)")
+	fs.Var(&f.importAliases, "import-alias", "qualifier of an import in the format 'path=alias', may be repeated")
+	fs.StringVar(&f.srcAlias, "src-alias", "", "qualifier of the source package when the mocks import it, such as domain")
+	fs.StringVar(&f.runtimeAlias, "runtime-alias", "", "qualifier of the runtime of -not-implemented and -report (default mirip, or miripruntime when the name is taken)")
+	fs.StringVar(&f.conflictSuffix, "conflict-suffix", mirip.DefaultConflictSuffix, "suffix of the parameter names which would conflict with an import, a keyword or a type")
+	fs.StringVar(&f.conflicts, "conflicts", mirip.ConflictNumber, "naming of the parameters and generated identifiers whose names collide: number (s1, s2), underscore (s_, s__) or letter (sA, sB)")
+	fs.StringVar(&f.paramNames, "param-names", mirip.ParamNamesType, "naming of unnamed parameters: type (ctx, s) or position (arg1, arg2)")
+	fs.Var(&f.typeNames, "type-name", "name of the unnamed parameters of a type in the format 'path.Name=name' or '*path.Name=name', may be repeated")
+	fs.StringVar(&f.receiver, "receiver", "m", "name of the receivers of the methods of mocks")
+	fs.StringVar(&f.callsField, "calls-field", "calls", "name of the field of mocks recording calls")
+	fs.StringVar(&f.lockField, "lock-field", "lock", "name of the field of mocks locking calls")
+	fs.BoolVar(&f.light, "light", false, "only count the calls of the mocks atomically, without recording their arguments")
+	fs.BoolVar(&f.recordSwitch, "toggle-recording", false, "add DisableRecording, EnableRecording and MaxCalls to the mocks, bounding the calls they record")
+	fs.BoolVar(&f.registry, "registry", false, "also declare Mocks, mapping the names of the interfaces to constructors of their mocks")
+	fs.BoolVar(&f.shared, "shared", false, "also generate a SharedXMock(t) accessor for each mock, returning the mock of the test for package-level fixtures")
+	fs.BoolVar(&f.builder, "builder", false, "also generate an XMockBuilder for each mock, configuring its Funcs and expected calls")
+	fs.BoolVar(&f.verifyAll, "verify-all", false, "with -builder, also declare VerifyAll, running the verifications of several builders and reporting all their failures")
+	fs.StringVar(&f.builderSpec, "builder-spec", "", "with -builder, also write a JSON descriptor of the chains of calls of the builders to the given file, for editor completion")
+	fs.BoolVar(&f.golden, "golden", false, "also generate SaveCallsGolden and AssertCallsGolden for each mock, comparing its calls with a golden file, updated with -update")
+	fs.BoolVar(&f.callTypes, "call-types", false, "declare the recorded calls of each method as an exported XMock<Method>Call type, for assertion helpers to take")
+	fs.BoolVar(&f.lockPerMethod, "lock-per-method", false, "lock the calls of each method of the mocks with its own lock instead of one per mock")
+	fs.StringVar(&f.template, "template", "", "text/template file replacing the template of the kind, checked by mirip template check")
+	fs.StringVar(&f.kind, "kind", "mock", "kind of code to generate: mock, chaos (fault-injecting wrapper), recorder (recording wrapper and replaying stub), stub (neither recording nor allocating) or fake-builtin (working fakes of io.Reader, net/http.RoundTripper and io/fs.FS)")
+	fs.StringVar(&f.kinds, "kinds", "", "comma-separated kinds to generate in one run, such as mock,stub, each to its own files of -out-dir or -out with "+mirip.KindPlaceholder)
+	fs.StringVar(&f.provider, "provide", "", "also generate ProvideMocks for dependency injection: func, wire or fx")
+	fs.StringVar(&f.flavor, "flavor", "", "add helpers for a test framework to the mocks: ginkgo or testify")
+	fs.StringVar(&f.stub, "stub", "", "return values from methods without a Func instead of panicking: zero, random, fixture (from the Fixture field) or fuzz (decoded from the Fuzz field)")
+	fs.BoolVar(&f.coverage, "coverage", false, "write the called methods of the mocks to the file named by $MIRIP_COVERAGE")
+	fs.BoolVar(&f.clock, "clock", false, "add a Clock field to mocks returning time.Time, giving these results of methods without a Func, with -stub")
+	fs.BoolVar(&f.notImplemented, "not-implemented", false, "return an error wrapping mirip.ErrNotImplemented of github.com/gmhafiz/mirip/runtime from the error results of stubbed methods")
+	fs.BoolVar(&f.report, "report", false, "record the order of the calls to the mocks, for mirip.Report of github.com/gmhafiz/mirip/runtime to print a table of the calls to several mocks")
+	fs.BoolVar(&f.fluent, "fluent", false, "return the mock itself from methods without a Func for the results of the type of the mocked interface")
+	fs.StringVar(&f.assert, "assert", "", "generate Assert<Method>CalledWith methods comparing the arguments of calls: cmp (github.com/google/go-cmp)")
+	fs.BoolVar(&f.assertContext, "assert-context", false, "generate assertions of the deadline and the values of the contexts of the calls of methods taking one first")
+	fs.BoolVar(&f.honorContext, "honor-context", false, "return the error of the context from the methods taking one first and returning an error once it is done, before calling their Func")
+	fs.StringVar(&f.calledBy, "called-by", "", "directory of the package under test: only mock the methods of the interfaces its code and tests call, delegating the others to the embedded interface")
+	fs.Var(&f.methods, "method", "regenerate only the declarations of the existing output files which the given method affects, leaving the others as they are, may be repeated")
+	fs.Var(&f.contextKeys, "context-key", "key of context values, in the format 'path.Name', returned by <Method>ContextValues for each call, may be repeated")
+	fs.BoolVar(&f.anonymousTypes, "name-anonymous", false, "declare named types for the parameters of anonymous struct or interface types, used in the recorded calls")
+	fs.StringVar(&f.cacheDir, "cache", os.Getenv("MIRIP_CACHE"), "directory caching the type information of source packages between runs (default $MIRIP_CACHE)")
+	fs.BoolVar(&f.strict, "strict", false, "fail on the constructs of the interfaces which the mocks degrade on, such as arguments kind recorder can't record, instead of warning")
+	fs.BoolVar(&f.allowErrs, "allow-errors", false, "generate from a source package with errors, as long as the interfaces are fully resolved")
+	fs.StringVar(&f.goos, "goos", "", "load the source package for this GOOS instead of the one of the environment")
+	fs.StringVar(&f.goarch, "goarch", "", "load the source package for this GOARCH instead of the one of the environment")
+	fs.StringVar(&f.variants, "variants", "", "comma-separated platforms, in the format goos or goos/goarch, to generate the mocks for, each to its own files constrained to it")
+	fs.Var(&f.env, "env", "environment variable of the go command loading the packages in the format 'KEY=value', such as GOFLAGS=-tags=e2e, may be repeated")
+	fs.StringVar(&f.modFile, "modfile", "", "go.mod file the packages are loaded with instead of the one of their module, like the -modfile flag of go build")
+	fs.StringVar(&f.tags, "tags", "", "comma-separated build tags the packages are loaded with, like the -tags flag of go build")
+	fs.BoolVar(&f.isolated, "isolated", false, "load the packages with a build cache and a module cache of their own, in a temporary directory removed once done, for hermetic CI sandboxes")
+	fs.StringVar(&f.chdir, "C", "", "change to the given directory before running, which the paths of the other flags and the arguments are relative to, like go -C")
+	fs.StringVar(&f.overlay, "overlay", "", "JSON file replacing the content of source files, in the format of go build -overlay")
+	fs.BoolVar(&f.noStamp, "no-stamp", false, "omit the absolute paths and the time from the provenance in the header of mocks, for reproducible builds")
+	fs.BoolVar(&f.version, "version", false, "show the version for mirip")
+	fs.BoolVar(&f.remove, "rm", false, "first remove output file, if it exists, and with -out-dir the generated files of the output dirs of no interface")
+	fs.BoolVar(&f.atomicRun, "atomic-run", false, "stage the output files in memory, written together only if none fails")
+	fs.StringVar(&f.lock, "lock", "", "record the output files and their hash in the given lock file, for mirip verify")
+	fs.StringVar(&f.stdout, "stdout", "", "write the output files to stdout instead: markers, each after a '// -- file: path --' line, or tar")
+	fs.BoolVar(&f.doc, "doc", false, "also write a doc.go with a package comment to the output directories of new mocks packages")
+	fs.BoolVar(&f.verbose, "v", false, "print the packages loaded, the interfaces resolved, the output files and the time of each phase")
+	fs.BoolVar(&f.verbose, "debug", false, "same as -v")
+	fs.StringVar(&f.logFile, "log-file", "", "write debug logs of the whole run to the given file as JSON lines, whatever the verbosity, to attach to bug reports")
+	fs.BoolVar(&f.json, "json", false, "print the results and errors as JSON, requires -out or -out-dir")
+	fs.BoolVar(&f.stats, "stats", false, "print a summary of the packages loaded, the files generated and the time of each phase on stderr, or with the results of -json")
+	fs.BoolVar(&quiet, "quiet", false, "print nothing but errors")
+	fs.BoolVar(&dryRun, "dry-run", false, "with -rm and -out-dir, print the orphaned generated files of the output dirs instead of removing them")
+	fs.BoolVar(&allowOutsideModule, "allow-outside-module", false, "write the output files outside the repository of the working directory, or its module outside repositories")
+	fs.BoolVar(&checkOnly, "check", false, "check the output files are up to date instead of writing them, exiting with 6 if not")
+	fs.IntVar(&f.jobs, "j", runtime.GOMAXPROCS(0), "number of files generated concurrently, with one file per interface")
+	f.profile.register(fs)
+}
+
+// commands are the subcommands of mirip, keyed by name. Each is invoked
+// with the arguments following its name.
+var commands = map[string]func(args []string) error{
+	"testgen":    runTestgen,
+	"coverage":   runCoverage,
+	"iface":      runIface,
+	"deps":       runDeps,
+	"suggest":    runSuggest,
+	"directives": runDirectives,
+	"docs":       runDocs,
+	"list":       runList,
+	"verify":     runVerify,
+	"serve":      runServe,
+	"template":   runTemplate,
+	"snapshot":   runSnapshot,
+	"fix":        runFix,
+	"unused":     runUnused,
+	"stats":      runMetrics,
+}
+
+func init() {
+	// completion is added here as it lists the commands.
+	commands["completion"] = runCompletion
 }
 
 func main() {
+	enableTypeAliases()
+
+	if len(os.Args) > 1 {
+		if cmd, ok := commands[os.Args[1]]; ok {
+			if err := cmd(os.Args[2:]); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+				os.Exit(exitCode(err))
+			}
+			return
+		}
+	}
+
 	var flags userFlags
-	flag.StringVar(&flags.outFile, "out", "", "output file (default stdout)")
-	flag.StringVar(&flags.pkgName, "pkg", "", "package name (default will infer)")
-	printVersion := flag.Bool("version", false, "show the version for mirip")
-	flag.BoolVar(&flags.remove, "rm", false, "first remove output file, if it exists")
+	flags.register(flag.CommandLine)
 
 	flag.Usage = func() {
 		fmt.Println(`mirip [flags] source-dir interface [interface2 [interface3 [...]]]`)
-		flag.PrintDefaults()
+		fmt.Println(`mirip [flags] -src source-dir=interface[,interface2] [-src ...]`)
+		printDefaults(flag.CommandLine)
 		fmt.Println(`Specifying an alias for the mock is also supported with the format 'interface:alias'`)
+		fmt.Println(`The source dir - reads a single Go file from stdin, mocked to stdout`)
 		fmt.Println(`Ex: mirip -pkg different . MyInterface:MyMock`)
 	}
 
-	flag.Parse()
+	args, err := expandArgs(os.Args[1:])
+	if err != nil {
+		_, _ = fmt.Fprintln(os.Stderr, err)
+		os.Exit(exitUsage)
+	}
+	_ = flag.CommandLine.Parse(args)
 	flags.args = flag.Args()
+	flags.argv = os.Args[1:]
+	flags.cmdline = stampArgs(flag.CommandLine, os.Args[1:])
 
-	if *printVersion {
+	if flags.version {
 		fmt.Printf("mirip version %s\n", Version)
 		os.Exit(0)
 	}
 
-	if err := run(flags); err != nil {
+	if flags.chdir != "" {
+		if err := os.Chdir(flags.chdir); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitUsage)
+		}
+	}
+
+	if flags.json {
+		jsonResults = &[]result{}
+	}
+	if flags.stats {
+		stats = newRunStats()
+	}
+	if flags.logFile != "" {
+		if runLog, err = openLog(flags.logFile); err != nil {
+			_, _ = fmt.Fprintln(os.Stderr, err)
+			os.Exit(exitCode(writeError{err}))
+		}
+	}
+
+	stopProfiles, err := flags.profile.start()
+	if err == nil {
+		err = execute(flags)
+	}
+	stopProfiles()
+	if closeErr := runLog.close(err); err == nil && closeErr != nil {
+		err = writeError{closeErr}
+	}
+	if stats != nil {
+		stats.finish()
+		if !flags.json {
+			stats.print(os.Stderr)
+		}
+	}
+	if flags.json {
+		printReport(err)
+		if err != nil {
+			os.Exit(exitCode(err))
+		}
+		return
+	}
+	if err != nil {
 		_, _ = fmt.Fprintln(os.Stderr, err)
-		flag.Usage()
-		os.Exit(1)
+		if exitCode(err) == exitUsage {
+			flag.Usage()
+		}
+		os.Exit(exitCode(err))
+	}
+}
+
+// execute runs mirip with the flags, then does what the runs of a config
+// file defer until all of them succeeded: writing the files of -stdout
+// and -atomic-run, and removing the orphaned files of -rm. Their state is
+// reset for the next execution within the process, such as the next
+// request of mirip serve.
+func execute(flags userFlags) error {
+	err := run(flags)
+	if err == nil && stdoutFiles != nil {
+		err = stdoutFiles.close()
 	}
+	if err == nil && staged != nil {
+		err = staged.commit()
+	}
+	if err == nil {
+		err = pruned.prune()
+	}
+	stdoutFiles, staged, pruned, dryRun = nil, nil, newPruning(), false
+	return err
 }
 
 func run(flags userFlags) error {
-	if len(flags.args) < 2 {
-		return errors.New("not enough arguments")
+	if flags.config != "" {
+		return runConfig(flags)
+	}
+	if flags.configProfile != "" {
+		return usageError{"-profile requires -config"}
 	}
 
-	if flags.remove && flags.outFile != "" {
-		if err := os.Remove(flags.outFile); err != nil {
-			if !errors.Is(err, os.ErrNotExist) {
-				return err
+	srcs := flags.sources
+	switch {
+	case len(srcs) == 0 && len(flags.args) == 1 && mirip.IsPattern(flags.args[0]):
+		// All the interfaces of the packages matched by the pattern.
+		srcs = sources{{dir: flags.args[0]}}
+	case len(srcs) == 0 && len(flags.args) < 2:
+		return usageError{"not enough arguments"}
+	case len(srcs) == 0:
+		srcs = sources{{dir: flags.args[0], namePairs: flags.args[1:]}}
+	case len(flags.args) != 0:
+		return usageError{"-src and the source dir argument are exclusive"}
+	}
+
+	for i, src := range srcs {
+		if src.dir != stdinDir {
+			continue
+		}
+		if len(srcs) > 1 || flags.outFile != "" || flags.outDir != "" || flags.example != "" || flags.conformance != "" || flags.bench != "" || flags.ensureFile != "" || flags.builderSpec != "" || flags.pkgName != "" {
+			return usageError{"the source read from stdin must be the only one, mocked to stdout in its package"}
+		}
+		dir, cleanup, err := stdinPackage(os.Stdin)
+		if err != nil {
+			return err
+		}
+		defer cleanup()
+		srcs[i].dir = dir
+	}
+
+	overlay, err := readOverlay(flags.overlay)
+	if err != nil {
+		return err
+	}
+	env, err := loadEnv(flags)
+	if err != nil {
+		return err
+	}
+	if flags.isolated {
+		var cleanup func()
+		if env, cleanup, err = isolatedEnv(env); err != nil {
+			return err
+		}
+		defer cleanup()
+	}
+
+	filter := filter{include: flags.include, exclude: flags.exclude}
+	if !filter.isEmpty() && !hasPattern(srcs) {
+		return usageError{"-include and -exclude require a package pattern"}
+	}
+	srcs, err = expandPatterns(srcs, filter, mirip.Config{GOOS: flags.goos, GOARCH: flags.goarch, Overlay: overlay, Env: env})
+	if err != nil {
+		return err
+	}
+	switch {
+	case flags.doc && flags.outFile == "" && flags.outDir == "":
+		return usageError{"-doc requires -out or -out-dir"}
+	case flags.lock != "" && flags.outFile == "" && flags.outDir == "":
+		return usageError{"-lock requires -out or -out-dir"}
+	case flags.stdout != "" && flags.outFile == "" && flags.outDir == "":
+		return usageError{"-stdout requires -out or -out-dir for the paths of the files"}
+	case flags.stdout != "" && (checkOnly || flags.json || flags.lock != ""):
+		return usageError{"-stdout can't be used with -check, -json or -lock"}
+	case len(srcs) > 1 && flags.outDir == "" && !mirip.IsOutFilePattern(flags.outFile) &&
+		!strings.Contains(flags.outFile, mirip.PackagePlaceholder):
+		return usageError{"more than one source dir requires -out-dir or -out with " +
+			mirip.InterfacePlaceholder + ", " + mirip.FilePlaceholder + " or " + mirip.PackagePlaceholder}
+	case len(srcs) > 1 && flags.example != "":
+		return usageError{"-example requires a single source dir"}
+	case len(srcs) > 1 && flags.builderSpec != "":
+		return usageError{"-builder-spec requires a single source dir"}
+	case flags.builderSpec != "" && !flags.builder:
+		return usageError{"-builder-spec requires -builder"}
+	case len(srcs) > 1 && flags.conformance != "":
+		return usageError{"-conformance requires a single source dir"}
+	case len(srcs) > 1 && flags.bench != "":
+		return usageError{"-bench requires a single source dir"}
+	case len(srcs) > 1 && flags.ensureFile != "":
+		return usageError{"-ensure-file requires a single source dir"}
+	case flags.ensureFile != "" && flags.skipEnsure:
+		return usageError{"-ensure-file and -skip-ensure are exclusive"}
+	case dryRun && (!flags.remove || flags.outDir == ""):
+		return usageError{"-dry-run requires -rm and -out-dir"}
+	case flags.benchImpl != "" && flags.bench == "":
+		return usageError{"-bench-impl requires -bench"}
+	case flags.registry && (flags.outDir != "" || mirip.IsOutFilePattern(flags.outFile)):
+		return usageError{"-registry requires a single output file per package"}
+	case flags.verifyAll && !flags.builder:
+		return usageError{"-verify-all requires -builder"}
+	case flags.verifyAll && (flags.outDir != "" || mirip.IsOutFilePattern(flags.outFile)):
+		return usageError{"-verify-all requires a single output file per package"}
+	}
+	if flags.outFile != "" && flags.outDir != "" {
+		return usageError{"-out and -out-dir are exclusive"}
+	}
+	if flags.json && flags.outFile == "" && flags.outDir == "" {
+		return usageError{"-json requires -out or -out-dir"}
+	}
+	if flags.outModule != "" {
+		if flags.outFile == "" && flags.outDir == "" {
+			return usageError{"-out-module requires -out or -out-dir"}
+		}
+		if err := checkOutModule(flags.outModule, flags.outFile+flags.outDir); err != nil {
+			return err
+		}
+	}
+	var kinds []string
+	if flags.kinds != "" {
+		if kinds, err = parseKinds(flags); err != nil {
+			return err
+		}
+	}
+	var variants []variant
+	if flags.variants != "" {
+		if variants, err = parseVariants(flags); err != nil {
+			return err
+		}
+	}
+	// The runs of a config file share the writer, and the staged files.
+	if flags.stdout != "" && stdoutFiles == nil {
+		if stdoutFiles, err = newStdoutWriter(flags.stdout, flags.noStamp); err != nil {
+			return err
+		}
+	}
+	if flags.atomicRun && staged == nil {
+		staged = newStaging()
+	}
+
+	generated, outputs = nil, make(map[string][]string)
+	if flags.verifyBuild {
+		generated = make(map[string][]byte)
+	}
+
+	var logf func(format string, args ...interface{})
+	if flags.verbose || runLog != nil {
+		logf = func(format string, args ...interface{}) {
+			runLog.debugf(format, args...)
+			if flags.verbose {
+				_, _ = fmt.Fprintf(os.Stderr, "mirip: "+format+"\n", args...)
 			}
 		}
+		verbosef = logf
 	}
 
-	var buf bytes.Buffer
-	var out io.Writer = os.Stdout
-	if flags.outFile != "" {
-		out = &buf
-	}
-
-	srcDir, args := flags.args[0], flags.args[1:]
-	m, err := mirip.New(mirip.Config{
-		SrcDir:     srcDir,
-		PkgName:    flags.pkgName,
-		Formatter:  flags.formatter,
-		StubImpl:   flags.stubImpl,
-		SkipEnsure: flags.skipEnsure,
-	})
+	var timings *mirip.Timings
+	if flags.profile.timings || flags.verbose || stats != nil || runLog != nil {
+		timings = &mirip.Timings{}
+		defer func() {
+			if flags.profile.timings || flags.verbose {
+				_, _ = fmt.Fprintln(os.Stderr, timings)
+			}
+			runLog.log("debug", "timings", map[string]interface{}{"ms": map[string]int64{
+				"load":    timings.Load.Milliseconds(),
+				"resolve": timings.Resolve.Milliseconds(),
+				"render":  timings.Render.Milliseconds(),
+				"format":  timings.Format.Milliseconds(),
+			}})
+			stats.addTimings(timings)
+		}()
+	}
+
+	aliases, err := readAliases(flags.aliases)
+	if err != nil {
+		return err
+	}
+	var tmpl []byte
+	if flags.template != "" {
+		if tmpl, err = os.ReadFile(flags.template); err != nil {
+			return err
+		}
+	}
+
+	stamp := &mirip.Stamp{Version: Version, MinVersion: minVersion, Args: flags.cmdline, Reproducible: flags.noStamp}
+
+	cfgs := make([]mirip.Config, len(srcs))
+	for i, src := range srcs {
+		cfgs[i] = mirip.Config{
+			SrcDir:         src.dir,
+			PkgName:        flags.pkgName,
+			OutFile:        flags.outFile,
+			OutDir:         flags.outDir,
+			FileCase:       flags.fileCase,
+			Prefix:         flags.prefix,
+			Suffix:         flags.suffix,
+			Aliases:        aliases,
+			Kind:           flags.kind,
+			Provider:       flags.provider,
+			Flavor:         flags.flavor,
+			Formatter:      flags.formatter,
+			Stub:           flags.stub,
+			Coverage:       flags.coverage,
+			Fluent:         flags.fluent,
+			Clock:          flags.clock,
+			NotImplemented: flags.notImplemented,
+			Report:         flags.report,
+			LockPerMethod:  flags.lockPerMethod,
+			Light:          flags.light,
+			RecordSwitch:   flags.recordSwitch,
+			Builder:        flags.builder,
+			VerifyAll:      flags.verifyAll,
+			Golden:         flags.golden,
+			CallTypes:      flags.callTypes,
+			Registry:       flags.registry,
+			Shared:         flags.shared,
+			Assert:         flags.assert,
+			AssertContext:  flags.assertContext,
+			HonorContext:   flags.honorContext,
+			ContextKeys:    flags.contextKeys,
+			Methods:        flags.methods,
+			CalledBy:       flags.calledBy,
+			Template:       string(tmpl),
+			Qualifiers:     flags.qualifiers,
+			ImportAliases:  flags.importAliases,
+			SrcAlias:       flags.srcAlias,
+			RuntimeAlias:   flags.runtimeAlias,
+			ConflictSuffix: flags.conflictSuffix,
+			Conflicts:      flags.conflicts,
+			ParamNames:     flags.paramNames,
+			TypeNames:      flags.typeNames,
+			Receiver:       flags.receiver,
+			CallsField:     flags.callsField,
+			LockField:      flags.lockField,
+			SkipEnsure:     flags.skipEnsure || flags.ensureFile != "",
+			EnsureForm:     flags.ensureForm,
+			EnsureAliases:  flags.ensureAliases,
+			Stamp:          stamp,
+			AnonymousTypes: flags.anonymousTypes,
+			CacheDir:       flags.cacheDir,
+			PackageCache:   packageCache,
+			AllowErrors:    flags.allowErrs,
+			GOOS:           flags.goos,
+			GOARCH:         flags.goarch,
+			Env:            env,
+			Overlay:        overlay,
+			Timings:        timings,
+			Logf:           logf,
+			Warn:           warn,
+			Strict:         flags.strict,
+		}
+	}
+	if kinds != nil {
+		cfgs, srcs = withKinds(cfgs, srcs, kinds)
+	}
+	if variants != nil {
+		cfgs, srcs = withVariants(cfgs, srcs, variants)
+	}
+	if flags.outModule != "" {
+		if err := writeOutModule(flags.outModule); err != nil {
+			return err
+		}
+	}
+	mockers, err := newMockers(cfgs)
 	if err != nil {
 		return err
 	}
+	stats.addPackages(cfgs)
+	if err := declaredInterfaces(mockers, srcs, filter); err != nil {
+		return err
+	}
+	if flags.streams {
+		for i, m := range mockers {
+			streams, err := m.Streams(srcs[i].namePairs...)
+			if err != nil {
+				return err
+			}
+			srcs[i].namePairs = append(srcs[i].namePairs, streams...)
+		}
+	}
+	if err := checkOutFiles(mockers, srcs); err != nil {
+		return err
+	}
+	var docs []doc
+	if flags.doc {
+		docs = newPackageDocs(mockers, srcs)
+	}
+
+	// The output files are written once all of them are generated, along
+	// with the failures of the others.
+	var g generation
+	for i, m := range mockers {
+		if len(srcs[i].namePairs) == 0 {
+			continue
+		}
+		if flags.outDir != "" || mirip.IsOutFilePattern(flags.outFile) {
+			mockEach(&g, m, flags.jobs, flags.remove, srcs[i].namePairs)
+		} else if flags.outFile != "" {
+			// The output file of the source dir, with the placeholder
+			// of its package replaced.
+			mock(&g, m, m.OutFile(srcs[i].namePairs[0]), flags.remove, srcs[i].namePairs)
+		} else {
+			mock(&g, m, "", false, srcs[i].namePairs)
+		}
+	}
+	if err := g.write(); err != nil {
+		return err
+	}
+	if flags.remove && flags.outDir != "" && writesFiles() {
+		for _, out := range g.files {
+			pruned.addDir(out.outFile)
+		}
+	}
 
-	if err = m.Mock(out, args...); err != nil {
+	if err := writeDocs(docs); err != nil {
 		return err
 	}
 
-	if flags.outFile == "" {
+	if flags.example != "" {
+		m, args := mockers[0], srcs[0].namePairs
+		var example bytes.Buffer
+		if err := m.Example(&example, args...); err != nil {
+			return err
+		}
+		status, err := writeOutput(flags.example, example.Bytes())
+		recordResults(args, flags.example, status, written(status, example.Len()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.builderSpec != "" {
+		m, args := mockers[0], srcs[0].namePairs
+		var spec bytes.Buffer
+		if err := m.BuilderSpec(&spec, args...); err != nil {
+			return err
+		}
+		status, err := writeOutput(flags.builderSpec, spec.Bytes())
+		recordResults(args, flags.builderSpec, status, written(status, spec.Len()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.conformance != "" {
+		m, args := mockers[0], srcs[0].namePairs
+		var conformance bytes.Buffer
+		if err := m.Conformance(&conformance, args...); err != nil {
+			return err
+		}
+		status, err := writeOutput(flags.conformance, conformance.Bytes())
+		recordResults(args, flags.conformance, status, written(status, conformance.Len()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.ensureFile != "" {
+		m, args := mockers[0], srcs[0].namePairs
+		var ensure bytes.Buffer
+		if err := m.Ensure(&ensure, args...); err != nil {
+			return err
+		}
+		status, err := writeOutput(flags.ensureFile, ensure.Bytes())
+		recordResults(args, flags.ensureFile, status, written(status, ensure.Len()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.bench != "" {
+		m, args := mockers[0], srcs[0].namePairs
+		var bench bytes.Buffer
+		if err := m.Benchmark(&bench, flags.benchImpl, args...); err != nil {
+			return err
+		}
+		status, err := writeOutput(flags.bench, bench.Bytes())
+		recordResults(args, flags.bench, status, written(status, bench.Len()))
+		if err != nil {
+			return err
+		}
+	}
+
+	if flags.verifyBuild {
+		if err := mirip.VerifyBuild(generated, cfgs[0]); err != nil {
+			return err
+		}
+	}
+
+	if flags.lock != "" && !checkOnly {
+		return updateLock(flags.lock, flags.argv)
+	}
+	return nil
+}
+
+// mock adds the mocks of the given interfaces to the generation, to be
+// written to the output file, or stdout.
+func mock(g *generation, m *mirip.Mocker, outFile string, remove bool, namePairs []string) {
+	var buf bytes.Buffer
+	if err := m.Mock(&buf, namePairs...); err != nil {
+		g.fail(m, namePairs, outFile, err)
+		return
+	}
+	g.add(namePairs, outFile, buf.Bytes(), remove)
+}
+
+// mockEach adds the mocks of the given interfaces to the generation, to
+// be written to their own output files, following the output file
+// pattern, generating up to jobs files concurrently. Interfaces whose
+// names are the same in the file case share a file.
+func mockEach(g *generation, m *mirip.Mocker, jobs int, remove bool, namePairs []string) {
+	var outFiles []string
+	byOutFile := make(map[string][]string)
+	for _, np := range namePairs {
+		outFile := m.OutFile(np)
+		if _, ok := byOutFile[outFile]; !ok {
+			outFiles = append(outFiles, outFile)
+		}
+		byOutFile[outFile] = append(byOutFile[outFile], np)
+	}
+
+	bufs := make([]bytes.Buffer, len(outFiles))
+	mockJobs := make([]mirip.Job, len(outFiles))
+	for i, outFile := range outFiles {
+		mockJobs[i] = mirip.Job{Out: &bufs[i], NamePairs: byOutFile[outFile]}
+	}
+
+	for i, err := range m.MockEach(jobs, mockJobs...) {
+		if err != nil {
+			g.fail(m, byOutFile[outFiles[i]], outFiles[i], err)
+			continue
+		}
+		g.add(byOutFile[outFiles[i]], outFiles[i], bufs[i].Bytes(), remove)
+	}
+}
+
+// writesFiles returns whether the output files are written, rather than
+// checked or written to stdout.
+func writesFiles() bool {
+	return !checkOnly && stdoutFiles == nil
+}
+
+// checkOnly makes writeFile check the output files are up to date
+// instead of writing them.
+var checkOnly bool
+
+// quiet makes writeFile print nothing but errors.
+var quiet bool
+
+// generated holds the content of the output files by name, for
+// -verify-build to type-check.
+var generated map[string][]byte
+
+// writeFile writes the content to the given file, creating its directory
+// if needed. The file is left untouched when it already has the content,
+// which keeps its modification time stable for build systems.
+func writeFile(name string, content []byte) error {
+	_, err := writeOutput(name, content)
+	return err
+}
+
+// keyValues are the pairs of a repeatable flag in the format 'key=value'.
+type keyValues map[string]string
+
+func (kv keyValues) String() string {
+	var pairs []string
+	for k, v := range kv {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (kv *keyValues) Set(value string) error {
+	k, v, ok := strings.Cut(value, "=")
+	if !ok || k == "" || v == "" {
+		return errors.New("expected 'key=value'")
+	}
+	if *kv == nil {
+		*kv = make(keyValues)
+	}
+	(*kv)[k] = v
+	return nil
+}
+
+// stringList are the values of a repeatable flag, in order.
+type stringList []string
+
+func (l stringList) String() string {
+	return strings.Join(l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// source is a source dir and the interfaces to mock in it. The source
+// dirs matched by a package pattern keep the pattern.
+type source struct {
+	dir       string
+	namePairs []string
+	pattern   string
+}
+
+// sources are the sources of a repeatable flag in the format
+// 'dir=Interface,Interface2', in order. The interfaces of a dir given more
+// than once are merged.
+type sources []source
+
+func (s sources) String() string {
+	pairs := make([]string, len(s))
+	for i, src := range s {
+		pairs[i] = src.dir + "=" + strings.Join(src.namePairs, ",")
+	}
+	return strings.Join(pairs, " ")
+}
+
+func (s *sources) Set(value string) error {
+	dir, names, ok := strings.Cut(value, "=")
+	if !ok || dir == "" || (names == "" && !mirip.IsPattern(dir)) {
+		return errors.New("expected 'dir=Interface[,Interface2]'")
+	}
+	// A package pattern without interfaces mocks all of theirs.
+	var namePairs []string
+	if names != "" {
+		namePairs = strings.Split(names, ",")
+	}
+	for _, np := range namePairs {
+		if np == "" {
+			return errors.New("expected 'dir=Interface[,Interface2]'")
+		}
+	}
+	for i := range *s {
+		if (*s)[i].dir == dir {
+			(*s)[i].namePairs = append((*s)[i].namePairs, namePairs...)
+			return nil
+		}
+	}
+	*s = append(*s, source{dir: dir, namePairs: namePairs})
+	return nil
+}
+
+// hasPattern returns whether the dir of one of the sources is a package
+// pattern.
+func hasPattern(srcs sources) bool {
+	for _, src := range srcs {
+		if mirip.IsPattern(src.dir) {
+			return true
+		}
+	}
+	return false
+}
+
+// expandPatterns replaces the sources whose dir is a package pattern with
+// a source for each package it matches which the filter keeps, with the
+// same interfaces.
+func expandPatterns(srcs sources, filter filter, cfg mirip.Config) (sources, error) {
+	var expanded sources
+	for _, src := range srcs {
+		if !mirip.IsPattern(src.dir) {
+			expanded = append(expanded, src)
+			continue
+		}
+
+		dirs, err := mirip.PatternDirs(src.dir, cfg)
+		if err != nil {
+			return nil, err
+		}
+		for _, dir := range dirs {
+			if !filter.keepsDir(dir) {
+				continue
+			}
+			expanded = append(expanded, source{dir: dir, namePairs: src.namePairs, pattern: src.dir})
+		}
+	}
+	return expanded, nil
+}
+
+// declaredInterfaces keeps the interfaces of the sources matched by a
+// package pattern to the ones their package declares, ensuring that each
+// is declared by one of the packages the pattern matched, or to all the
+// ones it declares if none is given. The filter then selects among them.
+func declaredInterfaces(mockers []*mirip.Mocker, srcs sources, filter filter) error {
+	var patterns []source
+	found := make(map[string]bool)
+	for i, m := range mockers {
+		if srcs[i].pattern == "" {
+			continue
+		}
+		if len(patterns) == 0 || patterns[len(patterns)-1].dir != srcs[i].pattern {
+			patterns = append(patterns, source{dir: srcs[i].pattern, namePairs: srcs[i].namePairs})
+		}
+
+		declared := make(map[string]bool)
+		for _, name := range m.Interfaces() {
+			declared[name] = true
+		}
+		given := srcs[i].namePairs
+		if len(given) == 0 {
+			given = m.Interfaces()
+		}
+		var namePairs []string
+		for _, np := range given {
+			// The interface name of 'interface:alias'.
+			name, _, _ := mirip.ParseNamePair(np)
+			if !declared[name] {
+				continue
+			}
+			found[srcs[i].pattern+"="+np] = true
+			if file, _ := m.InterfaceFile(name); filter.keepsInterface(name, file) {
+				namePairs = append(namePairs, np)
+			}
+		}
+		srcs[i].namePairs = namePairs
+	}
+
+	for _, pattern := range patterns {
+		for _, np := range pattern.namePairs {
+			if !found[pattern.dir+"="+np] {
+				return fmt.Errorf("interface not found: %s\n\tsearched the packages matched by %s", np, pattern.dir)
+			}
+		}
+	}
+	return nil
+}
+
+// checkOutFiles ensures that no two interfaces of different sources are
+// written to the same output file.
+func checkOutFiles(mockers []*mirip.Mocker, srcs sources) error {
+	if len(srcs) < 2 {
 		return nil
 	}
 
-	// create the file
-	err = os.MkdirAll(filepath.Dir(flags.outFile), 0750)
+	type written struct {
+		src  int
+		name string
+	}
+	seen := make(map[string]written)
+	for i, m := range mockers {
+		for _, np := range srcs[i].namePairs {
+			outFile := m.OutFile(np)
+			name := srcs[i].dir + "=" + np
+			if other, ok := seen[outFile]; ok && other.src != i {
+				return fmt.Errorf("both %s and %s would be written to %s", other.name, name, outFile)
+			}
+			seen[outFile] = written{src: i, name: name}
+		}
+	}
+	return nil
+}
+
+// readAliases reads the alias file of the given name, if any.
+func readAliases(name string) (map[string]string, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(name)
 	if err != nil {
-		return err
+		return nil, err
+	}
+	defer f.Close()
+
+	aliases, err := mirip.ReadAliases(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return aliases, nil
+}
+
+// loadEnv returns the variables of -env, along with GOFLAGS passing the
+// absolute path of -modfile to the go command, so that it applies to the
+// packages loaded from any directory, and the build tags of -tags, which
+// replace those of a -tags flag already in GOFLAGS.
+func loadEnv(flags userFlags) (map[string]string, error) {
+	var goFlags []string
+	if flags.modFile != "" {
+		modFile, err := filepath.Abs(flags.modFile)
+		if err != nil {
+			return nil, err
+		}
+		if strings.ContainsAny(modFile, " \t") {
+			return nil, usageError{"the path of -modfile can't have spaces, which separate the flags of GOFLAGS"}
+		}
+		if !strings.HasSuffix(modFile, ".mod") {
+			return nil, usageError{"-modfile must name a file ending in .mod"}
+		}
+		goFlags = append(goFlags, "-modfile="+modFile)
+	}
+	if flags.tags != "" {
+		if strings.ContainsAny(flags.tags, " \t") {
+			return nil, usageError{"-tags must be a comma-separated list, without spaces"}
+		}
+		goFlags = append(goFlags, "-tags="+flags.tags)
+	}
+	if len(goFlags) == 0 {
+		return flags.env, nil
+	}
+
+	env := make(map[string]string, len(flags.env)+1)
+	for name, value := range flags.env {
+		env[name] = value
+	}
+	goflags, ok := flags.env["GOFLAGS"]
+	if !ok {
+		goflags = os.Getenv("GOFLAGS")
+	}
+	env["GOFLAGS"] = strings.TrimSpace(goflags + " " + strings.Join(goFlags, " "))
+	return env, nil
+}
+
+// readOverlay reads the overlay file of the given name, if any.
+func readOverlay(name string) (map[string][]byte, error) {
+	if name == "" {
+		return nil, nil
+	}
+
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
 	}
+	defer f.Close()
 
-	return os.WriteFile(flags.outFile, buf.Bytes(), 0600)
+	overlay, err := mirip.ReadOverlay(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %s", name, err)
+	}
+	return overlay, nil
+}
+
+// warn prints the warning on stderr unless -quiet is set, or records it
+// for the results of its interface with -json.
+func warn(w mirip.Warning) {
+	runLog.log("warn", w.Msg, map[string]interface{}{"interface": w.Interface})
+	switch {
+	case jsonResults != nil:
+		warnings[w.Interface] = append(warnings[w.Interface], w.Msg)
+	case !quiet:
+		_, _ = fmt.Fprintf(os.Stderr, "mirip: warning: %s\n", w)
+	}
+}
+
+// enableTypeAliases makes go/types keep the type aliases of signatures, so
+// that mocks refer to them as written, which it only does by default from
+// Go 1.23 for modules requiring it. A gotypesalias setting in GODEBUG
+// still takes precedence.
+func enableTypeAliases() {
+	_ = os.Setenv("GODEBUG", strings.TrimSuffix("gotypesalias=1,"+os.Getenv("GODEBUG"), ","))
+}
+
+// verbosef logs a line with -v or to the -log-file. It is a no-op
+// otherwise.
+var verbosef = func(format string, args ...interface{}) {}
+
+// writeOutput writes the content to the given file like writeFile,
+// returning the status of the file. The file is left as is, up to date,
+// when it only differs from the content by its stamp, the content being
+// rendered and formatted anyway.
+func writeOutput(name string, content []byte) (string, error) {
+	verbosef("output %s (%d bytes)", name, len(content))
+	pruned.keep(name)
+	if generated != nil {
+		generated[name] = content
+	}
+	if stdoutFiles != nil {
+		return statusWritten, stdoutFiles.write(name, content)
+	}
+	if err := checkInsideRoot(name); err != nil {
+		return "", err
+	}
+	existing, err := os.ReadFile(osPath(name))
+	if err == nil && bytes.Equal(mirip.Unstamped(existing), mirip.Unstamped(content)) {
+		if jsonResults == nil && !quiet {
+			fmt.Printf("%s is up to date\n", name)
+		}
+		return statusUpToDate, nil
+	}
+	var skew string
+	if err == nil {
+		skew = versionSkew(existing)
+	}
+
+	if checkOnly {
+		if jsonResults == nil && !quiet {
+			for _, change := range diff.Methods(existing, content) {
+				fmt.Printf("%s: %s\n", name, change)
+			}
+			fmt.Print(diff.Unified(name, name+" (regenerated)", existing, content, useColor(os.Stdout)))
+		}
+		return statusOutOfDate, driftError{name: name, skew: skew}
+	}
+	if skew != "" {
+		warn(mirip.Warning{Interface: name, Msg: skew})
+	}
+
+	if staged != nil {
+		staged.stage(name, content)
+		return statusWritten, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(osPath(name)), 0750); err != nil {
+		return "", writeError{err}
+	}
+
+	if err := os.WriteFile(osPath(name), content, 0600); err != nil {
+		return "", writeError{err}
+	}
+	return statusWritten, nil
+}
+
+// useColor returns whether to colour the output to f, which is when f is
+// a terminal and NO_COLOR is not set.
+func useColor(f *os.File) bool {
+	if os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// written returns the number of bytes written for an output file of the
+// given status and size.
+func written(status string, size int) int {
+	if status != statusWritten {
+		return 0
+	}
+	return size
 }
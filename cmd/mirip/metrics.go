@@ -0,0 +1,93 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/gmhafiz/mirip/internal/suggest"
+)
+
+func runMetrics(args []string) error {
+	fs := flag.NewFlagSet("stats", flag.ExitOnError)
+	top := fs.Int("top", 10, "number of the largest interfaces listed")
+	fs.Usage = func() {
+		fmt.Println(`mirip stats [flags] [packages]`)
+		fs.PrintDefaults()
+		fmt.Println(`Reports the interfaces declared by each package, their average number of methods and how many are`)
+		fmt.Println(`mocked by files generated by mirip among the packages, along with the largest interfaces, for audits`)
+		fmt.Println(`of the interfaces of a codebase and of the coverage of their mocks. The packages default to ./...`)
+		fmt.Println(`Ex: mirip stats -top 5 ./...`)
+	}
+	_ = fs.Parse(args)
+
+	if *top < 0 {
+		return usageError{"-top can't be negative"}
+	}
+	patterns := fs.Args()
+	if len(patterns) == 0 {
+		patterns = []string{"./..."}
+	}
+	root, err := filepath.Abs(".")
+	if err != nil {
+		return err
+	}
+
+	declared, err := suggest.Interfaces(".", patterns...)
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "PACKAGE\tINTERFACES\tAVG METHODS\tMOCKED")
+	var total, methods, mocked int
+	for i := 0; i < len(declared); {
+		pkg := declared[i].Package
+		var n, pkgMethods, pkgMocked int
+		for ; i < len(declared) && declared[i].Package == pkg; i++ {
+			n++
+			pkgMethods += declared[i].Methods
+			if len(declared[i].Mocks) != 0 {
+				pkgMocked++
+			}
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%.1f\t%d (%.0f%%)\n", pkg, n, float64(pkgMethods)/float64(n), pkgMocked, percent(pkgMocked, n))
+		total, methods, mocked = total+n, methods+pkgMethods, mocked+pkgMocked
+	}
+	if total != 0 {
+		_, _ = fmt.Fprintf(w, "total\t%d\t%.1f\t%d (%.0f%%)\n", total, float64(methods)/float64(total), mocked, percent(mocked, total))
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	largest := append([]suggest.Declared(nil), declared...)
+	sort.SliceStable(largest, func(i, j int) bool {
+		return largest[i].Methods > largest[j].Methods
+	})
+	if len(largest) > *top {
+		largest = largest[:*top]
+	}
+	if len(largest) == 0 {
+		return nil
+	}
+	fmt.Println()
+	w = tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "LARGEST\tMETHODS\tMOCKED\tDECLARED AT")
+	for _, d := range largest {
+		mockedBy := "no"
+		if len(d.Mocks) != 0 {
+			mockedBy = "yes"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%d\t%s\t%s:%d\n", d.Interface, d.Methods, mockedBy, shownPath(root, d.Pos.Filename), d.Pos.Line)
+	}
+	return w.Flush()
+}
+
+// percent returns n as a percentage of total.
+func percent(n, total int) float64 {
+	return 100 * float64(n) / float64(total)
+}
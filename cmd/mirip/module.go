@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// writeOutModule writes the go.mod and go.sum files of the dedicated
+// module of mocks in dir with -out-module, unless it already has them.
+func writeOutModule(dir string) error {
+	gomod, gosum, err := registry.OutModule(dir)
+	if err != nil || gomod == nil {
+		return err
+	}
+
+	for _, file := range []struct {
+		name    string
+		content []byte
+	}{{"go.mod", gomod}, {"go.sum", gosum}} {
+		if file.content == nil {
+			continue
+		}
+		name := filepath.Join(dir, file.name)
+		status, err := writeOutput(name, file.content)
+		recordResults(nil, name, status, written(status, len(file.content)))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkOutModule returns a usage error unless the output files are in
+// the directory of the module of mocks.
+func checkOutModule(dir, out string) error {
+	rel, err := relPath(osPath(dir), out)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return usageError{fmt.Sprintf("the output %s isn't in the directory of -out-module %s", out, dir)}
+	}
+	return nil
+}
@@ -0,0 +1,77 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// osPath returns the path of the file of the given name for the file
+// system calls: absolute, as Windows only allows the paths longer than
+// 260 characters, which Go prefixes with \\?\, when they are absolute.
+func osPath(name string) string {
+	abs, err := filepath.Abs(name)
+	if err != nil {
+		return name
+	}
+	return abs
+}
+
+// slashed returns the argument of mirip with slashes rather than the
+// separator of the system, such as the backslashes of Windows paths, for
+// the code generated, and the files recording the commands, to be the
+// same on every system. Windows accepts either separator.
+func slashed(arg string) string {
+	return filepath.ToSlash(arg)
+}
+
+// allowOutsideModule lets the output files be written outside the root
+// of the working directory, see checkInsideRoot.
+var allowOutsideModule bool
+
+// checkInsideRoot returns a usage error unless the output file of the
+// given name is in the repository of the working directory, or in its
+// module outside repositories, for a typo in -out or a config file not to
+// scatter files across the disk, unless -allow-outside-module is set. The
+// files of working directories in neither are not checked.
+func checkInsideRoot(name string) error {
+	if allowOutsideModule {
+		return nil
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil
+	}
+	root, kind := outputRoot(wd)
+	if root == "" {
+		return nil
+	}
+	rel, err := relPath(root, name)
+	if err != nil || rel == ".." || strings.HasPrefix(rel, "../") {
+		return usageError{fmt.Sprintf("the output %s is outside the %s %s, use -allow-outside-module to write it anyway", name, kind, root)}
+	}
+	return nil
+}
+
+// outputRoot returns the root of the repository containing dir, or else
+// of its module, along with its kind, "" if dir is in neither.
+func outputRoot(dir string) (root, kind string) {
+	for {
+		if root == "" {
+			if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+				root, kind = dir, "module"
+			}
+		}
+		// Worktrees and submodules have a .git file.
+		if _, err := os.Stat(filepath.Join(dir, ".git")); err == nil {
+			return dir, "repository"
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return root, kind
+		}
+		dir = parent
+	}
+}
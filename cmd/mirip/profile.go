@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"runtime/pprof"
+	"runtime/trace"
+)
+
+// profileFlags are the hidden flags for reporting the performance of
+// slow generation.
+type profileFlags struct {
+	cpuProfile string
+	memProfile string
+	trace      string
+	timings    bool
+}
+
+// hiddenFlags are the names of the flags left out of the usage.
+var hiddenFlags = map[string]bool{
+	"cpuprofile": true,
+	"memprofile": true,
+	"trace":      true,
+	"timings":    true,
+}
+
+func (p *profileFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&p.cpuProfile, "cpuprofile", "", "write a CPU profile to the file")
+	fs.StringVar(&p.memProfile, "memprofile", "", "write a memory profile to the file")
+	fs.StringVar(&p.trace, "trace", "", "write an execution trace to the file")
+	fs.BoolVar(&p.timings, "timings", false, "print the time spent in each phase of generation")
+}
+
+// start starts the requested profiles, returning a func which stops them
+// and writes them out.
+func (p profileFlags) start() (stop func(), err error) {
+	var stops []func()
+	stop = func() {
+		for i := len(stops) - 1; i >= 0; i-- {
+			stops[i]()
+		}
+	}
+
+	if p.cpuProfile != "" {
+		f, err := os.Create(p.cpuProfile)
+		if err != nil {
+			return stop, err
+		}
+		if err := pprof.StartCPUProfile(f); err != nil {
+			_ = f.Close()
+			return stop, err
+		}
+		stops = append(stops, func() {
+			pprof.StopCPUProfile()
+			_ = f.Close()
+		})
+	}
+
+	if p.trace != "" {
+		f, err := os.Create(p.trace)
+		if err != nil {
+			return stop, err
+		}
+		if err := trace.Start(f); err != nil {
+			_ = f.Close()
+			return stop, err
+		}
+		stops = append(stops, func() {
+			trace.Stop()
+			_ = f.Close()
+		})
+	}
+
+	if p.memProfile != "" {
+		stops = append(stops, func() {
+			f, err := os.Create(p.memProfile)
+			if err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+				return
+			}
+			defer f.Close()
+
+			runtime.GC()
+			if err := pprof.WriteHeapProfile(f); err != nil {
+				_, _ = fmt.Fprintln(os.Stderr, err)
+			}
+		})
+	}
+
+	return stop, nil
+}
+
+// printDefaults prints the defaults of the flags of fs, like
+// fs.PrintDefaults, except for the hidden flags.
+func printDefaults(fs *flag.FlagSet) {
+	visible := flag.NewFlagSet(fs.Name(), flag.ContinueOnError)
+	visible.SetOutput(fs.Output())
+	fs.VisitAll(func(f *flag.Flag) {
+		if !hiddenFlags[f.Name] {
+			visible.Var(f.Value, f.Name, f.Usage)
+			visible.Lookup(f.Name).DefValue = f.DefValue
+		}
+	})
+	visible.PrintDefaults()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// pruned collects the output dirs of the runs with -rm and -out-dir,
+// along with the output files of every run, for the generated files of
+// the dirs no run generated to be removed once every run succeeded.
+var pruned = newPruning()
+
+// dryRun makes prune print the orphaned files instead of removing them.
+var dryRun bool
+
+// pruning is the state of the removal of orphaned generated files.
+type pruning struct {
+	dirs map[string]bool
+	kept map[string]bool
+}
+
+func newPruning() *pruning {
+	return &pruning{dirs: make(map[string]bool), kept: make(map[string]bool)}
+}
+
+// keep records an output file of a run, which isn't orphaned.
+func (p *pruning) keep(name string) {
+	if abs, err := filepath.Abs(osPath(name)); err == nil {
+		p.kept[abs] = true
+	}
+}
+
+// addDir records the dir of the output file, to be pruned.
+func (p *pruning) addDir(outFile string) {
+	if abs, err := filepath.Abs(filepath.Dir(osPath(outFile))); err == nil {
+		p.dirs[abs] = true
+	}
+}
+
+// prune removes the Go files generated by mirip in the dirs which aren't
+// output files of the runs, such as the mocks of deleted interfaces, or
+// prints them with -dry-run. The subdirectories are left as is, as they
+// are the output dirs of other source dirs.
+func (p *pruning) prune() error {
+	dirs := make([]string, 0, len(p.dirs))
+	for dir := range p.dirs {
+		dirs = append(dirs, dir)
+	}
+	sort.Strings(dirs)
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	for _, dir := range dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			return err
+		}
+		for _, e := range entries {
+			name := filepath.Join(dir, e.Name())
+			if e.IsDir() || !strings.HasSuffix(name, ".go") || p.kept[name] || !isGenerated(name) {
+				continue
+			}
+			if dryRun {
+				fmt.Printf("%s would be removed\n", shownPath(wd, name))
+				continue
+			}
+			if err := os.Remove(name); err != nil {
+				return writeError{err}
+			}
+			if jsonResults == nil && !quiet {
+				fmt.Printf("%s was removed\n", shownPath(wd, name))
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,222 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sync"
+	"syscall"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// packageCache keeps the loaded source packages between the requests of
+// mirip serve. It is nil otherwise.
+var packageCache *registry.PackageCache
+
+func runServe(args []string) error {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	socket := fs.String("socket", filepath.Join(os.TempDir(), fmt.Sprintf("mirip-%d.sock", os.Getuid())), "unix socket to listen on")
+	fs.Usage = func() {
+		fmt.Println(`mirip serve [flags]`)
+		fs.PrintDefaults()
+		fmt.Println(`Serves the Mirip.ListInterfaces, Mirip.Generate and Mirip.Check JSON-RPC 1.0 methods on the socket,`)
+		fmt.Println(`keeping the loaded packages in memory between requests. The params of Generate and Check are the`)
+		fmt.Println(`directory and the arguments of a mirip run, which requires -out or -out-dir, and their result is the`)
+		fmt.Println(`report of -json. The params and results have the version of their schema.`)
+		fmt.Println(`Ex: mirip serve -socket /tmp/mirip.sock`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return usageError{"expected no arguments"}
+	}
+
+	// The socket of a daemon which didn't exit cleanly is removed, unless
+	// a daemon still listens on it.
+	if conn, err := net.Dial("unix", *socket); err == nil {
+		_ = conn.Close()
+		return fmt.Errorf("mirip serve already listens on %s", *socket)
+	}
+	_ = os.Remove(*socket)
+
+	ln, err := net.Listen("unix", *socket)
+	if err != nil {
+		return err
+	}
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("Mirip", &server{cache: registry.NewPackageCache()}); err != nil {
+		return err
+	}
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-signals
+		_ = ln.Close()
+	}()
+
+	_, _ = fmt.Fprintf(os.Stderr, "mirip: listening on %s\n", *socket)
+	for {
+		conn, err := ln.Accept()
+		if errors.Is(err, net.ErrClosed) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		go srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+	}
+}
+
+// rpcVersion is the version of the schemas of the params and results of
+// the methods of mirip serve, bumped whenever they change incompatibly,
+// for the editor plugins maintained out of the tree to tell them apart.
+const rpcVersion = 1
+
+// checkVersion fails unless the version of the params of a request is
+// served, 0 being the current one.
+func checkVersion(version int) error {
+	if version < 0 || version > rpcVersion {
+		return fmt.Errorf("unsupported version %d of the params, mirip serves version %d", version, rpcVersion)
+	}
+	return nil
+}
+
+// ListInterfacesArgs are the params of Mirip.ListInterfaces: the source
+// directory, relative to Dir if any.
+type ListInterfacesArgs struct {
+	Version int    `json:"version"`
+	Dir     string `json:"dir"`
+	SrcDir  string `json:"src_dir"`
+}
+
+// ListInterfacesReply is the result of Mirip.ListInterfaces.
+type ListInterfacesReply struct {
+	Version    int             `json:"version"`
+	Interfaces []interfaceDecl `json:"interfaces"`
+}
+
+// interfaceDecl is an interface which can be mocked, along with the
+// position of its declaration for the code actions of editors, unset for
+// the interfaces declared in other packages.
+type interfaceDecl struct {
+	Name   string `json:"name"`
+	File   string `json:"file,omitempty"`
+	Line   int    `json:"line,omitempty"`
+	Column int    `json:"column,omitempty"`
+}
+
+// GenerateArgs are the params of Mirip.Generate and Mirip.Check: the
+// arguments of a run of mirip from Dir.
+type GenerateArgs struct {
+	Version int      `json:"version"`
+	Dir     string   `json:"dir"`
+	Args    []string `json:"args"`
+}
+
+// GenerateReply is the result of Mirip.Generate and Mirip.Check, the
+// report of -json.
+type GenerateReply struct {
+	Version int `json:"version"`
+	report
+}
+
+// server serves the methods of mirip serve. The runs share the globals of
+// mirip, so that they are serialized.
+type server struct {
+	mu    sync.Mutex
+	cache *registry.PackageCache
+}
+
+// Generate runs mirip with the arguments, replying with the results of
+// the output files. Failed runs are reported in the reply rather than as
+// an error, like with -json.
+func (s *server) Generate(args GenerateArgs, reply *GenerateReply) error {
+	return s.run(args, false, reply)
+}
+
+// Check runs mirip with the arguments and -check, replying with the
+// results of the output files, out-of-date for those which Generate would
+// change, without writing them.
+func (s *server) Check(args GenerateArgs, reply *GenerateReply) error {
+	return s.run(args, true, reply)
+}
+
+// ListInterfaces replies with the interfaces which can be mocked in the
+// source directory, like mirip list.
+func (s *server) ListInterfaces(args ListInterfacesArgs, reply *ListInterfacesReply) error {
+	if err := checkVersion(args.Version); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	registry.ForgetModules()
+
+	srcDir := args.SrcDir
+	if srcDir == "" {
+		srcDir = "."
+	}
+	if !filepath.IsAbs(srcDir) {
+		srcDir = filepath.Join(args.Dir, srcDir)
+	}
+	m, err := mirip.New(mirip.Config{SrcDir: srcDir, PackageCache: s.cache})
+	if err != nil {
+		return err
+	}
+
+	*reply = ListInterfacesReply{Version: rpcVersion, Interfaces: []interfaceDecl{}}
+	for _, name := range m.Interfaces() {
+		decl := interfaceDecl{Name: name}
+		if pos, ok := m.InterfacePos(name); ok {
+			decl.File, decl.Line, decl.Column = pos.Filename, pos.Line, pos.Column
+		}
+		reply.Interfaces = append(reply.Interfaces, decl)
+	}
+	return nil
+}
+
+// run runs mirip for Generate, or Check with check.
+func (s *server) run(args GenerateArgs, check bool, reply *GenerateReply) error {
+	if err := checkVersion(args.Version); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The modules are hashed again for the cached packages of the
+	// modules changed since the last request to be loaded again.
+	registry.ForgetModules()
+
+	results := []result{}
+	jsonResults, warnings, outputs, packageCache, quiet = &results, make(map[string][]string), make(map[string][]string), s.cache, true
+	defer func() {
+		jsonResults, packageCache, quiet, checkOnly, dryRun = nil, nil, false, false, false
+		stdoutFiles, staged, pruned = nil, nil, newPruning()
+	}()
+
+	cmdArgs := args.Args
+	if check {
+		cmdArgs = append([]string{"-check"}, cmdArgs...)
+	}
+	err := runArgs(args.Dir, cmdArgs, func(flags userFlags) error {
+		switch {
+		case flags.outFile == "" && flags.outDir == "":
+			return usageError{"requests require -out or -out-dir"}
+		case flags.stdout != "":
+			return usageError{"requests can't use -stdout"}
+		}
+		return nil
+	})
+	*reply = GenerateReply{Version: rpcVersion, report: newReport(err)}
+	return nil
+}
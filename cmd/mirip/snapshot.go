@@ -0,0 +1,170 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// snapshotVersion is the version of the format of the snapshot files,
+// bumped whenever it changes incompatibly.
+const snapshotVersion = 1
+
+// snapshotFile records the method sets of interfaces, for mirip snapshot
+// -check to detect their incompatible changes. Interfaces maps the
+// interfaces, in the format 'path.Interface', to the signatures of their
+// methods, as returned by Mocker.Snapshot.
+type snapshotFile struct {
+	Version    int                          `json:"version"`
+	Interfaces map[string]map[string]string `json:"interfaces"`
+}
+
+func runSnapshot(args []string) error {
+	fs := flag.NewFlagSet("snapshot", flag.ExitOnError)
+	name := fs.String("file", "mirip.snapshot.json", "snapshot file recording the interfaces")
+	check := fs.Bool("check", false, "check the interfaces of the snapshot file didn't change incompatibly instead of writing it, exiting with 6 if they did")
+	cacheDir := fs.String("cache", os.Getenv("MIRIP_CACHE"), "directory caching the type information of source packages between runs (default $MIRIP_CACHE)")
+	fs.Usage = func() {
+		fmt.Println(`mirip snapshot [flags] source-dir [interface [interface2 [...]]]`)
+		fmt.Println(`mirip snapshot -check [flags]`)
+		fs.PrintDefaults()
+		fmt.Println(`Records the method sets of the interfaces, all those of the package by default, in the snapshot file,`)
+		fmt.Println(`replacing its content. With -check, the interfaces recorded are resolved again from the directory of`)
+		fmt.Println(`the file, failing if one was removed or one of its methods was added, removed or changed signature.`)
+		fmt.Println(`Ex: mirip snapshot -file api.snapshot.json ./store Store`)
+	}
+	_ = fs.Parse(args)
+
+	if *check {
+		if fs.NArg() != 0 {
+			fs.Usage()
+			return usageError{"-check expects no arguments"}
+		}
+		return checkSnapshot(*name, *cacheDir)
+	}
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return usageError{"expected a source directory"}
+	}
+
+	m, err := mirip.New(mirip.Config{SrcDir: fs.Arg(0), CacheDir: *cacheDir})
+	if err != nil {
+		return err
+	}
+	names := fs.Args()[1:]
+	if len(names) == 0 {
+		names = m.Interfaces()
+	}
+	interfaces, err := m.Snapshot(names...)
+	if err != nil {
+		return err
+	}
+
+	content, err := json.MarshalIndent(snapshotFile{Version: snapshotVersion, Interfaces: interfaces}, "", "  ")
+	if err != nil {
+		return err
+	}
+	return writeFile(*name, append(content, '\n'))
+}
+
+// checkSnapshot resolves the interfaces of the snapshot file of the given
+// name from its directory, printing their incompatible changes, and
+// returns a driftError if there are any.
+func checkSnapshot(name, cacheDir string) error {
+	snapshot, err := readSnapshot(name)
+	if err != nil {
+		return err
+	}
+
+	m, err := mirip.New(mirip.Config{SrcDir: filepath.Dir(name), CacheDir: cacheDir})
+	if err != nil {
+		return err
+	}
+
+	ifaces := make([]string, 0, len(snapshot.Interfaces))
+	for iface := range snapshot.Interfaces {
+		ifaces = append(ifaces, iface)
+	}
+	sort.Strings(ifaces)
+
+	problems := 0
+	for _, iface := range ifaces {
+		current, err := m.Snapshot(iface)
+		var notFoundErr *registry.NotFoundError
+		switch {
+		case errors.As(err, &notFoundErr):
+			fmt.Printf("%s: removed\n", iface)
+			problems++
+			continue
+		case err != nil:
+			return err
+		}
+
+		for _, change := range incompatibleChanges(snapshot.Interfaces[iface], current[iface]) {
+			fmt.Printf("%s: %s\n", iface, change)
+			problems++
+		}
+	}
+
+	if problems != 0 {
+		return driftError{name: name}
+	}
+	fmt.Printf("%d interfaces are compatible with %s\n", len(ifaces), name)
+	return nil
+}
+
+// incompatibleChanges returns the changes of the methods of an interface
+// from those of the snapshot, sorted by method: the removed methods and
+// those changing signature break its callers, and the added ones its
+// implementations.
+func incompatibleChanges(recorded, current map[string]string) []string {
+	var names []string
+	for method := range recorded {
+		names = append(names, method)
+	}
+	for method := range current {
+		if _, ok := recorded[method]; !ok {
+			names = append(names, method)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, method := range names {
+		from, inRecorded := recorded[method]
+		to, inCurrent := current[method]
+		switch {
+		case !inRecorded:
+			changes = append(changes, "added "+method+to)
+		case !inCurrent:
+			changes = append(changes, "removed "+method+from)
+		case from != to:
+			changes = append(changes, "changed "+method+from+" to "+method+to)
+		}
+	}
+	return changes
+}
+
+// readSnapshot reads the snapshot file of the given name.
+func readSnapshot(name string) (snapshotFile, error) {
+	var snapshot snapshotFile
+	content, err := os.ReadFile(name)
+	if err != nil {
+		return snapshot, err
+	}
+	if err := json.Unmarshal(content, &snapshot); err != nil {
+		return snapshot, fmt.Errorf("invalid snapshot file %s: %w", name, err)
+	}
+	if snapshot.Version != snapshotVersion {
+		return snapshot, fmt.Errorf("snapshot file %s has version %d, which mirip %s doesn't read: upgrade mirip",
+			name, snapshot.Version, Version)
+	}
+	return snapshot, nil
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// staged holds the output files of the runs with -atomic-run, written
+// together once every run succeeded. It is nil otherwise.
+var staged *staging
+
+// staging is the content of output files to be written together.
+type staging struct {
+	names    []string
+	contents map[string][]byte
+}
+
+func newStaging() *staging {
+	return &staging{contents: make(map[string][]byte)}
+}
+
+// stage records the content of the output file of the given name.
+func (s *staging) stage(name string, content []byte) {
+	if _, ok := s.contents[name]; !ok {
+		s.names = append(s.names, name)
+	}
+	s.contents[name] = content
+}
+
+// commit writes the staged files, each to a temporary file of its
+// directory first, renamed over the file once all of them are written.
+// The files replaced are moved aside, to a backup of their directory, for
+// a failure to write or rename one to restore those renamed before it,
+// leaving the output files as they were.
+func (s *staging) commit() error {
+	tmps := make([]string, 0, len(s.names))
+	cleanup := func() {
+		for _, tmp := range tmps {
+			_ = os.Remove(tmp)
+		}
+	}
+
+	for _, name := range s.names {
+		dir := filepath.Dir(osPath(name))
+		if err := os.MkdirAll(dir, 0750); err != nil {
+			cleanup()
+			return writeError{err}
+		}
+		// The go command ignores the files starting with a dot.
+		f, err := os.CreateTemp(dir, "."+filepath.Base(name)+".*")
+		if err != nil {
+			cleanup()
+			return writeError{err}
+		}
+		tmps = append(tmps, f.Name())
+		_, err = f.Write(s.contents[name])
+		if closeErr := f.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			cleanup()
+			return writeError{err}
+		}
+	}
+
+	backups := make([]string, len(s.names))
+	// restore puts back the files replaced by the first n staged files,
+	// removing those which are new.
+	restore := func(n int) {
+		for i := n - 1; i >= 0; i-- {
+			if backups[i] != "" {
+				_ = os.Rename(backups[i], osPath(s.names[i]))
+			} else {
+				_ = os.Remove(osPath(s.names[i]))
+			}
+		}
+	}
+	for i, name := range s.names {
+		backup, err := moveAside(osPath(name))
+		if err == nil {
+			if err = os.Rename(tmps[i], osPath(name)); err != nil && backup != "" {
+				_ = os.Rename(backup, osPath(name))
+			}
+		}
+		if err != nil {
+			restore(i)
+			tmps = tmps[i:]
+			cleanup()
+			return writeError{err}
+		}
+		backups[i] = backup
+	}
+	for _, backup := range backups {
+		if backup != "" {
+			_ = os.Remove(backup)
+		}
+	}
+	return nil
+}
+
+// moveAside renames the file at path to a backup of its directory, whose
+// path it returns, or returns "" if there is no file at path. Directories
+// are left in place, for the staged file to fail to replace them.
+func moveAside(path string) (string, error) {
+	info, err := os.Lstat(path)
+	if errors.Is(err, fs.ErrNotExist) || err == nil && info.IsDir() {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+
+	f, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*.orig")
+	if err != nil {
+		return "", err
+	}
+	backup := f.Name()
+	if err := f.Close(); err != nil {
+		_ = os.Remove(backup)
+		return "", err
+	}
+	if err := os.Rename(path, backup); err != nil {
+		_ = os.Remove(backup)
+		return "", err
+	}
+	return backup, nil
+}
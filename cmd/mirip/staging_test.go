@@ -0,0 +1,82 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+)
+
+// TestStagingCommitRestores makes the rename of the second staged file
+// fail, over a directory, and checks that the staged files renamed before
+// it are undone: the file replaced has its content back, the new one is
+// removed, and neither the temporary files nor the backups are left.
+func TestStagingCommitRestores(t *testing.T) {
+	dir := t.TempDir()
+	replaced := filepath.Join(dir, "replaced_mock.go")
+	created := filepath.Join(dir, "created_mock.go")
+	blocked := filepath.Join(dir, "blocked_mock.go")
+	if err := os.WriteFile(replaced, []byte("package old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Mkdir(blocked, 0750); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newStaging()
+	s.stage(replaced, []byte("package replaced\n"))
+	s.stage(created, []byte("package created\n"))
+	s.stage(blocked, []byte("package blocked\n"))
+	if err := s.commit(); err == nil {
+		t.Fatal("commit succeeded, renaming a file over a directory")
+	}
+
+	if content, err := os.ReadFile(replaced); err != nil || string(content) != "package old\n" {
+		t.Errorf("%s = %q, %v, want the content it had", replaced, content, err)
+	}
+	if _, err := os.Stat(created); !os.IsNotExist(err) {
+		t.Errorf("%s is left, want it removed: %v", created, err)
+	}
+	if info, err := os.Stat(blocked); err != nil || !info.IsDir() {
+		t.Errorf("%s isn't the directory it was: %v", blocked, err)
+	}
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, entry := range entries {
+		names = append(names, entry.Name())
+	}
+	sort.Strings(names)
+	if len(names) != 2 || names[0] != "blocked_mock.go" || names[1] != "replaced_mock.go" {
+		t.Errorf("the files left are %v, want only blocked_mock.go and replaced_mock.go", names)
+	}
+}
+
+// TestStagingCommit checks that the staged files are written, the files
+// replaced leaving no backups.
+func TestStagingCommit(t *testing.T) {
+	dir := t.TempDir()
+	replaced := filepath.Join(dir, "replaced_mock.go")
+	created := filepath.Join(dir, "mocks", "created_mock.go")
+	if err := os.WriteFile(replaced, []byte("package old\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s := newStaging()
+	s.stage(replaced, []byte("package replaced\n"))
+	s.stage(created, []byte("package created\n"))
+	if err := s.commit(); err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string]string{replaced: "package replaced\n", created: "package created\n"} {
+		if content, err := os.ReadFile(path); err != nil || string(content) != want {
+			t.Errorf("%s = %q, %v, want %q", path, content, err, want)
+		}
+	}
+	if entries, err := os.ReadDir(dir); err != nil || len(entries) != 2 {
+		t.Errorf("%d files left in %s, want replaced_mock.go and mocks: %v", len(entries), dir, err)
+	}
+}
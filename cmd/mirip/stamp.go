@@ -0,0 +1,82 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strings"
+
+	"golang.org/x/mod/semver"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// minVersion is the earliest version of mirip generating the same code as
+// this one, recorded in the stamp of the mocks. It is bumped to the next
+// release by any change of the code generated for the arguments which
+// older versions accept, such as the methods added to the builders of
+// -builder, while new flags need none, as older versions reject them.
+const minVersion = "v1.5.0"
+
+// runFlags are the flags which change how mirip runs rather than the code
+// it generates, left out of the arguments in the stamp of the mocks.
+var runFlags = map[string]bool{
+	"verify-build": true, "cache": true, "rm": true, "lock": true, "stdout": true,
+	"v": true, "debug": true, "json": true, "quiet": true, "check": true, "j": true,
+	"cpuprofile": true, "memprofile": true, "trace": true, "timings": true, "stats": true, "method": true,
+	"dry-run": true, "log-file": true, "C": true, "isolated": true, "allow-outside-module": true,
+}
+
+// stampArgs returns the arguments of mirip, parsed by fs, recorded in the
+// stamp of the mocks: shell-quoted and without the run flags, so that
+// checking the mocks, such as with -check, doesn't change their stamp,
+// and with slashes, so that the mocks generated on Windows are the same.
+func stampArgs(fs *flag.FlagSet, args []string) []string {
+	var stamped []string
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" || arg == "-" || !strings.HasPrefix(arg, "-") {
+			for _, arg := range args[i:] {
+				stamped = append(stamped, shellQuote(slashed(arg)))
+			}
+			break
+		}
+
+		name, _, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		takesValue := !hasValue
+		if f := fs.Lookup(name); f != nil {
+			if b, ok := f.Value.(interface{ IsBoolFlag() bool }); ok && b.IsBoolFlag() {
+				takesValue = false
+			}
+		}
+		if runFlags[name] {
+			if takesValue {
+				i++
+			}
+			continue
+		}
+		stamped = append(stamped, shellQuote(slashed(arg)))
+		if takesValue && i+1 < len(args) {
+			i++
+			stamped = append(stamped, shellQuote(slashed(args[i])))
+		}
+	}
+	return stamped
+}
+
+// versionSkew returns how the version of mirip which generated the
+// existing output file, differing from its regeneration, generates other
+// code than this one, if it does, so that mixed versions across a team
+// are pointed out rather than their diffs committed.
+func versionSkew(existing []byte) string {
+	version, min := mirip.StampVersions(existing)
+	switch {
+	case version == "" || version == Version || min == minVersion:
+		return ""
+	case semver.IsValid(min) && semver.Compare(min, minVersion) > 0:
+		return fmt.Sprintf("generated by mirip %s, which generates other code than this mirip %s: upgrade mirip to %s or later rather than regenerating it with an older version",
+			version, Version, min)
+	default:
+		return fmt.Sprintf("generated by mirip %s, whose code differs from that of mirip %s and later: the regeneration also changes what that version generated, upgrade mirip across the team",
+			version, minVersion)
+	}
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// stats collects the summary of the run with -stats, over every run of a
+// config file, printed on stderr or along with the results of -json. It
+// is nil otherwise.
+var stats *runStats
+
+// runStats is the summary of a run of mirip.
+type runStats struct {
+	Packages   int            `json:"packages"`
+	Interfaces int            `json:"interfaces"`
+	Files      map[string]int `json:"files"`
+	Phases     phaseStats     `json:"phases_ms"`
+
+	start    time.Time
+	packages map[string]bool
+}
+
+// phaseStats are the times spent in each phase of generation, in
+// milliseconds, along with the total time of the run.
+type phaseStats struct {
+	Load    int64 `json:"load"`
+	Resolve int64 `json:"resolve"`
+	Render  int64 `json:"render"`
+	Format  int64 `json:"format"`
+	Total   int64 `json:"total"`
+}
+
+func newRunStats() *runStats {
+	return &runStats{
+		Files:    make(map[string]int),
+		start:    time.Now(),
+		packages: make(map[string]bool),
+	}
+}
+
+// addPackages counts the source packages of the configs, loaded once per
+// directory.
+func (s *runStats) addPackages(cfgs []mirip.Config) {
+	if s == nil {
+		return
+	}
+	for _, cfg := range cfgs {
+		dir, err := filepath.Abs(cfg.SrcDir)
+		if err != nil {
+			dir = cfg.SrcDir
+		}
+		if !s.packages[dir] {
+			s.packages[dir] = true
+			s.Packages++
+		}
+	}
+}
+
+// addFile counts the output file of the given status generated for the
+// given interfaces.
+func (s *runStats) addFile(namePairs []string, status string) {
+	if s == nil {
+		return
+	}
+	s.Interfaces += len(namePairs)
+	s.Files[status]++
+}
+
+// addTimings adds the times of the phases of a run.
+func (s *runStats) addTimings(t *mirip.Timings) {
+	if s == nil || t == nil {
+		return
+	}
+	s.Phases.Load += t.Load.Milliseconds()
+	s.Phases.Resolve += t.Resolve.Milliseconds()
+	s.Phases.Render += t.Render.Milliseconds()
+	s.Phases.Format += t.Format.Milliseconds()
+}
+
+// finish records the total time of the run.
+func (s *runStats) finish() {
+	s.Phases.Total = time.Since(s.start).Milliseconds()
+}
+
+// print prints the summary on a line, such as 'mirip: 2 packages loaded,
+// 5 interfaces generated in 4 files (3 up-to-date, 1 written); load 310ms,
+// resolve 4ms, render 2ms, format 6ms, total 340ms'.
+func (s *runStats) print(w io.Writer) {
+	var files int
+	var names []string
+	for status, n := range s.Files {
+		files += n
+		names = append(names, status)
+	}
+	sort.Strings(names)
+	statuses := make([]string, len(names))
+	for i, status := range names {
+		statuses[i] = fmt.Sprintf("%d %s", s.Files[status], status)
+	}
+
+	summary := fmt.Sprintf("mirip: %d packages loaded, %d interfaces generated in %d files",
+		s.Packages, s.Interfaces, files)
+	if len(statuses) != 0 {
+		summary += " (" + strings.Join(statuses, ", ") + ")"
+	}
+	ms := func(n int64) time.Duration { return time.Duration(n) * time.Millisecond }
+	_, _ = fmt.Fprintf(w, "%s; load %s, resolve %s, render %s, format %s, total %s\n", summary,
+		ms(s.Phases.Load), ms(s.Phases.Resolve), ms(s.Phases.Render), ms(s.Phases.Format), ms(s.Phases.Total))
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"runtime"
+)
+
+// stdinDir is the source dir reading the source from stdin.
+const stdinDir = "-"
+
+// goVersion matches the language version of the Go release of mirip.
+var goVersion = regexp.MustCompile(`^go(\d+\.\d+)`)
+
+// stdinPackage writes the Go file read from r to a package of its own, in
+// a temporary module without dependencies, so that it can be loaded like
+// any source dir. The file may only import the standard library. The
+// returned func removes the package.
+func stdinPackage(r io.Reader) (string, func(), error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return "", nil, err
+	}
+
+	dir, err := os.MkdirTemp("", "mirip-stdin-")
+	if err != nil {
+		return "", nil, err
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(dir)
+	}
+
+	version := "1.19"
+	if m := goVersion.FindStringSubmatch(runtime.Version()); m != nil {
+		version = m[1]
+	}
+	gomod := "module stdin\n\ngo " + version + "\n"
+	if err := os.WriteFile(filepath.Join(dir, "go.mod"), []byte(gomod), 0600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, "stdin.go"), src, 0600); err != nil {
+		cleanup()
+		return "", nil, err
+	}
+	return dir, cleanup, nil
+}
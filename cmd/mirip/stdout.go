@@ -0,0 +1,82 @@
+package main
+
+import (
+	"archive/tar"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Formats of the output files written to stdout with -stdout.
+const (
+	stdoutMarkers = "markers"
+	stdoutTar     = "tar"
+)
+
+// stdoutFiles writes the output files to stdout instead of the files, if
+// set with -stdout.
+var stdoutFiles *stdoutWriter
+
+// stdoutWriter writes output files to stdout, each preceded by a
+// '// -- file: path --' marker line, or as a tar archive. The files of a
+// reproducible archive are dated to the Unix epoch, rather than the time
+// they are written at, for the same files to make the same archive.
+type stdoutWriter struct {
+	format       string
+	tw           *tar.Writer
+	reproducible bool
+}
+
+func newStdoutWriter(format string, reproducible bool) (*stdoutWriter, error) {
+	switch format {
+	case stdoutMarkers:
+		return &stdoutWriter{format: format}, nil
+	case stdoutTar:
+		return &stdoutWriter{format: format, tw: tar.NewWriter(os.Stdout), reproducible: reproducible}, nil
+	}
+	return nil, usageError{"unknown stdout format: " + format}
+}
+
+// write writes the output file of the given name and content.
+func (w *stdoutWriter) write(name string, content []byte) error {
+	if w.tw == nil {
+		if _, err := fmt.Printf("// -- file: %s --\n", filepath.ToSlash(name)); err != nil {
+			return writeError{err}
+		}
+		_, err := os.Stdout.Write(content)
+		if err != nil {
+			return writeError{err}
+		}
+		return nil
+	}
+
+	modTime := time.Now()
+	if w.reproducible {
+		modTime = time.Unix(0, 0)
+	}
+	err := w.tw.WriteHeader(&tar.Header{
+		Name:    filepath.ToSlash(name),
+		Mode:    0600,
+		Size:    int64(len(content)),
+		ModTime: modTime,
+	})
+	if err == nil {
+		_, err = w.tw.Write(content)
+	}
+	if err != nil {
+		return writeError{err}
+	}
+	return nil
+}
+
+// close ends the tar archive, if any.
+func (w *stdoutWriter) close() error {
+	if w.tw == nil {
+		return nil
+	}
+	if err := w.tw.Close(); err != nil {
+		return writeError{err}
+	}
+	return nil
+}
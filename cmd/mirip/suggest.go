@@ -0,0 +1,75 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+	"github.com/gmhafiz/mirip/internal/suggest"
+)
+
+func runSuggest(args []string) error {
+	fs := flag.NewFlagSet("suggest", flag.ExitOnError)
+	generate := fs.Bool("generate", false, "generate the suggested mocks instead of only printing the go:generate directives")
+	fs.Usage = func() {
+		fmt.Println(`mirip suggest [flags] package-dir`)
+		fs.PrintDefaults()
+		fmt.Println(`Suggests mocks for the interfaces the package depends on which its tests hand-stub or leave unset.`)
+		fmt.Println(`Ex: mirip suggest ./service`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return usageError{"expected a package dir"}
+	}
+	dir := fs.Arg(0)
+
+	suggestions, err := suggest.Analyze(dir)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range suggestions {
+		for _, reason := range s.Reasons {
+			fmt.Println(reason)
+		}
+		if s.SrcDir == "" {
+			fmt.Printf("%s is outside of the module and can't be mocked from a go:generate directive\n", s.Interface)
+		}
+	}
+
+	directives := suggest.Directives(suggestions)
+	for _, d := range directives {
+		fmt.Println(d)
+	}
+	if !*generate || len(directives) == 0 {
+		return nil
+	}
+
+	cfgs := make([]mirip.Config, len(directives))
+	for i, d := range directives {
+		cfgs[i] = mirip.Config{
+			SrcDir:  filepath.Join(dir, d.SrcDir),
+			PkgName: d.PkgName,
+			OutFile: filepath.Join(dir, d.OutFile),
+		}
+	}
+	mockers, err := mirip.NewAll(cfgs...)
+	if err != nil {
+		return err
+	}
+
+	for i, d := range directives {
+		var buf bytes.Buffer
+		if err := mockers[i].Mock(&buf, d.Interfaces...); err != nil {
+			return err
+		}
+		if err := writeFile(cfgs[i].OutFile, buf.Bytes()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runTemplate(args []string) error {
+	fs := flag.NewFlagSet("template", flag.ExitOnError)
+	kind := fs.String("kind", mirip.KindMock, "kind whose template the templates replace")
+	stub := fs.String("stub", "", "stub the templates are rendered with")
+	fs.Usage = func() {
+		fmt.Println(`mirip template check [flags] template-file [template-file ...]`)
+		fs.PrintDefaults()
+		fmt.Println(`Checks the templates for -template: the fields and methods they refer to must be in the data of`)
+		fmt.Println(`the templates, and the code they generate for a synthetic interface must format and build.`)
+		fmt.Println(`Ex: mirip template check mock.tmpl`)
+	}
+	if len(args) == 0 || args[0] != "check" {
+		fs.Usage()
+		return usageError{"expected the check subcommand"}
+	}
+	_ = fs.Parse(args[1:])
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return usageError{"expected a template file"}
+	}
+
+	failed := 0
+	for _, name := range fs.Args() {
+		text, err := os.ReadFile(name)
+		if err != nil {
+			return err
+		}
+		if err := mirip.CheckTemplate(name, string(text), mirip.Config{Kind: *kind, Stub: *stub}); err != nil {
+			fmt.Printf("%s:\n%s\n", name, err)
+			failed++
+			continue
+		}
+		fmt.Printf("%s: ok\n", name)
+	}
+	if failed != 0 {
+		return fmt.Errorf("%d of %d templates failed the check", failed, fs.NArg())
+	}
+	return nil
+}
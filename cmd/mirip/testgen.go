@@ -0,0 +1,63 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+func runTestgen(args []string) error {
+	fs := flag.NewFlagSet("testgen", flag.ExitOnError)
+	outFile := fs.String("out", "", "output file (default stdout)")
+	prefix := fs.String("prefix", "", "prefix of the mock names")
+	suffix := fs.String("suffix", "", "suffix of the mock names (default Mock, unless -prefix is set)")
+	aliasFile := fs.String("aliases", "", "file mapping interfaces to mock names, one 'Interface=Alias' per line")
+	fs.Usage = func() {
+		fmt.Println(`mirip testgen [flags] source-dir function`)
+		fs.PrintDefaults()
+		fmt.Println(`Generates a table-driven test skeleton for the function, mocking its interface parameters.`)
+		fmt.Println(`Ex: mirip testgen -out service_test.go . NewService`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 2 {
+		fs.Usage()
+		return usageError{"expected a source dir and a function"}
+	}
+
+	aliases, err := readAliases(*aliasFile)
+	if err != nil {
+		return err
+	}
+
+	m, err := mirip.New(mirip.Config{
+		SrcDir:  fs.Arg(0),
+		Prefix:  *prefix,
+		Suffix:  *suffix,
+		Aliases: aliases,
+		Warn:    warn,
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	var out io.Writer = os.Stdout
+	if *outFile != "" {
+		out = &buf
+	}
+
+	if err := m.TestSkeleton(out, fs.Arg(1)); err != nil {
+		return err
+	}
+
+	if *outFile == "" {
+		return nil
+	}
+
+	return writeFile(*outFile, buf.Bytes())
+}
@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/suggest"
+)
+
+func runUnused(args []string) error {
+	fs := flag.NewFlagSet("unused", flag.ExitOnError)
+	fs.Usage = func() {
+		fmt.Println(`mirip unused [module-dir]`)
+		fmt.Println(`Reports the mocks generated by mirip in the module which are likely dead: none of their Func fields`)
+		fmt.Println(`is assigned and none of their calls is asserted, along with the test files still naming them.`)
+		fmt.Println(`Ex: mirip unused .`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() > 1 {
+		fs.Usage()
+		return usageError{"expected at most a module dir"}
+	}
+	dir := "."
+	if fs.NArg() == 1 {
+		dir = fs.Arg(0)
+	}
+	root, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+
+	unused, err := suggest.FindUnused(dir)
+	if err != nil {
+		return err
+	}
+	for _, u := range unused {
+		usedBy := "no test"
+		if len(u.UsedBy) != 0 {
+			files := make([]string, len(u.UsedBy))
+			for i, name := range u.UsedBy {
+				files[i] = shownPath(root, name)
+			}
+			usedBy = strings.Join(files, ", ")
+		}
+		fmt.Printf("%s: %s of %s is neither configured nor asserted, named by %s\n",
+			shownPath(root, u.File), u.Name, u.Interface, usedBy)
+	}
+	if len(unused) != 0 {
+		return fmt.Errorf("%d mocks are likely unused", len(unused))
+	}
+	return nil
+}
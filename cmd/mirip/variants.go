@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// variant is a platform of -variants, in the format 'goos' or
+// 'goos/goarch'.
+type variant struct {
+	goos   string
+	goarch string
+}
+
+// suffix returns the suffix of the output files of the variant, such as
+// '_windows_amd64', which the go command also reads as a constraint.
+func (v variant) suffix() string {
+	if v.goarch == "" {
+		return "_" + v.goos
+	}
+	return "_" + v.goos + "_" + v.goarch
+}
+
+// constraint returns the expression of the go:build line of the output
+// files of the variant.
+func (v variant) constraint() string {
+	if v.goarch == "" {
+		return v.goos
+	}
+	return v.goos + " && " + v.goarch
+}
+
+// parseVariants returns the variants of -variants, separated by commas.
+func parseVariants(flags userFlags) ([]variant, error) {
+	switch {
+	case flags.goos != "" || flags.goarch != "":
+		return nil, usageError{"-variants and -goos or -goarch are exclusive"}
+	case flags.outFile == "" && flags.outDir == "":
+		return nil, usageError{"-variants requires -out or -out-dir"}
+	case flags.ensureFile != "" || flags.example != "" || flags.conformance != "" || flags.builderSpec != "":
+		return nil, usageError{"-variants can't be used with -ensure-file, -example, -conformance or -builder-spec"}
+	}
+
+	var variants []variant
+	seen := make(map[variant]bool)
+	for _, platform := range strings.Split(flags.variants, ",") {
+		goos, goarch, _ := strings.Cut(platform, "/")
+		v := variant{goos: goos, goarch: goarch}
+		switch {
+		case goos == "" || strings.HasSuffix(platform, "/") || strings.Contains(goarch, "/"):
+			return nil, usageError{fmt.Sprintf("variant %q isn't in the format goos or goos/goarch", platform)}
+		case seen[v]:
+			return nil, usageError{fmt.Sprintf("variant %s is given twice to -variants", platform)}
+		}
+		seen[v] = true
+		variants = append(variants, v)
+	}
+	return variants, nil
+}
+
+// withVariants returns a config of each of the given variants for each
+// config, along with the source dir of each config. The source packages
+// are loaded for the platform of the variant, and the mocks written to
+// files suffixed with it, such as 'store_mock_windows.go', constrained to
+// the platform. The configs of a variant are consecutive.
+func withVariants(cfgs []mirip.Config, srcs sources, variants []variant) ([]mirip.Config, sources) {
+	variantCfgs := make([]mirip.Config, 0, len(cfgs)*len(variants))
+	variantSrcs := make(sources, 0, len(cfgs)*len(variants))
+	for _, v := range variants {
+		for i, cfg := range cfgs {
+			cfg.GOOS, cfg.GOARCH = v.goos, v.goarch
+			cfg.Constraint = v.constraint()
+			if cfg.OutDir != "" && cfg.OutFile == "" {
+				cfg.OutFile = mirip.InterfacePlaceholder + "_mock.go"
+			}
+			cfg.OutFile = variantFile(cfg.OutFile, v)
+			variantCfgs = append(variantCfgs, cfg)
+			variantSrcs = append(variantSrcs, srcs[i])
+		}
+	}
+	return variantCfgs, variantSrcs
+}
+
+// variantFile returns the name of the output file suffixed with the
+// variant, before the _test.go or .go extension.
+func variantFile(name string, v variant) string {
+	for _, ext := range []string{"_test.go", ".go"} {
+		if strings.HasSuffix(name, ext) {
+			return strings.TrimSuffix(name, ext) + v.suffix() + ext
+		}
+	}
+	return name + v.suffix()
+}
+
+// newMockers makes the mockers of the configs, loading together the
+// source packages of the consecutive configs of the same platform.
+func newMockers(cfgs []mirip.Config) ([]*mirip.Mocker, error) {
+	if len(cfgs) == 0 {
+		return mirip.NewAll()
+	}
+
+	var mockers []*mirip.Mocker
+	for start := 0; start < len(cfgs); {
+		end := start + 1
+		for end < len(cfgs) && cfgs[end].GOOS == cfgs[start].GOOS && cfgs[end].GOARCH == cfgs[start].GOARCH {
+			end++
+		}
+		platform, err := mirip.NewAll(cfgs[start:end]...)
+		if err != nil {
+			return nil, err
+		}
+		mockers = append(mockers, platform...)
+		start = end
+	}
+	return mockers, nil
+}
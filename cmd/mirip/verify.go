@@ -0,0 +1,224 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// generatedHeader is the first line of the files generated by mirip.
+const generatedHeader = "// Code generated by mirip; DO NOT EDIT."
+
+func runVerify(args []string) error {
+	fs := flag.NewFlagSet("verify", flag.ExitOnError)
+	lockName := fs.String("lock", "mirip.lock", "lock file recording the output files")
+	fs.Usage = func() {
+		fmt.Println(`mirip verify [flags]`)
+		fs.PrintDefaults()
+		fmt.Println(`Checks the output files recorded in the lock file are present, unedited and up to date, and that`)
+		fmt.Println(`no file generated by mirip under the directory of the lock file is missing from it.`)
+		fmt.Println(`Ex: mirip verify -lock mirip.lock`)
+	}
+	_ = fs.Parse(args)
+
+	if fs.NArg() != 0 {
+		fs.Usage()
+		return usageError{"expected no arguments"}
+	}
+
+	lock, err := readLock(*lockName)
+	if err != nil {
+		return err
+	}
+	root, err := filepath.Abs(filepath.Dir(*lockName))
+	if err != nil {
+		return err
+	}
+
+	problems := 0
+	report := func(format string, args ...interface{}) {
+		problems++
+		fmt.Printf(format+"\n", args...)
+	}
+
+	// The files are regenerated once per command, in check mode.
+	type command struct {
+		dir  string
+		args []string
+	}
+	var commands []command
+	seen := make(map[string]bool)
+	recorded := make(map[string]bool)
+	for _, e := range lock.Files {
+		recorded[e.File] = true
+
+		name := filepath.Join(root, filepath.FromSlash(e.File))
+		hash, err := hashFile(name)
+		switch {
+		case os.IsNotExist(err):
+			report("missing: %s", e.File)
+		case err != nil:
+			return err
+		case hash != e.Hash:
+			report("edited: %s", e.File)
+		}
+
+		key := e.Dir + "\x00" + strings.Join(e.Args, "\x00")
+		if !seen[key] {
+			seen[key] = true
+			commands = append(commands, command{dir: e.Dir, args: e.Args})
+		}
+	}
+
+	for _, c := range commands {
+		err := regenerate(filepath.Join(root, filepath.FromSlash(c.dir)), c.args)
+		var driftErr driftError
+		switch {
+		case errors.As(err, &driftErr):
+			if driftErr.skew != "" {
+				report("stale: %s in %s, %s", driftErr.name, c.dir, driftErr.skew)
+				break
+			}
+			report("stale: %s in %s, regenerate with: mirip %s", driftErr.name, c.dir, strings.Join(c.args, " "))
+		case err != nil:
+			report("failed: mirip %s in %s: %s", strings.Join(c.args, " "), c.dir, err)
+		}
+	}
+
+	orphans, err := generatedFiles(root)
+	if err != nil {
+		return err
+	}
+	for _, file := range orphans {
+		if !recorded[file] {
+			report("orphaned: %s", file)
+		}
+	}
+
+	if problems != 0 {
+		return driftError{name: *lockName}
+	}
+	fmt.Printf("%d output files are up to date\n", len(lock.Files))
+	return nil
+}
+
+// regenerate runs mirip with the given arguments from dir in check mode,
+// printing nothing.
+func regenerate(dir string, args []string) error {
+	defer func(check, q bool) {
+		checkOnly, quiet = check, q
+	}(checkOnly, quiet)
+	checkOnly, quiet = true, true
+
+	return runArgs(dir, args, nil)
+}
+
+// runArgs runs mirip with the given arguments from dir, without -json,
+// -v or profiling. check, if not nil, checks the parsed flags first.
+func runArgs(dir string, args []string, check func(userFlags) error) error {
+	return inDir(dir, func() error {
+		flags, err := parseArgs(args)
+		if err != nil {
+			return err
+		}
+		flags.json, flags.verbose = false, false
+		if check != nil {
+			if err := check(flags); err != nil {
+				return err
+			}
+		}
+
+		return execute(flags)
+	})
+}
+
+// inDir calls f with dir as the working directory.
+func inDir(dir string, f func() error) error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+	if err := os.Chdir(dir); err != nil {
+		return err
+	}
+	defer func() {
+		_ = os.Chdir(wd)
+	}()
+
+	return f()
+}
+
+// parseArgs parses the arguments of a run of mirip within the process,
+// which can't profile it. The run is in check mode or quiet when the
+// process is.
+func parseArgs(args []string) (userFlags, error) {
+	var flags userFlags
+	fs := flag.NewFlagSet("mirip", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	check, q, dry := checkOnly, quiet, dryRun
+	flags.register(fs)
+	checkOnly, quiet, dryRun = check, q, dry
+	expanded, err := expandArgs(args)
+	if err != nil {
+		return flags, err
+	}
+	if err := fs.Parse(expanded); err != nil {
+		return flags, usageError{err.Error()}
+	}
+	if flags.chdir != "" {
+		return flags, usageError{"-C can only be given to the mirip command"}
+	}
+	flags.args = fs.Args()
+	flags.argv = args
+	flags.cmdline = stampArgs(fs, args)
+	flags.profile = profileFlags{}
+	return flags, nil
+}
+
+// generatedFiles returns the Go files generated by mirip under root,
+// relative to it with slashes. Hidden directories, vendor and testdata
+// are skipped.
+func generatedFiles(root string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || name == "vendor" || name == "testdata") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || !isGenerated(path) {
+			return nil
+		}
+		rel, err := relPath(root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, rel)
+		return nil
+	})
+	return files, err
+}
+
+// isGenerated returns whether the file of the given name was generated by
+// mirip, from its first line.
+func isGenerated(name string) bool {
+	f, err := os.Open(name)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	line, _ := bufio.NewReader(f).ReadString('\n')
+	return strings.TrimRight(line, "\r\n") == generatedHeader
+}
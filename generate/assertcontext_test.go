@@ -0,0 +1,51 @@
+package generate
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// TestAssertContext checks that the context assertions of a mock pass for
+// the calls with the contexts asserted, and fail for the others.
+func TestAssertContext(t *testing.T) {
+	mock := &AssertedFetcher{
+		FetchFunc: func(context.Context, string) ([]byte, int, error) { return nil, 0, nil },
+		CloseFunc: func(context.Context) error { return nil },
+	}
+	ctx, cancel := context.WithTimeout(context.WithValue(context.Background(), RequestIDKey, "a"), time.Minute)
+	defer cancel()
+	mock.Fetch(ctx, "https://example.com")
+	mock.Fetch(context.WithValue(context.Background(), RequestIDKey, "b"), "https://example.com")
+	mock.Close(context.Background())
+
+	tb := &failingTB{TB: t}
+	mock.AssertFetchCalledWithContextValue(tb, RequestIDKey, "a")
+	mock.AssertFetchCalledWithContextValue(tb, RequestIDKey, "b")
+	mock.AssertCloseCalledWithContextValue(tb, RequestIDKey, nil)
+	if len(tb.errors) != 0 {
+		t.Errorf("the assertions of the contexts given failed with %q", tb.errors)
+	}
+
+	mock.AssertFetchCalledWithContextDeadline(tb)
+	if len(tb.errors) != 1 || tb.errors[0] != "AssertedFetcher.Fetch was called without a context deadline at call 1" {
+		t.Errorf("asserting the deadlines failed with %q, want only the call 1", tb.errors)
+	}
+	tb.errors = nil
+	mock.AssertFetchCalledWithContextValue(tb, RequestIDKey, "c")
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "in 2 calls") {
+		t.Errorf("asserting a value of no context failed with %q", tb.errors)
+	}
+	tb.errors = nil
+	mock.AssertLenCalledWithContextDeadline(tb)
+	if len(tb.errors) != 1 || tb.errors[0] != "AssertedFetcher.Len was not called" {
+		t.Errorf("asserting the deadlines of a method not called failed with %q", tb.errors)
+	}
+
+	want := []map[interface{}]interface{}{{RequestIDKey: "a"}, {RequestIDKey: "b"}}
+	if got := mock.FetchContextValues(); !reflect.DeepEqual(got, want) {
+		t.Errorf("FetchContextValues() = %v, want %v", got, want)
+	}
+}
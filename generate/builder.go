@@ -0,0 +1,13 @@
+package generate
+
+// The builder of Ledger declares its mock aside from the receiver of the
+// mock, named b like the receiver of the builder, and the parameters of
+// Post are renamed not to shadow the variables of its Build.
+
+//go:generate mirip -rm -no-stamp -builder -receiver b -out builder_mock.go . Ledger
+
+// Ledger is an interface whose mock is built with a builder.
+type Ledger interface {
+	Post(t string, f float64, mock bool) (int, error)
+	Balance(want string) float64
+}
@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+)
+
+// failingTB is a testing.TB recording the failures of the code under test,
+// Fatalf panicking with errFatal for the test to recover it.
+type failingTB struct {
+	testing.TB
+	errors []string
+}
+
+var errFatal = errors.New("Fatalf called")
+
+func (t *failingTB) Helper() {}
+
+func (t *failingTB) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func (t *failingTB) Fatalf(format string, args ...interface{}) {
+	t.Errorf(format, args...)
+	panic(errFatal)
+}
+
+// TestBuilderVerify checks that the verification of a built mock fails for
+// each method called other than the number of times expected, and only
+// for those.
+func TestBuilderVerify(t *testing.T) {
+	mock, verify := new(LedgerMockBuilder).
+		ReturnPost(1, nil).ExpectPostCalled(2).
+		ReturnBalance(2.5).ExpectBalanceCalled(1).
+		Build()
+	if n, err := mock.Post("t", 1, true); n != 1 || err != nil {
+		t.Errorf("Post() = %d, %v, want 1, nil", n, err)
+	}
+	if got := mock.Balance("want"); got != 2.5 {
+		t.Errorf("Balance() = %v, want 2.5", got)
+	}
+
+	tb := &failingTB{TB: t}
+	verify(tb)
+	if len(tb.errors) != 1 || tb.errors[0] != "LedgerMock.Post calls = 1, want 2" {
+		t.Errorf("the verification failed with %q, want only the calls to Post", tb.errors)
+	}
+	mock.Post("t", 1, true)
+	tb.errors = nil
+	verify(tb)
+	if len(tb.errors) != 0 {
+		t.Errorf("the verification failed with %q, want it to pass", tb.errors)
+	}
+}
+
+// TestBuilderFailFast checks that a mock built with FailFast fails the test
+// during the first call exceeding the calls expected, without calling its
+// Func, and that the methods without expectations are left unchecked.
+func TestBuilderFailFast(t *testing.T) {
+	tb := &failingTB{TB: t}
+	posted := 0
+	mock, _ := new(LedgerMockBuilder).
+		WithPost(func(string, float64, bool) (int, error) {
+			posted++
+			return posted, nil
+		}).ExpectPostCalled(1).
+		ReturnBalance(1).
+		FailFast(tb).
+		Build()
+	mock.Balance("want")
+	mock.Balance("want")
+	mock.Post("t", 1, true)
+	if len(tb.errors) != 0 {
+		t.Fatalf("the calls expected failed with %q", tb.errors)
+	}
+
+	func() {
+		defer func() {
+			if r := recover(); r != errFatal {
+				t.Errorf("the call exceeding the calls expected recovered %v, want Fatalf called", r)
+			}
+		}()
+		mock.Post("t", 1, true)
+	}()
+	if len(tb.errors) != 1 || tb.errors[0] != "LedgerMock.Post calls = 2, want 1" {
+		t.Errorf("the call exceeding the calls expected failed with %q", tb.errors)
+	}
+	if posted != 1 {
+		t.Errorf("PostFunc called %d times, want once", posted)
+	}
+}
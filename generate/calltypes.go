@@ -0,0 +1,14 @@
+package generate
+
+import "context"
+
+// The calls recorded by the mock of Queue are of exported types, which
+// the assertion helpers of tests can take.
+
+//go:generate mirip -rm -no-stamp -call-types -out calltypes_mock.go . Queue
+
+// Queue is a generic interface whose calls are of exported types.
+type Queue[T any] interface {
+	Push(ctx context.Context, items ...T) error
+	Pop() (T, bool)
+}
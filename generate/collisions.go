@@ -0,0 +1,29 @@
+package generate
+
+// The mocks of Collisions check that the helpers of the generated code are
+// renamed rather than colliding with the methods, as go build fails
+// otherwise.
+
+//go:generate mirip -rm -no-stamp -stub random -lock-per-method -toggle-recording -out collisions_mock.go . Collisions
+//go:generate mirip -rm -no-stamp -kind chaos -out collisions_chaos.go . Collisions
+//go:generate mirip -rm -no-stamp -kind recorder -out collisions_recorder.go . Collisions
+
+// Collisions is an interface whose methods are named like the helpers of
+// the mocks, and their parameters like the variables of the generated code.
+type Collisions interface {
+	calls(m int) int
+	lock(calls, lock int) error
+	Rand(ptrs ...interface{}) string
+	Rand1()
+	randomize() bool
+	MaxCalls() int
+	firstCall(calls int) int
+	Impl() error
+	Policy(method string) error
+	inject(method string) error
+	WriteFixture(w, v int) error
+	replay(args []interface{}) error
+	replayed() bool
+	OneCalls()
+	One(results, err int)
+}
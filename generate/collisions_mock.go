@@ -0,0 +1,783 @@
+// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+//
+// mirip:version dev
+// mirip:min-version v1.5.0
+// mirip:source github.com/gmhafiz/mirip/generate.Collisions
+// mirip:args -no-stamp -stub random -lock-per-method -toggle-recording -out collisions_mock.go . Collisions
+
+package generate
+
+import (
+	"math/rand"
+	"reflect"
+	"sync"
+	"testing/quick"
+	"time"
+)
+
+// Ensure that CollisionsMock implements Collisions.
+var _ Collisions = &CollisionsMock{}
+
+// CollisionsMock is a mock implementation of Collisions.
+type CollisionsMock struct {
+	ImplFunc         func() error
+	MaxCallsFunc     func() int
+	OneFunc          func(results int, err int)
+	OneCallsFunc     func()
+	PolicyFunc       func(method string) error
+	RandFunc         func(ptrs ...interface{}) string
+	Rand1Func        func()
+	WriteFixtureFunc func(w int, v int) error
+	callsFunc        func(mMiripParam int) int
+	firstCallFunc    func(calls int) int
+	injectFunc       func(method string) error
+	lockFunc         func(calls int, lock int) error
+	randomizeFunc    func() bool
+	replayFunc       func(args []interface{}) error
+	replayedFunc     func() bool
+
+	// Rand2 is the source of the random values returned by methods without
+	// a Func. It is seeded with the current time when nil.
+	Rand2 *rand.Rand
+
+	// MaxCalls1, if positive, is the number of the last calls of each method
+	// kept by the mock, dropping the earlier ones.
+	MaxCalls1 int
+
+	recordingDisabled bool
+
+	calls1 struct {
+		Impl     []struct{}
+		MaxCalls []struct{}
+		One      []struct {
+			Results int
+			Err     int
+		}
+		OneCalls []struct{}
+		Policy   []struct {
+			Method string
+		}
+		Rand []struct {
+			Ptrs []interface{}
+		}
+		Rand1        []struct{}
+		WriteFixture []struct {
+			W int
+			V int
+		}
+		calls []struct {
+			MMiripParam int
+		}
+		firstCall []struct {
+			Calls int
+		}
+		inject []struct {
+			Method string
+		}
+		lock []struct {
+			Calls int
+			Lock  int
+		}
+		randomize []struct{}
+		replay    []struct {
+			Args []interface{}
+		}
+		replayed []struct{}
+	}
+	lock1 struct {
+		Impl         sync.RWMutex
+		MaxCalls     sync.RWMutex
+		One          sync.RWMutex
+		OneCalls     sync.RWMutex
+		Policy       sync.RWMutex
+		Rand         sync.RWMutex
+		Rand1        sync.RWMutex
+		WriteFixture sync.RWMutex
+		calls        sync.RWMutex
+		firstCall    sync.RWMutex
+		inject       sync.RWMutex
+		lock         sync.RWMutex
+		randomize    sync.RWMutex
+		replay       sync.RWMutex
+		replayed     sync.RWMutex
+		Rand2        sync.Mutex
+	}
+}
+
+func (m *CollisionsMock) Impl() error {
+	m.lock1.Impl.Lock()
+	if !m.recordingDisabled {
+		m.calls1.Impl = append(m.calls1.Impl[m.firstCall1(len(m.calls1.Impl)):], struct{}{})
+	}
+	m.lock1.Impl.Unlock()
+	if m.ImplFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.ImplFunc()
+}
+
+// ImplCalls returns the calls made to Impl.
+func (m *CollisionsMock) ImplCalls() []struct{} {
+	m.lock1.Impl.RLock()
+	defer m.lock1.Impl.RUnlock()
+	return m.calls1.Impl
+}
+
+// ImplCallsSnapshot returns a copy of the calls made to Impl,
+// which the calls recorded later don't change, for reading them while
+// Impl may still be called concurrently.
+func (m *CollisionsMock) ImplCallsSnapshot() []struct{} {
+	m.lock1.Impl.RLock()
+	defer m.lock1.Impl.RUnlock()
+	calls := make([]struct{}, len(m.calls1.Impl))
+	copy(calls, m.calls1.Impl)
+	return calls
+}
+
+func (m *CollisionsMock) MaxCalls() int {
+	m.lock1.MaxCalls.Lock()
+	if !m.recordingDisabled {
+		m.calls1.MaxCalls = append(m.calls1.MaxCalls[m.firstCall1(len(m.calls1.MaxCalls)):], struct{}{})
+	}
+	m.lock1.MaxCalls.Unlock()
+	if m.MaxCallsFunc == nil {
+		var (
+			nOut int
+		)
+		m.randomize1(&nOut)
+		return nOut
+	}
+	return m.MaxCallsFunc()
+}
+
+// MaxCallsCalls returns the calls made to MaxCalls.
+func (m *CollisionsMock) MaxCallsCalls() []struct{} {
+	m.lock1.MaxCalls.RLock()
+	defer m.lock1.MaxCalls.RUnlock()
+	return m.calls1.MaxCalls
+}
+
+// MaxCallsCallsSnapshot returns a copy of the calls made to MaxCalls,
+// which the calls recorded later don't change, for reading them while
+// MaxCalls may still be called concurrently.
+func (m *CollisionsMock) MaxCallsCallsSnapshot() []struct{} {
+	m.lock1.MaxCalls.RLock()
+	defer m.lock1.MaxCalls.RUnlock()
+	calls := make([]struct{}, len(m.calls1.MaxCalls))
+	copy(calls, m.calls1.MaxCalls)
+	return calls
+}
+
+func (m *CollisionsMock) One(results int, err int) {
+	m.lock1.One.Lock()
+	if !m.recordingDisabled {
+		m.calls1.One = append(m.calls1.One[m.firstCall1(len(m.calls1.One)):], struct {
+			Results int
+			Err     int
+		}{
+			Results: results,
+			Err:     err,
+		})
+	}
+	m.lock1.One.Unlock()
+	if m.OneFunc == nil {
+		return
+	}
+	m.OneFunc(results, err)
+}
+
+// OneCalls1 returns the calls made to One.
+func (m *CollisionsMock) OneCalls1() []struct {
+	Results int
+	Err     int
+} {
+	m.lock1.One.RLock()
+	defer m.lock1.One.RUnlock()
+	return m.calls1.One
+}
+
+// OneCallsSnapshot returns a copy of the calls made to One,
+// which the calls recorded later don't change, for reading them while
+// One may still be called concurrently.
+func (m *CollisionsMock) OneCallsSnapshot() []struct {
+	Results int
+	Err     int
+} {
+	m.lock1.One.RLock()
+	defer m.lock1.One.RUnlock()
+	calls := make([]struct {
+		Results int
+		Err     int
+	}, len(m.calls1.One))
+	copy(calls, m.calls1.One)
+	return calls
+}
+
+func (m *CollisionsMock) OneCalls() {
+	m.lock1.OneCalls.Lock()
+	if !m.recordingDisabled {
+		m.calls1.OneCalls = append(m.calls1.OneCalls[m.firstCall1(len(m.calls1.OneCalls)):], struct{}{})
+	}
+	m.lock1.OneCalls.Unlock()
+	if m.OneCallsFunc == nil {
+		return
+	}
+	m.OneCallsFunc()
+}
+
+// OneCallsCalls returns the calls made to OneCalls.
+func (m *CollisionsMock) OneCallsCalls() []struct{} {
+	m.lock1.OneCalls.RLock()
+	defer m.lock1.OneCalls.RUnlock()
+	return m.calls1.OneCalls
+}
+
+// OneCallsCallsSnapshot returns a copy of the calls made to OneCalls,
+// which the calls recorded later don't change, for reading them while
+// OneCalls may still be called concurrently.
+func (m *CollisionsMock) OneCallsCallsSnapshot() []struct{} {
+	m.lock1.OneCalls.RLock()
+	defer m.lock1.OneCalls.RUnlock()
+	calls := make([]struct{}, len(m.calls1.OneCalls))
+	copy(calls, m.calls1.OneCalls)
+	return calls
+}
+
+func (m *CollisionsMock) Policy(method string) error {
+	m.lock1.Policy.Lock()
+	if !m.recordingDisabled {
+		m.calls1.Policy = append(m.calls1.Policy[m.firstCall1(len(m.calls1.Policy)):], struct {
+			Method string
+		}{
+			Method: method,
+		})
+	}
+	m.lock1.Policy.Unlock()
+	if m.PolicyFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.PolicyFunc(method)
+}
+
+// PolicyCalls returns the calls made to Policy.
+func (m *CollisionsMock) PolicyCalls() []struct {
+	Method string
+} {
+	m.lock1.Policy.RLock()
+	defer m.lock1.Policy.RUnlock()
+	return m.calls1.Policy
+}
+
+// PolicyCallsSnapshot returns a copy of the calls made to Policy,
+// which the calls recorded later don't change, for reading them while
+// Policy may still be called concurrently.
+func (m *CollisionsMock) PolicyCallsSnapshot() []struct {
+	Method string
+} {
+	m.lock1.Policy.RLock()
+	defer m.lock1.Policy.RUnlock()
+	calls := make([]struct {
+		Method string
+	}, len(m.calls1.Policy))
+	copy(calls, m.calls1.Policy)
+	return calls
+}
+
+func (m *CollisionsMock) Rand(ptrs ...interface{}) string {
+	m.lock1.Rand.Lock()
+	if !m.recordingDisabled {
+		m.calls1.Rand = append(m.calls1.Rand[m.firstCall1(len(m.calls1.Rand)):], struct {
+			Ptrs []interface{}
+		}{
+			Ptrs: ptrs,
+		})
+	}
+	m.lock1.Rand.Unlock()
+	if m.RandFunc == nil {
+		var (
+			sOut string
+		)
+		m.randomize1(&sOut)
+		return sOut
+	}
+	return m.RandFunc(ptrs...)
+}
+
+// RandCalls returns the calls made to Rand.
+func (m *CollisionsMock) RandCalls() []struct {
+	Ptrs []interface{}
+} {
+	m.lock1.Rand.RLock()
+	defer m.lock1.Rand.RUnlock()
+	return m.calls1.Rand
+}
+
+// RandCallsSnapshot returns a copy of the calls made to Rand,
+// which the calls recorded later don't change, for reading them while
+// Rand may still be called concurrently.
+func (m *CollisionsMock) RandCallsSnapshot() []struct {
+	Ptrs []interface{}
+} {
+	m.lock1.Rand.RLock()
+	defer m.lock1.Rand.RUnlock()
+	calls := make([]struct {
+		Ptrs []interface{}
+	}, len(m.calls1.Rand))
+	copy(calls, m.calls1.Rand)
+	for i := range calls {
+		calls[i].Ptrs = append(calls[i].Ptrs[:0:0], calls[i].Ptrs...)
+	}
+	return calls
+}
+
+func (m *CollisionsMock) Rand1() {
+	m.lock1.Rand1.Lock()
+	if !m.recordingDisabled {
+		m.calls1.Rand1 = append(m.calls1.Rand1[m.firstCall1(len(m.calls1.Rand1)):], struct{}{})
+	}
+	m.lock1.Rand1.Unlock()
+	if m.Rand1Func == nil {
+		return
+	}
+	m.Rand1Func()
+}
+
+// Rand1Calls returns the calls made to Rand1.
+func (m *CollisionsMock) Rand1Calls() []struct{} {
+	m.lock1.Rand1.RLock()
+	defer m.lock1.Rand1.RUnlock()
+	return m.calls1.Rand1
+}
+
+// Rand1CallsSnapshot returns a copy of the calls made to Rand1,
+// which the calls recorded later don't change, for reading them while
+// Rand1 may still be called concurrently.
+func (m *CollisionsMock) Rand1CallsSnapshot() []struct{} {
+	m.lock1.Rand1.RLock()
+	defer m.lock1.Rand1.RUnlock()
+	calls := make([]struct{}, len(m.calls1.Rand1))
+	copy(calls, m.calls1.Rand1)
+	return calls
+}
+
+func (m *CollisionsMock) WriteFixture(w int, v int) error {
+	m.lock1.WriteFixture.Lock()
+	if !m.recordingDisabled {
+		m.calls1.WriteFixture = append(m.calls1.WriteFixture[m.firstCall1(len(m.calls1.WriteFixture)):], struct {
+			W int
+			V int
+		}{
+			W: w,
+			V: v,
+		})
+	}
+	m.lock1.WriteFixture.Unlock()
+	if m.WriteFixtureFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.WriteFixtureFunc(w, v)
+}
+
+// WriteFixtureCalls returns the calls made to WriteFixture.
+func (m *CollisionsMock) WriteFixtureCalls() []struct {
+	W int
+	V int
+} {
+	m.lock1.WriteFixture.RLock()
+	defer m.lock1.WriteFixture.RUnlock()
+	return m.calls1.WriteFixture
+}
+
+// WriteFixtureCallsSnapshot returns a copy of the calls made to WriteFixture,
+// which the calls recorded later don't change, for reading them while
+// WriteFixture may still be called concurrently.
+func (m *CollisionsMock) WriteFixtureCallsSnapshot() []struct {
+	W int
+	V int
+} {
+	m.lock1.WriteFixture.RLock()
+	defer m.lock1.WriteFixture.RUnlock()
+	calls := make([]struct {
+		W int
+		V int
+	}, len(m.calls1.WriteFixture))
+	copy(calls, m.calls1.WriteFixture)
+	return calls
+}
+
+func (m *CollisionsMock) calls(mMiripParam int) int {
+	m.lock1.calls.Lock()
+	if !m.recordingDisabled {
+		m.calls1.calls = append(m.calls1.calls[m.firstCall1(len(m.calls1.calls)):], struct {
+			MMiripParam int
+		}{
+			MMiripParam: mMiripParam,
+		})
+	}
+	m.lock1.calls.Unlock()
+	if m.callsFunc == nil {
+		var (
+			nOut int
+		)
+		m.randomize1(&nOut)
+		return nOut
+	}
+	return m.callsFunc(mMiripParam)
+}
+
+// callsCalls returns the calls made to calls.
+func (m *CollisionsMock) callsCalls() []struct {
+	MMiripParam int
+} {
+	m.lock1.calls.RLock()
+	defer m.lock1.calls.RUnlock()
+	return m.calls1.calls
+}
+
+// callsCallsSnapshot returns a copy of the calls made to calls,
+// which the calls recorded later don't change, for reading them while
+// calls may still be called concurrently.
+func (m *CollisionsMock) callsCallsSnapshot() []struct {
+	MMiripParam int
+} {
+	m.lock1.calls.RLock()
+	defer m.lock1.calls.RUnlock()
+	calls := make([]struct {
+		MMiripParam int
+	}, len(m.calls1.calls))
+	copy(calls, m.calls1.calls)
+	return calls
+}
+
+func (m *CollisionsMock) firstCall(calls int) int {
+	m.lock1.firstCall.Lock()
+	if !m.recordingDisabled {
+		m.calls1.firstCall = append(m.calls1.firstCall[m.firstCall1(len(m.calls1.firstCall)):], struct {
+			Calls int
+		}{
+			Calls: calls,
+		})
+	}
+	m.lock1.firstCall.Unlock()
+	if m.firstCallFunc == nil {
+		var (
+			nOut int
+		)
+		m.randomize1(&nOut)
+		return nOut
+	}
+	return m.firstCallFunc(calls)
+}
+
+// firstCallCalls returns the calls made to firstCall.
+func (m *CollisionsMock) firstCallCalls() []struct {
+	Calls int
+} {
+	m.lock1.firstCall.RLock()
+	defer m.lock1.firstCall.RUnlock()
+	return m.calls1.firstCall
+}
+
+// firstCallCallsSnapshot returns a copy of the calls made to firstCall,
+// which the calls recorded later don't change, for reading them while
+// firstCall may still be called concurrently.
+func (m *CollisionsMock) firstCallCallsSnapshot() []struct {
+	Calls int
+} {
+	m.lock1.firstCall.RLock()
+	defer m.lock1.firstCall.RUnlock()
+	calls := make([]struct {
+		Calls int
+	}, len(m.calls1.firstCall))
+	copy(calls, m.calls1.firstCall)
+	return calls
+}
+
+func (m *CollisionsMock) inject(method string) error {
+	m.lock1.inject.Lock()
+	if !m.recordingDisabled {
+		m.calls1.inject = append(m.calls1.inject[m.firstCall1(len(m.calls1.inject)):], struct {
+			Method string
+		}{
+			Method: method,
+		})
+	}
+	m.lock1.inject.Unlock()
+	if m.injectFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.injectFunc(method)
+}
+
+// injectCalls returns the calls made to inject.
+func (m *CollisionsMock) injectCalls() []struct {
+	Method string
+} {
+	m.lock1.inject.RLock()
+	defer m.lock1.inject.RUnlock()
+	return m.calls1.inject
+}
+
+// injectCallsSnapshot returns a copy of the calls made to inject,
+// which the calls recorded later don't change, for reading them while
+// inject may still be called concurrently.
+func (m *CollisionsMock) injectCallsSnapshot() []struct {
+	Method string
+} {
+	m.lock1.inject.RLock()
+	defer m.lock1.inject.RUnlock()
+	calls := make([]struct {
+		Method string
+	}, len(m.calls1.inject))
+	copy(calls, m.calls1.inject)
+	return calls
+}
+
+func (m *CollisionsMock) lock(calls int, lock int) error {
+	m.lock1.lock.Lock()
+	if !m.recordingDisabled {
+		m.calls1.lock = append(m.calls1.lock[m.firstCall1(len(m.calls1.lock)):], struct {
+			Calls int
+			Lock  int
+		}{
+			Calls: calls,
+			Lock:  lock,
+		})
+	}
+	m.lock1.lock.Unlock()
+	if m.lockFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.lockFunc(calls, lock)
+}
+
+// lockCalls returns the calls made to lock.
+func (m *CollisionsMock) lockCalls() []struct {
+	Calls int
+	Lock  int
+} {
+	m.lock1.lock.RLock()
+	defer m.lock1.lock.RUnlock()
+	return m.calls1.lock
+}
+
+// lockCallsSnapshot returns a copy of the calls made to lock,
+// which the calls recorded later don't change, for reading them while
+// lock may still be called concurrently.
+func (m *CollisionsMock) lockCallsSnapshot() []struct {
+	Calls int
+	Lock  int
+} {
+	m.lock1.lock.RLock()
+	defer m.lock1.lock.RUnlock()
+	calls := make([]struct {
+		Calls int
+		Lock  int
+	}, len(m.calls1.lock))
+	copy(calls, m.calls1.lock)
+	return calls
+}
+
+func (m *CollisionsMock) randomize() bool {
+	m.lock1.randomize.Lock()
+	if !m.recordingDisabled {
+		m.calls1.randomize = append(m.calls1.randomize[m.firstCall1(len(m.calls1.randomize)):], struct{}{})
+	}
+	m.lock1.randomize.Unlock()
+	if m.randomizeFunc == nil {
+		var (
+			bOut bool
+		)
+		m.randomize1(&bOut)
+		return bOut
+	}
+	return m.randomizeFunc()
+}
+
+// randomizeCalls returns the calls made to randomize.
+func (m *CollisionsMock) randomizeCalls() []struct{} {
+	m.lock1.randomize.RLock()
+	defer m.lock1.randomize.RUnlock()
+	return m.calls1.randomize
+}
+
+// randomizeCallsSnapshot returns a copy of the calls made to randomize,
+// which the calls recorded later don't change, for reading them while
+// randomize may still be called concurrently.
+func (m *CollisionsMock) randomizeCallsSnapshot() []struct{} {
+	m.lock1.randomize.RLock()
+	defer m.lock1.randomize.RUnlock()
+	calls := make([]struct{}, len(m.calls1.randomize))
+	copy(calls, m.calls1.randomize)
+	return calls
+}
+
+func (m *CollisionsMock) replay(args []interface{}) error {
+	m.lock1.replay.Lock()
+	if !m.recordingDisabled {
+		m.calls1.replay = append(m.calls1.replay[m.firstCall1(len(m.calls1.replay)):], struct {
+			Args []interface{}
+		}{
+			Args: args,
+		})
+	}
+	m.lock1.replay.Unlock()
+	if m.replayFunc == nil {
+		var (
+			errOut error
+		)
+		m.randomize1(&errOut)
+		return errOut
+	}
+	return m.replayFunc(args)
+}
+
+// replayCalls returns the calls made to replay.
+func (m *CollisionsMock) replayCalls() []struct {
+	Args []interface{}
+} {
+	m.lock1.replay.RLock()
+	defer m.lock1.replay.RUnlock()
+	return m.calls1.replay
+}
+
+// replayCallsSnapshot returns a copy of the calls made to replay,
+// which the calls recorded later don't change, for reading them while
+// replay may still be called concurrently.
+func (m *CollisionsMock) replayCallsSnapshot() []struct {
+	Args []interface{}
+} {
+	m.lock1.replay.RLock()
+	defer m.lock1.replay.RUnlock()
+	calls := make([]struct {
+		Args []interface{}
+	}, len(m.calls1.replay))
+	copy(calls, m.calls1.replay)
+	return calls
+}
+
+func (m *CollisionsMock) replayed() bool {
+	m.lock1.replayed.Lock()
+	if !m.recordingDisabled {
+		m.calls1.replayed = append(m.calls1.replayed[m.firstCall1(len(m.calls1.replayed)):], struct{}{})
+	}
+	m.lock1.replayed.Unlock()
+	if m.replayedFunc == nil {
+		var (
+			bOut bool
+		)
+		m.randomize1(&bOut)
+		return bOut
+	}
+	return m.replayedFunc()
+}
+
+// replayedCalls returns the calls made to replayed.
+func (m *CollisionsMock) replayedCalls() []struct{} {
+	m.lock1.replayed.RLock()
+	defer m.lock1.replayed.RUnlock()
+	return m.calls1.replayed
+}
+
+// replayedCallsSnapshot returns a copy of the calls made to replayed,
+// which the calls recorded later don't change, for reading them while
+// replayed may still be called concurrently.
+func (m *CollisionsMock) replayedCallsSnapshot() []struct{} {
+	m.lock1.replayed.RLock()
+	defer m.lock1.replayed.RUnlock()
+	calls := make([]struct{}, len(m.calls1.replayed))
+	copy(calls, m.calls1.replayed)
+	return calls
+}
+
+// randomize1 sets the values pointed to by ptrs to random values of their
+// type. Values of types which can't be generated, such as interfaces, are
+// left unchanged.
+func (m *CollisionsMock) randomize1(ptrs ...interface{}) {
+	m.lock1.Rand2.Lock()
+	defer m.lock1.Rand2.Unlock()
+	if m.Rand2 == nil {
+		m.Rand2 = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+	for _, ptr := range ptrs {
+		v := reflect.ValueOf(ptr).Elem()
+		if value, ok := quick.Value(v.Type(), m.Rand2); ok {
+			v.Set(value)
+		}
+	}
+}
+
+// DisableRecording stops the mock from recording calls, such as for long
+// stress tests, until EnableRecording. The calls recorded so far are kept.
+func (m *CollisionsMock) DisableRecording() {
+	m.setRecordingDisabled(true)
+}
+
+// EnableRecording resumes the recording of calls after DisableRecording.
+func (m *CollisionsMock) EnableRecording() {
+	m.setRecordingDisabled(false)
+}
+
+func (m *CollisionsMock) setRecordingDisabled(disabled bool) {
+	m.lock1.Impl.Lock()
+	m.lock1.MaxCalls.Lock()
+	m.lock1.One.Lock()
+	m.lock1.OneCalls.Lock()
+	m.lock1.Policy.Lock()
+	m.lock1.Rand.Lock()
+	m.lock1.Rand1.Lock()
+	m.lock1.WriteFixture.Lock()
+	m.lock1.calls.Lock()
+	m.lock1.firstCall.Lock()
+	m.lock1.inject.Lock()
+	m.lock1.lock.Lock()
+	m.lock1.randomize.Lock()
+	m.lock1.replay.Lock()
+	m.lock1.replayed.Lock()
+	m.recordingDisabled = disabled
+	m.lock1.Impl.Unlock()
+	m.lock1.MaxCalls.Unlock()
+	m.lock1.One.Unlock()
+	m.lock1.OneCalls.Unlock()
+	m.lock1.Policy.Unlock()
+	m.lock1.Rand.Unlock()
+	m.lock1.Rand1.Unlock()
+	m.lock1.WriteFixture.Unlock()
+	m.lock1.calls.Unlock()
+	m.lock1.firstCall.Unlock()
+	m.lock1.inject.Unlock()
+	m.lock1.lock.Unlock()
+	m.lock1.randomize.Unlock()
+	m.lock1.replay.Unlock()
+	m.lock1.replayed.Unlock()
+}
+
+// firstCall1 returns the index of the first of the given number of calls
+// to keep when recording another, for keeping at most MaxCalls1.
+func (m *CollisionsMock) firstCall1(calls int) int {
+	if m.MaxCalls1 <= 0 || calls < m.MaxCalls1 {
+		return 0
+	}
+	return calls - m.MaxCalls1 + 1
+}
@@ -0,0 +1,67 @@
+package generate
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestToggleRecording checks that the calls to a mock aren't recorded while
+// its recording is disabled, and that only the last MaxCalls1 are kept.
+func TestToggleRecording(t *testing.T) {
+	mock := &CollisionsMock{OneFunc: func(int, int) {}}
+	mock.One(1, 0)
+	mock.DisableRecording()
+	mock.One(2, 0)
+	mock.OneCalls()
+	mock.EnableRecording()
+	mock.One(3, 0)
+	if calls := mock.OneCalls1(); len(calls) != 2 || calls[0].Results != 1 || calls[1].Results != 3 {
+		t.Errorf("the calls recorded to One are %v, want those of 1 and 3", calls)
+	}
+	if calls := mock.OneCallsCalls(); len(calls) != 0 {
+		t.Errorf("%d calls to OneCalls recorded, made with the recording disabled", len(calls))
+	}
+
+	mock.MaxCalls1 = 2
+	mock.One(4, 0)
+	mock.One(5, 0)
+	if calls := mock.OneCalls1(); len(calls) != 2 || calls[0].Results != 4 || calls[1].Results != 5 {
+		t.Errorf("the calls kept of One are %v, want the last two, of 4 and 5", calls)
+	}
+}
+
+// TestLockPerMethod calls the methods of a mock, each having its own lock,
+// concurrently with the reads of their calls, checking that every call is
+// recorded, which the race detector checks further.
+func TestLockPerMethod(t *testing.T) {
+	const n = 100
+	mock := &CollisionsMock{
+		OneFunc:    func(int, int) {},
+		PolicyFunc: func(string) error { return nil },
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(3)
+		go func(i int) {
+			defer wg.Done()
+			mock.One(i, 0)
+		}(i)
+		go func() {
+			defer wg.Done()
+			mock.Policy("method")
+		}()
+		go func() {
+			defer wg.Done()
+			_ = mock.OneCallsSnapshot()
+			_ = mock.PolicyCallsSnapshot()
+		}()
+	}
+	wg.Wait()
+
+	if got := len(mock.OneCalls1()); got != n {
+		t.Errorf("%d calls to One recorded, want %d", got, n)
+	}
+	if got := len(mock.PolicyCalls()); got != n {
+		t.Errorf("%d calls to Policy recorded, want %d", got, n)
+	}
+}
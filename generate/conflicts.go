@@ -0,0 +1,24 @@
+package generate
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+// The mock of Conflicts checks the names of the parameters and results
+// which conflict with each other, with the imports or with the keywords,
+// which are numbered in a single pass rather than renamed as they are
+// added.
+
+//go:generate mirip -rm -no-stamp -out conflicts_mock.go . Conflicts
+
+// Conflicts is an interface whose parameters and results are named alike.
+type Conflicts interface {
+	Same(string, string, string) (string, string)
+	Numbered(s1 string, _ string, _ string, s3 string)
+	Results(string) (s string, _ string, err error, _ error)
+	Imports(time string, _ time.Duration, io io.Reader) (context.Context, error)
+	Keywords(_ map[string]int, _ chan int, _ func(), _ interface{}) (_ map[string]int, _ struct{})
+	Contexts(ctx context.Context, _ context.Context, s string, _ string) (s2 string, _ string)
+}
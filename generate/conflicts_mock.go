@@ -0,0 +1,339 @@
+// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+//
+// mirip:version dev
+// mirip:min-version v1.5.0
+// mirip:source github.com/gmhafiz/mirip/generate.Conflicts
+// mirip:args -no-stamp -out conflicts_mock.go . Conflicts
+
+package generate
+
+import (
+	"context"
+	"io"
+	"sync"
+	"time"
+)
+
+// Ensure that ConflictsMock implements Conflicts.
+var _ Conflicts = &ConflictsMock{}
+
+// ConflictsMock is a mock implementation of Conflicts.
+type ConflictsMock struct {
+	ContextsFunc func(ctx1 context.Context, ctx2 context.Context, s1 string, s2 string) (string, string)
+	ImportsFunc  func(timeMiripParam string, d time.Duration, ioMiripParam io.Reader) (context.Context, error)
+	KeywordsFunc func(stringToInt map[string]int, intCh chan int, fn func(), ifaceVal interface{}) (map[string]int, struct{})
+	NumberedFunc func(s1 string, s2 string, s4 string, s3 string)
+	ResultsFunc  func(s string) (string, string, error, error)
+	SameFunc     func(s1 string, s2 string, s3 string) (string, string)
+
+	calls struct {
+		Contexts []struct {
+			Ctx1 context.Context
+			Ctx2 context.Context
+			S1   string
+			S2   string
+		}
+		Imports []struct {
+			TimeMiripParam string
+			D              time.Duration
+			IoMiripParam   io.Reader
+		}
+		Keywords []struct {
+			StringToInt map[string]int
+			IntCh       chan int
+			Fn          func()
+			IfaceVal    interface{}
+		}
+		Numbered []struct {
+			S1 string
+			S2 string
+			S4 string
+			S3 string
+		}
+		Results []struct {
+			S string
+		}
+		Same []struct {
+			S1 string
+			S2 string
+			S3 string
+		}
+	}
+	lock sync.RWMutex
+}
+
+func (m *ConflictsMock) Contexts(ctx1 context.Context, ctx2 context.Context, s1 string, s2 string) (string, string) {
+	m.lock.Lock()
+	m.calls.Contexts = append(m.calls.Contexts, struct {
+		Ctx1 context.Context
+		Ctx2 context.Context
+		S1   string
+		S2   string
+	}{
+		Ctx1: ctx1,
+		Ctx2: ctx2,
+		S1:   s1,
+		S2:   s2,
+	})
+	m.lock.Unlock()
+	return m.ContextsFunc(ctx1, ctx2, s1, s2)
+}
+
+// ContextsCalls returns the calls made to Contexts.
+func (m *ConflictsMock) ContextsCalls() []struct {
+	Ctx1 context.Context
+	Ctx2 context.Context
+	S1   string
+	S2   string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Contexts
+}
+
+// ContextsCallsSnapshot returns a copy of the calls made to Contexts,
+// which the calls recorded later don't change, for reading them while
+// Contexts may still be called concurrently.
+func (m *ConflictsMock) ContextsCallsSnapshot() []struct {
+	Ctx1 context.Context
+	Ctx2 context.Context
+	S1   string
+	S2   string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		Ctx1 context.Context
+		Ctx2 context.Context
+		S1   string
+		S2   string
+	}, len(m.calls.Contexts))
+	copy(calls, m.calls.Contexts)
+	return calls
+}
+
+func (m *ConflictsMock) Imports(timeMiripParam string, d time.Duration, ioMiripParam io.Reader) (context.Context, error) {
+	m.lock.Lock()
+	m.calls.Imports = append(m.calls.Imports, struct {
+		TimeMiripParam string
+		D              time.Duration
+		IoMiripParam   io.Reader
+	}{
+		TimeMiripParam: timeMiripParam,
+		D:              d,
+		IoMiripParam:   ioMiripParam,
+	})
+	m.lock.Unlock()
+	return m.ImportsFunc(timeMiripParam, d, ioMiripParam)
+}
+
+// ImportsCalls returns the calls made to Imports.
+func (m *ConflictsMock) ImportsCalls() []struct {
+	TimeMiripParam string
+	D              time.Duration
+	IoMiripParam   io.Reader
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Imports
+}
+
+// ImportsCallsSnapshot returns a copy of the calls made to Imports,
+// which the calls recorded later don't change, for reading them while
+// Imports may still be called concurrently.
+func (m *ConflictsMock) ImportsCallsSnapshot() []struct {
+	TimeMiripParam string
+	D              time.Duration
+	IoMiripParam   io.Reader
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		TimeMiripParam string
+		D              time.Duration
+		IoMiripParam   io.Reader
+	}, len(m.calls.Imports))
+	copy(calls, m.calls.Imports)
+	return calls
+}
+
+func (m *ConflictsMock) Keywords(stringToInt map[string]int, intCh chan int, fn func(), ifaceVal interface{}) (map[string]int, struct{}) {
+	m.lock.Lock()
+	m.calls.Keywords = append(m.calls.Keywords, struct {
+		StringToInt map[string]int
+		IntCh       chan int
+		Fn          func()
+		IfaceVal    interface{}
+	}{
+		StringToInt: stringToInt,
+		IntCh:       intCh,
+		Fn:          fn,
+		IfaceVal:    ifaceVal,
+	})
+	m.lock.Unlock()
+	return m.KeywordsFunc(stringToInt, intCh, fn, ifaceVal)
+}
+
+// KeywordsCalls returns the calls made to Keywords.
+func (m *ConflictsMock) KeywordsCalls() []struct {
+	StringToInt map[string]int
+	IntCh       chan int
+	Fn          func()
+	IfaceVal    interface{}
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Keywords
+}
+
+// KeywordsCallsSnapshot returns a copy of the calls made to Keywords,
+// which the calls recorded later don't change, for reading them while
+// Keywords may still be called concurrently.
+func (m *ConflictsMock) KeywordsCallsSnapshot() []struct {
+	StringToInt map[string]int
+	IntCh       chan int
+	Fn          func()
+	IfaceVal    interface{}
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		StringToInt map[string]int
+		IntCh       chan int
+		Fn          func()
+		IfaceVal    interface{}
+	}, len(m.calls.Keywords))
+	copy(calls, m.calls.Keywords)
+	return calls
+}
+
+func (m *ConflictsMock) Numbered(s1 string, s2 string, s4 string, s3 string) {
+	m.lock.Lock()
+	m.calls.Numbered = append(m.calls.Numbered, struct {
+		S1 string
+		S2 string
+		S4 string
+		S3 string
+	}{
+		S1: s1,
+		S2: s2,
+		S4: s4,
+		S3: s3,
+	})
+	m.lock.Unlock()
+	m.NumberedFunc(s1, s2, s4, s3)
+}
+
+// NumberedCalls returns the calls made to Numbered.
+func (m *ConflictsMock) NumberedCalls() []struct {
+	S1 string
+	S2 string
+	S4 string
+	S3 string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Numbered
+}
+
+// NumberedCallsSnapshot returns a copy of the calls made to Numbered,
+// which the calls recorded later don't change, for reading them while
+// Numbered may still be called concurrently.
+func (m *ConflictsMock) NumberedCallsSnapshot() []struct {
+	S1 string
+	S2 string
+	S4 string
+	S3 string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		S1 string
+		S2 string
+		S4 string
+		S3 string
+	}, len(m.calls.Numbered))
+	copy(calls, m.calls.Numbered)
+	return calls
+}
+
+func (m *ConflictsMock) Results(s string) (string, string, error, error) {
+	m.lock.Lock()
+	m.calls.Results = append(m.calls.Results, struct {
+		S string
+	}{
+		S: s,
+	})
+	m.lock.Unlock()
+	return m.ResultsFunc(s)
+}
+
+// ResultsCalls returns the calls made to Results.
+func (m *ConflictsMock) ResultsCalls() []struct {
+	S string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Results
+}
+
+// ResultsCallsSnapshot returns a copy of the calls made to Results,
+// which the calls recorded later don't change, for reading them while
+// Results may still be called concurrently.
+func (m *ConflictsMock) ResultsCallsSnapshot() []struct {
+	S string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		S string
+	}, len(m.calls.Results))
+	copy(calls, m.calls.Results)
+	return calls
+}
+
+func (m *ConflictsMock) Same(s1 string, s2 string, s3 string) (string, string) {
+	m.lock.Lock()
+	m.calls.Same = append(m.calls.Same, struct {
+		S1 string
+		S2 string
+		S3 string
+	}{
+		S1: s1,
+		S2: s2,
+		S3: s3,
+	})
+	m.lock.Unlock()
+	return m.SameFunc(s1, s2, s3)
+}
+
+// SameCalls returns the calls made to Same.
+func (m *ConflictsMock) SameCalls() []struct {
+	S1 string
+	S2 string
+	S3 string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Same
+}
+
+// SameCallsSnapshot returns a copy of the calls made to Same,
+// which the calls recorded later don't change, for reading them while
+// Same may still be called concurrently.
+func (m *ConflictsMock) SameCallsSnapshot() []struct {
+	S1 string
+	S2 string
+	S3 string
+} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct {
+		S1 string
+		S2 string
+		S3 string
+	}, len(m.calls.Same))
+	copy(calls, m.calls.Same)
+	return calls
+}
@@ -0,0 +1,23 @@
+package generate
+
+import "context"
+
+// The methods of the mock of Fetcher taking a context and returning an
+// error return the error of the context once it is done, and those of its
+// asserted mock check their contexts.
+
+//go:generate mirip -rm -no-stamp -honor-context -out context_mock.go . Fetcher
+//go:generate mirip -rm -no-stamp -assert-context -context-key github.com/gmhafiz/mirip/generate.RequestIDKey -prefix Asserted -out assertcontext_mock.go . Fetcher
+
+// Fetcher is an interface whose methods take contexts.
+type Fetcher interface {
+	Fetch(ctx context.Context, url string) ([]byte, int, error)
+	Close(ctx context.Context) error
+	Len(ctx context.Context) int
+}
+
+// RequestIDKey keys the request IDs of the contexts given to Fetcher.
+const RequestIDKey contextKey = "request-id"
+
+// contextKey is the type of the keys of context values.
+type contextKey string
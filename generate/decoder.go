@@ -0,0 +1,14 @@
+package generate
+
+// The builder of Decoder has typed setters of the results of type any
+// and []any, such as DecoderMockReturnDecodeAs, and VerifyAll verifies
+// several of its mocks at once.
+
+//go:generate mirip -rm -no-stamp -builder -verify-all -builder-spec decoder_builder.json -out decoder_mock.go . Decoder
+
+// Decoder is an interface whose methods return values of any type.
+type Decoder interface {
+	Decode(data []byte) (any, error)
+	DecodeAll(data []byte) ([]any, error)
+	Offset() int
+}
@@ -0,0 +1,39 @@
+package generate
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gmhafiz/mirip/generate/embedded"
+)
+
+// The mocks of Embedded and Cache check the methods of the interfaces of
+// other packages they embed, qualified by their imports, whether the
+// embedded interfaces are generic or have unexported methods, which the
+// mocks delegate to the interface they embed.
+
+//go:generate mirip -rm -no-stamp -out embedded_mock.go . Embedded Cache
+
+// Embedded embeds popular interfaces of the standard library and generic
+// and sealed interfaces of another package.
+type Embedded interface {
+	io.ReadCloser
+	driver.Conn
+	http.ResponseWriter
+	http.Flusher
+	fmt.Stringer
+	sort.Interface
+	embedded.Getter[time.Duration]
+	embedded.Node
+}
+
+// Cache is a generic interface embedding the instance of a generic
+// interface of another package.
+type Cache[V any] interface {
+	embedded.Store[string, V]
+	Keys() []string
+}
@@ -0,0 +1,29 @@
+// Package embedded declares the interfaces of another package than the
+// corpus embedded by its interfaces.
+package embedded
+
+import "time"
+
+// Getter is a generic interface.
+type Getter[T any] interface {
+	Get(key string) (T, error)
+}
+
+// Store is a generic interface embedding another one.
+type Store[K comparable, V any] interface {
+	Getter[V]
+	Put(key K, v V, ttl time.Duration) error
+}
+
+// Sealed is an interface with an unexported method, which only the types
+// of this package implement, or the types embedding a Sealed.
+type Sealed interface {
+	Kind() string
+	sealed()
+}
+
+// Node embeds Sealed.
+type Node interface {
+	Sealed
+	Children() []Node
+}
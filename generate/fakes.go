@@ -0,0 +1,6 @@
+package generate
+
+// The fakes of the interfaces of the standard library check the code of
+// kind fake-builtin, importing the packages of each fake.
+
+//go:generate mirip -rm -no-stamp -kind fake-builtin -out fakes_fake.go . io.Reader net/http.RoundTripper io/fs.FS
@@ -0,0 +1,20 @@
+package generate
+
+// The stub of Inventory returns the results recorded in its Fixture,
+// except those which can't be encoded, such as the channel of Items and
+// the interface of Clone, which are left zero.
+
+//go:generate mirip -rm -no-stamp -stub fixture -out fixture_mock.go . Inventory
+
+// Item is an item of an Inventory.
+type Item struct {
+	ID    string
+	Count int
+}
+
+// Inventory is an interface whose stub returns fixtures.
+type Inventory interface {
+	Get(id string) (Item, error)
+	Items() <-chan Item
+	Clone() Inventory
+}
@@ -0,0 +1,36 @@
+package generate
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestFixture checks that the calls to a stub without a Func return the
+// results of its fixture in their order, the calls answered by a Func
+// leaving them to the next ones, and panic once the fixture runs out.
+func TestFixture(t *testing.T) {
+	mock := &InventoryMock{}
+	fixture := `{"Get": [{"item": {"ID": "a", "Count": 1}}, {"err": "not found"}]}`
+	if err := json.Unmarshal([]byte(fixture), &mock.Fixture); err != nil {
+		t.Fatal(err)
+	}
+
+	mock.GetFunc = func(id string) (Item, error) { return Item{ID: id}, nil }
+	if item, err := mock.Get("func"); item.ID != "func" || err != nil {
+		t.Errorf(`Get("func") = %v, %v with a GetFunc, want {func 0}, nil`, item, err)
+	}
+	mock.GetFunc = nil
+	if item, err := mock.Get("a"); item != (Item{ID: "a", Count: 1}) || err != nil {
+		t.Errorf(`Get("a") = %v, %v, want {a 1}, nil`, item, err)
+	}
+	if item, err := mock.Get("b"); item != (Item{}) || err == nil || err.Error() != "not found" {
+		t.Errorf(`Get("b") = %v, %v, want {}, not found`, item, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("the call past the fixture didn't panic")
+		}
+	}()
+	mock.Get("c")
+}
@@ -0,0 +1,40 @@
+package generate
+
+import "context"
+
+// The mocks of Mapper and Strings check the functions of the type
+// parameters of generic interfaces, nested in the signatures of their
+// methods and in the type arguments of generic types, and the recorder of
+// Strings the replay of the results holding functions, which it can't
+// encode.
+
+//go:generate mirip -rm -no-stamp -out funcs_mock.go . Mapper Strings
+//go:generate mirip -rm -no-stamp -kind recorder -out funcs_recorder.go . Strings
+
+// Fn is a generic function type.
+type Fn[T, U any] func(T) U
+
+// Pair is a generic struct, holding functions when instantiated with
+// function types.
+type Pair[K comparable, V any] struct {
+	Key K
+	Val V
+}
+
+// Mapper is a generic interface whose methods take and return functions
+// of its type parameters.
+type Mapper[T any, U comparable] interface {
+	Map(f func(T) U) []U
+	MapFn(f Fn[T, U]) []U
+	Reduce(f func(acc U, v T) (U, error), init U) (U, error)
+	Compose(f func(Fn[T, U]) Fn[U, T]) func(T) T
+	Pairs(keep func(Pair[U, T]) bool) []Pair[U, func(T) U]
+	Each(ctx context.Context, fs ...func(context.Context, T) error) error
+	Anonymous(f func(struct{ X T }) struct{ Y U }) struct{ F func(T) U }
+}
+
+// Strings instantiates Mapper with function types.
+type Strings interface {
+	Mapper[string, int]
+	Lookup(key string) (func(string) int, bool)
+}
@@ -0,0 +1,26 @@
+package generate
+
+import "context"
+
+// The fuzz stub of Catalog decodes results of most kinds of types from
+// the input of fuzz targets, and leaves its channel zero.
+
+//go:generate mirip -rm -no-stamp -stub fuzz -out fuzz_mock.go . Catalog
+
+// Product is a result of Catalog holding several kinds of values.
+type Product struct {
+	ID     int64
+	Name   string
+	Price  float64
+	Tags   []string
+	Stock  map[string]uint16
+	Parent *Product
+}
+
+// Catalog is an interface whose results are decoded by the fuzz stub.
+type Catalog interface {
+	Product(ctx context.Context, id int64) (*Product, error)
+	Search(query string) ([]Product, bool, error)
+	Counts() [3]int8
+	Updates() <-chan Product
+}
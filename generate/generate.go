@@ -3,7 +3,7 @@ package generate
 // In a terminal, run `go generate` in this directory to have it generates the
 //generated.go file.
 
-//go:generate mirip -rm -out generated.go . MyInterface
+//go:generate mirip -rm -no-stamp -out generated.go . MyInterface
 
 // MyInterface is a test interface.
 type MyInterface interface {
@@ -1,25 +1,105 @@
 // Code generated by mirip; DO NOT EDIT.
 // github.com/gmhafiz/mirip
+//
+// mirip:version dev
+// mirip:min-version v1.5.0
+// mirip:source github.com/gmhafiz/mirip/generate.MyInterface
+// mirip:args -no-stamp -out generated.go . MyInterface
 
 package generate
 
-import ()
+import (
+	"sync"
+)
+
+// Ensure that MyInterfaceMock implements MyInterface.
+var _ MyInterface = &MyInterfaceMock{}
 
 // MyInterfaceMock is a mock implementation of MyInterface.
 type MyInterfaceMock struct {
 	OneFunc   func() bool
 	ThreeFunc func() string
 	TwoFunc   func() int
+
+	calls struct {
+		One   []struct{}
+		Three []struct{}
+		Two   []struct{}
+	}
+	lock sync.RWMutex
 }
 
 func (m *MyInterfaceMock) One() bool {
+	m.lock.Lock()
+	m.calls.One = append(m.calls.One, struct{}{})
+	m.lock.Unlock()
 	return m.OneFunc()
 }
 
+// OneCalls returns the calls made to One.
+func (m *MyInterfaceMock) OneCalls() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.One
+}
+
+// OneCallsSnapshot returns a copy of the calls made to One,
+// which the calls recorded later don't change, for reading them while
+// One may still be called concurrently.
+func (m *MyInterfaceMock) OneCallsSnapshot() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct{}, len(m.calls.One))
+	copy(calls, m.calls.One)
+	return calls
+}
+
 func (m *MyInterfaceMock) Three() string {
+	m.lock.Lock()
+	m.calls.Three = append(m.calls.Three, struct{}{})
+	m.lock.Unlock()
 	return m.ThreeFunc()
 }
 
+// ThreeCalls returns the calls made to Three.
+func (m *MyInterfaceMock) ThreeCalls() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Three
+}
+
+// ThreeCallsSnapshot returns a copy of the calls made to Three,
+// which the calls recorded later don't change, for reading them while
+// Three may still be called concurrently.
+func (m *MyInterfaceMock) ThreeCallsSnapshot() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct{}, len(m.calls.Three))
+	copy(calls, m.calls.Three)
+	return calls
+}
+
 func (m *MyInterfaceMock) Two() int {
+	m.lock.Lock()
+	m.calls.Two = append(m.calls.Two, struct{}{})
+	m.lock.Unlock()
 	return m.TwoFunc()
 }
+
+// TwoCalls returns the calls made to Two.
+func (m *MyInterfaceMock) TwoCalls() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	return m.calls.Two
+}
+
+// TwoCallsSnapshot returns a copy of the calls made to Two,
+// which the calls recorded later don't change, for reading them while
+// Two may still be called concurrently.
+func (m *MyInterfaceMock) TwoCallsSnapshot() []struct{} {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+	calls := make([]struct{}, len(m.calls.Two))
+	copy(calls, m.calls.Two)
+	return calls
+}
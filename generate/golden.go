@@ -0,0 +1,14 @@
+package generate
+
+import "context"
+
+// The mock of Journal saves its calls to golden files, leaving out the
+// arguments which can't be encoded, such as its callback.
+
+//go:generate mirip -rm -no-stamp -golden -out golden_mock.go . Journal
+
+// Journal is an interface whose calls are compared with golden files.
+type Journal interface {
+	Append(ctx context.Context, entry string, tags ...string) error
+	Flush(done func())
+}
@@ -0,0 +1,76 @@
+package generate
+
+import (
+	"context"
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// The flag AssertCallsGolden looks up, as the tests of its users define it.
+var _ = flag.Bool("update", false, "update the golden files")
+
+// TestGolden checks that the calls of a mock are compared with the golden
+// file written with -update or MIRIP_UPDATE, failing without it.
+func TestGolden(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "testdata", "journal.json")
+	mock := &JournalMock{
+		AppendFunc: func(context.Context, string, ...string) error { return nil },
+		FlushFunc:  func(func()) {},
+	}
+	mock.Append(context.Background(), "entry", "a", "b")
+	mock.Flush(nil)
+
+	tb := &failingTB{TB: t}
+	assert := func() {
+		defer func() {
+			if r := recover(); r != nil && r != errFatal {
+				panic(r)
+			}
+		}()
+		mock.AssertCallsGolden(tb, path)
+	}
+	assert()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "update them with -update") {
+		t.Errorf("comparing with no golden file failed with %q", tb.errors)
+	}
+
+	for name, update := range map[string]func(t *testing.T){
+		"MIRIP_UPDATE": func(t *testing.T) { t.Setenv("MIRIP_UPDATE", "1") },
+		"-update": func(t *testing.T) {
+			if err := flag.Set("update", "true"); err != nil {
+				t.Fatal(err)
+			}
+			t.Cleanup(func() { _ = flag.Set("update", "false") })
+		},
+	} {
+		if err := os.RemoveAll(filepath.Dir(path)); err != nil {
+			t.Fatal(err)
+		}
+		t.Run(name, func(t *testing.T) {
+			update(t)
+			tb.errors = nil
+			assert()
+			if len(tb.errors) != 0 {
+				t.Errorf("updating the golden file failed with %q", tb.errors)
+			}
+		})
+		want := "{\n\t\"Append\": [\n\t\t{\n\t\t\t\"entry\": \"entry\",\n\t\t\t\"tags\": [\n\t\t\t\t\"a\",\n\t\t\t\t\"b\"\n\t\t\t]\n\t\t}\n\t],\n\t\"Flush\": [\n\t\t{}\n\t]\n}\n"
+		if got, err := os.ReadFile(path); err != nil || string(got) != want {
+			t.Errorf("the golden file = %q, %v, want %q", got, err, want)
+		}
+
+		assert()
+		if len(tb.errors) != 0 {
+			t.Errorf("comparing with the golden file updated failed with %q", tb.errors)
+		}
+	}
+
+	mock.Flush(nil)
+	assert()
+	if len(tb.errors) != 1 || !strings.Contains(tb.errors[0], "calls of JournalMock differ from "+path) {
+		t.Errorf("comparing other calls failed with %q", tb.errors)
+	}
+}
@@ -0,0 +1,63 @@
+package generate
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"unicode/utf8"
+)
+
+// TestRecorderReplay records calls to Strings and replays them from the
+// fixture written, in the order of the calls of each method with the same
+// arguments, the errors by their message and the functions as zero.
+func TestRecorderReplay(t *testing.T) {
+	reduced := 0
+	rec := &StringsRecorder{Impl: &StringsMock{
+		LookupFunc: func(key string) (func(string) int, bool) { return utf8.RuneCountInString, key == "count" },
+		MapFunc:    func(f func(string) int) []int { return []int{f("a"), f("b")} },
+		ReduceFunc: func(f func(acc int, v string) (int, error), init int) (int, error) {
+			reduced++
+			if reduced > 1 {
+				return 0, errors.New("reduced twice")
+			}
+			return init + 1, nil
+		},
+	}}
+	rec.Lookup("count")
+	rec.Lookup("missing")
+	rec.Map(func(s string) int { return len(s) })
+	rec.Reduce(nil, 1)
+	rec.Reduce(nil, 1)
+
+	var fixture bytes.Buffer
+	if err := rec.WriteFixture(&fixture); err != nil {
+		t.Fatal(err)
+	}
+	rep, err := NewStringsRecorderReplayer(&fixture)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if fn, ok := rep.Lookup("missing"); fn != nil || ok {
+		t.Errorf(`Lookup("missing") = %p, %t, want nil, false`, fn, ok)
+	}
+	if fn, ok := rep.Lookup("count"); fn != nil || !ok {
+		t.Errorf(`Lookup("count") = %p, %t, want nil, true`, fn, ok)
+	}
+	if got := rep.Map(nil); len(got) != 2 || got[0] != 1 || got[1] != 1 {
+		t.Errorf("Map() = %v, want [1 1]", got)
+	}
+	if n, err := rep.Reduce(nil, 1); n != 2 || err != nil {
+		t.Errorf("the first Reduce(1) = %d, %v, want 2, nil", n, err)
+	}
+	if n, err := rep.Reduce(nil, 1); n != 0 || err == nil || err.Error() != "reduced twice" {
+		t.Errorf("the second Reduce(1) = %d, %v, want 0, reduced twice", n, err)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Error("replaying a call not recorded didn't panic")
+		}
+	}()
+	rep.Lookup("count")
+}
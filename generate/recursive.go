@@ -0,0 +1,22 @@
+package generate
+
+// The methods of Tree take and return Tree, and those of the generic Node
+// its instances, which the mocks refer to within the package and, in
+// recursivemock, through its import.
+
+//go:generate mirip -rm -no-stamp -out recursive_mock.go . Tree Node
+//go:generate mirip -rm -no-stamp -pkg recursivemock -out recursivemock/recursive_mock.go . Tree Node
+
+// Tree is an interface using its own type.
+type Tree interface {
+	Clone() Tree
+	Merge(other Tree) Tree
+	Subtrees() map[string][]Tree
+}
+
+// Node is a generic interface using its own instances.
+type Node[T any] interface {
+	Value() T
+	Children() []Node[T]
+	Map(f func(T) T) Node[T]
+}
@@ -0,0 +1,13 @@
+package generate
+
+// The mock of Session is shared by the package-level fixtures of a test
+// through SharedSessionMock.
+
+//go:generate mirip -rm -no-stamp -shared -out shared_mock.go . Session
+
+// Session is an interface whose mocks are reached through accessors
+// scoped to the test.
+type Session interface {
+	User() (string, error)
+	Close()
+}
@@ -1,10 +1,13 @@
 module github.com/gmhafiz/mirip
 
-go 1.19
+go 1.25.0
 
-require golang.org/x/tools v0.24.0
+require (
+	golang.org/x/mod v0.20.0
+	golang.org/x/tools v0.24.0
+)
 
 require (
-	golang.org/x/mod v0.20.0 // indirect
+	github.com/onsi/gomega v1.44.0 // indirect
 	golang.org/x/sync v0.8.0 // indirect
 )
@@ -0,0 +1,60 @@
+package coverage
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+// Report is the coverage of mocked methods across a test run.
+type Report struct {
+	// Methods is the number of mocked methods.
+	Methods int
+
+	// Uncalled is the list of mocked methods which were never called,
+	// in the format 'pkg.Mock.Method'. The list is sorted.
+	Uncalled []string
+}
+
+// Read reads a coverage file written by mocks into a Report.
+//
+// Each line of the file is an event followed by a method, where the
+// 'mock' event declares a mocked method and the 'call' event records
+// that it was called. Events may be repeated, as each test binary and
+// mock instance writes its own.
+func Read(r io.Reader) (Report, error) {
+	mocked := make(map[string]bool)
+	called := make(map[string]bool)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		event, method, ok := strings.Cut(line, " ")
+		switch {
+		case ok && event == "mock":
+			mocked[method] = true
+		case ok && event == "call":
+			called[method] = true
+		default:
+			return Report{}, fmt.Errorf("line %d: invalid coverage event: %s", n, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return Report{}, err
+	}
+
+	report := Report{Methods: len(mocked)}
+	for method := range mocked {
+		if !called[method] {
+			report.Uncalled = append(report.Uncalled, method)
+		}
+	}
+	sort.Strings(report.Uncalled)
+	return report, nil
+}
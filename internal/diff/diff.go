@@ -0,0 +1,223 @@
+// Package diff renders unified diffs between two versions of a file.
+package diff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// context is the number of unchanged lines shown around the changes.
+const context = 3
+
+// ANSI escape codes of the colours of the parts of a diff.
+const (
+	colorReset  = "\x1b[0m"
+	colorHeader = "\x1b[1m"
+	colorHunk   = "\x1b[36m"
+	colorDelete = "\x1b[31m"
+	colorInsert = "\x1b[32m"
+)
+
+// op is an edit of one line: kept (' '), deleted ('-') or inserted ('+').
+type op struct {
+	kind byte
+	line string
+}
+
+// Unified returns the unified diff from the content named fromName to the
+// content named toName, or an empty string if they are the same. The
+// diff is coloured with ANSI escape codes if color is set.
+func Unified(fromName, toName string, from, to []byte, color bool) string {
+	ops := edits(lines(string(from)), lines(string(to)))
+
+	paint := func(code, s string) string {
+		if !color {
+			return s
+		}
+		return code + s + colorReset
+	}
+
+	var b strings.Builder
+	for _, h := range hunks(ops) {
+		if b.Len() == 0 {
+			b.WriteString(paint(colorHeader, "--- "+fromName) + "\n")
+			b.WriteString(paint(colorHeader, "+++ "+toName) + "\n")
+		}
+
+		b.WriteString(paint(colorHunk, fmt.Sprintf("@@ -%s +%s @@", h.from, h.to)) + "\n")
+		for _, o := range ops[h.start:h.end] {
+			line := string(o.kind) + strings.TrimSuffix(o.line, "\n")
+			switch o.kind {
+			case '-':
+				line = paint(colorDelete, line)
+			case '+':
+				line = paint(colorInsert, line)
+			}
+			b.WriteString(line + "\n")
+		}
+	}
+	return b.String()
+}
+
+// lines splits s into its lines, keeping their line endings.
+func lines(s string) []string {
+	if s == "" {
+		return nil
+	}
+	l := strings.SplitAfter(s, "\n")
+	if l[len(l)-1] == "" {
+		l = l[:len(l)-1]
+	}
+	return l
+}
+
+// hunk is a range of the edits, around changes, and its line ranges in
+// both versions in the format 'start,count'.
+type hunk struct {
+	start, end int
+	from, to   string
+}
+
+// hunks groups the changes of the edits, with their context, into hunks.
+func hunks(ops []op) []hunk {
+	var hs []hunk
+	for i := 0; i < len(ops); i++ {
+		if ops[i].kind == ' ' {
+			continue
+		}
+
+		start := i - context
+		if start < 0 {
+			start = 0
+		}
+
+		// Extend the hunk over changes whose contexts overlap.
+		end, kept := i+1, 0
+		for ; end < len(ops) && kept <= 2*context; end++ {
+			if ops[end].kind == ' ' {
+				kept++
+			} else {
+				kept = 0
+			}
+		}
+		end -= kept
+		end += context
+		if end > len(ops) {
+			end = len(ops)
+		}
+
+		hs = append(hs, hunk{start: start, end: end})
+		i = end - 1
+	}
+
+	// Compute the line ranges of the hunks.
+	fromLine, toLine, h := 0, 0, 0
+	for i, o := range ops {
+		if h < len(hs) && i == hs[h].start {
+			var fromCount, toCount int
+			for _, o := range ops[hs[h].start:hs[h].end] {
+				if o.kind != '+' {
+					fromCount++
+				}
+				if o.kind != '-' {
+					toCount++
+				}
+			}
+			hs[h].from = lineRange(fromLine, fromCount)
+			hs[h].to = lineRange(toLine, toCount)
+			h++
+		}
+
+		if o.kind != '+' {
+			fromLine++
+		}
+		if o.kind != '-' {
+			toLine++
+		}
+	}
+	return hs
+}
+
+// lineRange returns the range of count lines from the 0-based line index.
+func lineRange(index, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", index)
+	}
+	return fmt.Sprintf("%d,%d", index+1, count)
+}
+
+// edits returns the shortest edit script from a to b, using the Myers
+// algorithm.
+func edits(a, b []string) []op {
+	n, m := len(a), len(b)
+	max := n + m
+	offset := max + 1
+	v := make([]int, 2*max+3)
+
+	// trace holds, for each d, the furthest x reached on the diagonals
+	// -d to d.
+	var trace [][]int
+search:
+	for d := 0; d <= max; d++ {
+		for k := -d; k <= d; k += 2 {
+			var x int
+			if k == -d || (k != d && v[offset+k-1] < v[offset+k+1]) {
+				x = v[offset+k+1]
+			} else {
+				x = v[offset+k-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && a[x] == b[y] {
+				x++
+				y++
+			}
+			v[offset+k] = x
+
+			if x >= n && y >= m {
+				trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+				break search
+			}
+		}
+		trace = append(trace, append([]int(nil), v[offset-d:offset+d+1]...))
+	}
+
+	// Walk back from the end to the start.
+	var ops []op
+	x, y := n, m
+	for d := len(trace) - 1; d > 0; d-- {
+		prev := trace[d-1]
+		at := func(k int) int { return prev[k+d-1] }
+
+		k := x - y
+		var prevK int
+		if k == -d || (k != d && at(k-1) < at(k+1)) {
+			prevK = k + 1
+		} else {
+			prevK = k - 1
+		}
+		prevX := at(prevK)
+		prevY := prevX - prevK
+
+		for x > prevX && y > prevY {
+			ops = append(ops, op{' ', a[x-1]})
+			x--
+			y--
+		}
+		if x == prevX {
+			ops = append(ops, op{'+', b[prevY]})
+		} else {
+			ops = append(ops, op{'-', a[prevX]})
+		}
+		x, y = prevX, prevY
+	}
+	for x > 0 && y > 0 {
+		ops = append(ops, op{' ', a[x-1]})
+		x--
+		y--
+	}
+
+	for i, j := 0, len(ops)-1; i < j; i, j = i+1, j-1 {
+		ops[i], ops[j] = ops[j], ops[i]
+	}
+	return ops
+}
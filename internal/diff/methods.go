@@ -0,0 +1,155 @@
+package diff
+
+import (
+	"bytes"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"sort"
+	"strings"
+)
+
+// Methods returns the changes of the mocked methods between two versions
+// of a generated Go file, one line per change, such as 'StoreMock: added
+// Close() error'. The mocked methods of a type are the ones with a Func
+// field, or all its exported methods if it has none, such as chaos
+// wrappers. No change is returned when either version doesn't parse.
+func Methods(from, to []byte) []string {
+	fromTypes, ok := mockedMethods(from)
+	if !ok {
+		return nil
+	}
+	toTypes, ok := mockedMethods(to)
+	if !ok {
+		return nil
+	}
+
+	var names []string
+	for name := range fromTypes {
+		names = append(names, name)
+	}
+	for name := range toTypes {
+		if _, ok := fromTypes[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	var changes []string
+	for _, name := range names {
+		fromMethods, inFrom := fromTypes[name]
+		toMethods, inTo := toTypes[name]
+		switch {
+		case !inFrom:
+			changes = append(changes, name+": added")
+			continue
+		case !inTo:
+			changes = append(changes, name+": removed")
+			continue
+		}
+
+		for _, method := range sortedKeys(fromMethods, toMethods) {
+			fromSig, inFrom := fromMethods[method]
+			toSig, inTo := toMethods[method]
+			switch {
+			case !inFrom:
+				changes = append(changes, name+": added "+method+toSig)
+			case !inTo:
+				changes = append(changes, name+": removed "+method+fromSig)
+			case fromSig != toSig:
+				changes = append(changes, name+": changed "+method+fromSig+" to "+method+toSig)
+			}
+		}
+	}
+	return changes
+}
+
+// mockedMethods returns the signatures of the mocked methods of the types
+// declared in the source, keyed by type and method name.
+func mockedMethods(src []byte) (map[string]map[string]string, bool) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, 0)
+	if err != nil {
+		return nil, false
+	}
+
+	funcFields := make(map[string]map[string]bool)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			ts := spec.(*ast.TypeSpec)
+			st, ok := ts.Type.(*ast.StructType)
+			if !ok {
+				continue
+			}
+			fields := make(map[string]bool)
+			for _, field := range st.Fields.List {
+				if _, ok := field.Type.(*ast.FuncType); !ok {
+					continue
+				}
+				for _, n := range field.Names {
+					fields[n.Name] = true
+				}
+			}
+			funcFields[ts.Name.Name] = fields
+		}
+	}
+
+	methods := make(map[string]map[string]string)
+	for name := range funcFields {
+		methods[name] = make(map[string]string)
+	}
+	for _, decl := range file.Decls {
+		fn, ok := decl.(*ast.FuncDecl)
+		if !ok || fn.Recv == nil || len(fn.Recv.List) != 1 {
+			continue
+		}
+		recv := fn.Recv.List[0].Type
+		if star, ok := recv.(*ast.StarExpr); ok {
+			recv = star.X
+		}
+		ident, ok := recv.(*ast.Ident)
+		if !ok || funcFields[ident.Name] == nil || !isMocked(fn.Name.Name, funcFields[ident.Name]) {
+			continue
+		}
+
+		var sig bytes.Buffer
+		_ = printer.Fprint(&sig, fset, fn.Type)
+		methods[ident.Name][fn.Name.Name] = strings.TrimPrefix(sig.String(), "func")
+	}
+	return methods, true
+}
+
+// isMocked returns whether the method of the given name of a type with the
+// given func fields is a mocked method, which has a Func field, possibly
+// renamed with a number, or is exported if the type has no func field.
+func isMocked(method string, funcFields map[string]bool) bool {
+	if len(funcFields) == 0 {
+		return ast.IsExported(method)
+	}
+	for field := range funcFields {
+		if suffix := strings.TrimPrefix(field, method+"Func"); suffix != field && strings.Trim(suffix, "0123456789") == "" {
+			return true
+		}
+	}
+	return false
+}
+
+// sortedKeys returns the keys of both maps, sorted.
+func sortedKeys(a, b map[string]string) []string {
+	var keys []string
+	for k := range a {
+		keys = append(keys, k)
+	}
+	for k := range b {
+		if _, ok := a[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+	return keys
+}
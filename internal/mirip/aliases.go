@@ -0,0 +1,40 @@
+package mirip
+
+import (
+	"bufio"
+	"fmt"
+	"go/token"
+	"io"
+	"strings"
+)
+
+// ReadAliases reads a mapping of interface names to the names of their
+// mocks, for Config.Aliases.
+//
+// Each line of the mapping is in the format 'Interface=Alias'. Blank
+// lines and lines starting with '#' are ignored.
+func ReadAliases(r io.Reader) (map[string]string, error) {
+	aliases := make(map[string]string)
+
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		name, alias, ok := strings.Cut(line, "=")
+		name, alias = strings.TrimSpace(name), strings.TrimSpace(alias)
+		if !ok || !token.IsIdentifier(name) || !token.IsIdentifier(alias) {
+			return nil, fmt.Errorf("line %d: invalid alias, expected 'Interface=Alias': %s", n, line)
+		}
+		if prev, ok := aliases[name]; ok {
+			return nil, fmt.Errorf("line %d: %s is already aliased to %s", n, name, prev)
+		}
+		aliases[name] = alias
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return aliases, nil
+}
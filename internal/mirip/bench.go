@@ -0,0 +1,115 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/token"
+	"go/types"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// benchNames are the identifiers of the benchmarks, which the parameters
+// and results of the methods are renamed not to shadow.
+var benchNames = []string{"b", "newImpl", "impl", "i", "n"}
+
+// Benchmark generates a benchmark helper for each of the given
+// interfaces, BenchX(b, newImpl), measuring each method called with zero
+// arguments on the implementation returned by newImpl, along with a
+// BenchmarkXMock benchmark running it on the mock, or a BenchmarkXStub
+// benchmark failing if the stub allocates too. With impl, the name of
+// a function of the source package returning an implementation of the
+// single interface, a benchmark of the implementation is added too.
+func (m Mocker) Benchmark(out io.Writer, impl string, namePairs ...string) error {
+	if m.cfg.Kind != KindMock && m.cfg.Kind != KindStub {
+		return fmt.Errorf("benchmarks are not supported for kind %s", m.cfg.Kind)
+	}
+	if impl != "" && len(namePairs) != 1 {
+		return fmt.Errorf("the benchmark of %s requires a single interface", impl)
+	}
+
+	m.vars = benchNames
+	data, err := m.data(namePairs)
+	if err != nil {
+		return err
+	}
+	for _, mock := range data.Mocks {
+		if mock.TypeParams != "" {
+			return fmt.Errorf("interface %s is generic, which benchmarks don't support", mock.InterfaceName)
+		}
+	}
+
+	// The benchmarks of stubs don't set their Funcs, which refer to the
+	// types of the results.
+	var vars []*registry.Var
+	for _, mock := range data.Mocks {
+		for _, method := range mock.Methods {
+			params := method.Params
+			if m.cfg.Kind == KindMock {
+				params = append(params, method.Returns...)
+			}
+			for _, p := range params {
+				vars = append(vars, p.Var)
+			}
+		}
+	}
+	extra, err := m.interfacePackages(namePairs)
+	if err != nil {
+		return err
+	}
+	extra = append(extra, types.NewPackage("testing", "testing"))
+
+	if impl != "" {
+		qualifier, err := m.benchImpl(impl, namePairs[0])
+		if err != nil {
+			return err
+		}
+		if qualifier != "" {
+			extra = append(extra, m.registry.SrcPkg())
+		}
+		data.Impl = qualifier + impl
+		data.ImplName = strings.TrimPrefix(impl, "New")
+		if data.ImplName == "" {
+			data.ImplName = impl
+		}
+	}
+	data.Imports = m.registry.ImportsOf(vars, extra...)
+
+	start := time.Now()
+	var buf bytes.Buffer
+	err = m.tmpl.ExecuteBenchmark(&buf, data)
+	m.cfg.Timings.since(phaseRender, start)
+	if err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
+
+// benchImpl checks that impl is a function of the source package taking
+// no arguments and returning an implementation of the interface, which
+// the benchmarks can call, and returns its qualifier in the benchmarks.
+func (m Mocker) benchImpl(impl, namePair string) (string, error) {
+	sig, err := m.registry.LookupFunc(impl)
+	if err != nil {
+		return "", err
+	}
+
+	name, _ := parseInterfaceName(namePair, m.mockName)
+	_, name, iface, err := m.lookupInterface(name)
+	if err != nil {
+		return "", err
+	}
+	if sig.Params().Len() != 0 || sig.Results().Len() != 1 || !types.Implements(sig.Results().At(0).Type(), iface) {
+		return "", fmt.Errorf("%s must take no arguments and return an implementation of %s", impl, name)
+	}
+
+	qualifier := m.interfaceQualifier(m.registry.SrcPkg())
+	if qualifier != "" && !token.IsExported(impl) {
+		return "", fmt.Errorf("%s is not exported, which the benchmarks outside of its package require", impl)
+	}
+	return qualifier, nil
+}
@@ -0,0 +1,198 @@
+package mirip
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// builderSpecVersion is the version of the format of the descriptors of
+// BuilderSpec, bumped whenever it changes incompatibly.
+const builderSpecVersion = 1
+
+// builderSpec describes the fluent API of the builders of the mocks of a
+// file, for editor snippets and language server plugins to complete
+// their chains of calls, such as 'ReturnGet(...).ExpectGetCalled(...)'.
+// The types are written as in the file of the mocks, qualified by its
+// imports.
+type builderSpec struct {
+	Version  int           `json:"version"`
+	Package  string        `json:"package"`
+	Builders []builderDesc `json:"builders"`
+}
+
+// builderDesc describes the builder of a mock: New is the expression of
+// a new builder, its zero value, FailFast the step choosing to fail at the
+// calls exceeding those expected and Build the call ending its chains.
+type builderDesc struct {
+	Interface  string       `json:"interface"`
+	Mock       string       `json:"mock"`
+	Builder    string       `json:"builder"`
+	TypeParams string       `json:"typeParams,omitempty"`
+	New        string       `json:"new"`
+	FailFast   builderStep  `json:"failFast"`
+	Build      builderStep  `json:"build"`
+	Methods    []methodDesc `json:"methods"`
+}
+
+// methodDesc describes the steps of a builder configuring a method of
+// its mock.
+type methodDesc struct {
+	Name      string        `json:"name"`
+	Signature string        `json:"signature"`
+	Steps     []builderStep `json:"steps"`
+}
+
+// builderStep is a call of a chain of a builder. Func is set for the
+// functions taking the builder as their first parameter, such as those
+// of the typed results of methods returning any, rather than methods of
+// the builder, along with their TypeParams. Snippet is the call in the snippet syntax of the language
+// server protocol, with a tab stop for each parameter.
+type builderStep struct {
+	Name       string `json:"name"`
+	Func       bool   `json:"func,omitempty"`
+	TypeParams string `json:"typeParams,omitempty"`
+	Params     string `json:"params"`
+	Results    string `json:"results"`
+	Snippet    string `json:"snippet"`
+	Doc        string `json:"doc"`
+}
+
+// BuilderSpec writes a JSON descriptor of the fluent API of the builders
+// of the mocks of the given interfaces, generated with Builder: for each
+// method, the With, Return and Expect steps of the chains of its builder,
+// along with their parameters and snippets, see builderSpec.
+func (m Mocker) BuilderSpec(out io.Writer, namePairs ...string) error {
+	if !m.cfg.Builder {
+		return errors.New("builder descriptors require the builders of -builder")
+	}
+
+	data, err := m.data(namePairs)
+	if err != nil {
+		return err
+	}
+
+	spec := builderSpec{Version: builderSpecVersion, Package: data.PkgName, Builders: make([]builderDesc, len(data.Mocks))}
+	testing := "testing"
+	for _, imprt := range data.Imports {
+		if imprt.Path() == "testing" {
+			testing = imprt.Qualifier()
+		}
+	}
+	for i, mock := range data.Mocks {
+		spec.Builders[i] = describeBuilder(mock, testing)
+	}
+
+	// The expressions such as &XMockBuilder{} are kept as they are.
+	enc := json.NewEncoder(out)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent("", "  ")
+	return enc.Encode(spec)
+}
+
+// describeBuilder returns the descriptor of the builder of the mock,
+// following the builder template, testing being the qualifier of the
+// testing package in the file of the mock.
+func describeBuilder(mock template.MockData, testing string) builderDesc {
+	builder := mock.MockName + "Builder" + mock.TypeArgs
+	desc := builderDesc{
+		Interface:  mock.SrcPkgQualifier + mock.InterfaceName,
+		Mock:       mock.MockName,
+		Builder:    mock.MockName + "Builder",
+		TypeParams: mock.TypeParams,
+		New:        "&" + builder + "{}",
+		FailFast: builderStep{
+			Name:    "FailFast",
+			Params:  "t " + testing + ".TB",
+			Results: "*" + builder,
+			Snippet: "FailFast(${1:t})",
+			Doc:     "FailFast makes the calls to the methods of the mock with a Func which exceed the number of calls expected by the builder fail the test t with Fatalf during the call, rather than at the verification.",
+		},
+		Build: builderStep{
+			Name:    "Build",
+			Results: "(*" + mock.MockName + mock.TypeArgs + ", func(t " + testing + ".TB))",
+			Snippet: "Build()",
+			Doc:     "Build returns a new " + mock.MockName + " with the Funcs of the builder, along with the verification of the calls to the mock expected by the builder.",
+		},
+		Methods: make([]methodDesc, len(mock.Methods)),
+	}
+	self := "*" + builder
+	for i, method := range mock.Methods {
+		fn := "func(" + method.ArgList() + ") " + method.ReturnArgTypeList()
+		steps := []builderStep{{
+			Name:    "With" + method.Name,
+			Params:  "f " + strings.TrimSpace(fn),
+			Results: self,
+			Snippet: "With" + method.Name + "(" + escapeSnippet(strings.TrimSpace(fn)) + " {\n\t$0\n})",
+			Doc:     "With" + method.Name + " sets the " + method.FuncName + " of the mock.",
+		}}
+
+		ret := builderStep{Name: "Return" + method.Name, Results: self, Snippet: "Return" + method.Name + "()"}
+		if len(method.Returns) != 0 {
+			names := make([]string, len(method.Returns))
+			params := make([]string, len(method.Returns))
+			for j, r := range method.Returns {
+				names[j] = r.Name()
+				params[j] = r.MethodArg()
+			}
+			ret.Params = strings.Join(params, ", ")
+			ret.Snippet = "Return" + method.Name + "(" + snippetArgs(names) + ")"
+			ret.Doc = "Return" + method.Name + " sets the " + method.FuncName + " of the mock to return the given results, whatever the arguments."
+		} else {
+			ret.Doc = "Return" + method.Name + " sets the " + method.FuncName + " of the mock to do nothing, for the calls to " + method.Name + " not to panic."
+		}
+		steps = append(steps, ret)
+
+		if typed := mock.AnyResults(method); typed != nil {
+			name := mock.MockName + "Return" + method.Name + "As"
+			var names []string
+			for _, param := range strings.Split(typed.ArgList, ", ") {
+				names = append(names, strings.SplitN(param, " ", 2)[0])
+			}
+			steps = append(steps, builderStep{
+				Name:       name,
+				Func:       true,
+				TypeParams: typed.TypeParams,
+				Params:     "b " + self + ", " + typed.ArgList,
+				Results:    self,
+				Snippet:    name + "(" + snippetArgs(append([]string{"b"}, names...)) + ")",
+				Doc:        name + " sets the " + method.FuncName + " of the mock of the builder to return the given results, of any type T for those of type any, and []T for those of type []any.",
+			})
+		}
+
+		steps = append(steps, builderStep{
+			Name:    "Expect" + method.Name + "Called",
+			Params:  "n int",
+			Results: self,
+			Snippet: "Expect" + method.Name + "Called(${1:1})",
+			Doc:     "Expect" + method.Name + "Called makes the verification fail unless " + method.Name + " was called n times.",
+		})
+
+		desc.Methods[i] = methodDesc{
+			Name:      method.Name,
+			Signature: strings.TrimSpace(method.Name + "(" + method.ArgList() + ") " + method.ReturnArgTypeList()),
+			Steps:     steps,
+		}
+	}
+	return desc
+}
+
+// snippetArgs returns the arguments of a call in the snippet syntax, a
+// numbered placeholder for each name.
+func snippetArgs(names []string) string {
+	args := make([]string, len(names))
+	for i, name := range names {
+		args[i] = fmt.Sprintf("${%d:%s}", i+1, escapeSnippet(name))
+	}
+	return strings.Join(args, ", ")
+}
+
+// escapeSnippet escapes the characters the snippet syntax reserves, such
+// as the braces of the interface{} types.
+func escapeSnippet(s string) string {
+	return strings.NewReplacer(`\`, `\\`, `$`, `\$`, `}`, `\}`).Replace(s)
+}
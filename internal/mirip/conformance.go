@@ -0,0 +1,80 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+	"time"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// conformanceNames are the identifiers of the conformance tests, which
+// the parameters and results of the methods are renamed not to shadow.
+var conformanceNames = []string{"t", "newImpl", "checks", "impl", "check"}
+
+// Conformance generates a conformance test function for each of the
+// given interfaces, TestXConformance(t, newImpl, checks), which the tests
+// of any implementation of X, including its mocks, can run. Each method
+// is called with zero arguments on a new implementation, failing the test
+// if it panics, and its results are passed to the check of the method, if
+// any.
+func (m Mocker) Conformance(out io.Writer, namePairs ...string) error {
+	if len(namePairs) == 0 {
+		return fmt.Errorf("must specify one interface")
+	}
+
+	testing := types.NewPackage("testing", "testing")
+	m.registry.AddImport(testing)
+
+	mocks := make([]template.MockData, len(namePairs))
+	extra := []*types.Package{testing}
+	var vars []*registry.Var
+	for i, np := range namePairs {
+		name, _ := parseInterfaceName(np, m.mockName)
+		pkg, name, iface, err := m.lookupInterface(name)
+		if err != nil {
+			return err
+		}
+		if err := m.preflight(m.checkTypes(pkg, name, iface)); err != nil {
+			return err
+		}
+		if typeParams, _ := m.registry.TypeParams(pkg.Scope().Lookup(name).Type()); typeParams != "" {
+			return fmt.Errorf("interface %s is generic, which conformance tests don't support", name)
+		}
+
+		methods := make([]template.MethodData, iface.NumMethods())
+		for j := range methods {
+			methods[j] = m.methodData(iface.Method(j), conformanceNames...)
+			for _, p := range append(methods[j].Params, methods[j].Returns...) {
+				vars = append(vars, p.Var)
+			}
+		}
+		mocks[i] = template.MockData{
+			InterfaceName:   name,
+			SrcPkgQualifier: m.interfaceQualifier(pkg),
+			Methods:         methods,
+		}
+		if mocks[i].SrcPkgQualifier != "" {
+			extra = append(extra, pkg)
+		}
+	}
+
+	data := template.Data{
+		PkgName: m.mockPkgName(),
+		Imports: m.registry.ImportsOf(vars, extra...),
+		Mocks:   mocks,
+	}
+
+	start := time.Now()
+	var buf bytes.Buffer
+	err := m.tmpl.ExecuteConformance(&buf, data)
+	m.cfg.Timings.since(phaseRender, start)
+	if err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
@@ -0,0 +1,92 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/types"
+	"io"
+
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// Dependencies generates mocks for the interfaces the given concrete
+// type depends on, which are the interface types of its fields and of the
+// parameters of its constructors. Interfaces can be declared in any
+// package.
+func (m Mocker) Dependencies(out io.Writer, typeName string) error {
+	named, err := m.registry.LookupConcreteType(typeName)
+	if err != nil {
+		return err
+	}
+
+	ifaces := dependencies(named, m.registry.Constructors(named))
+	if len(ifaces) == 0 {
+		return fmt.Errorf("%s does not depend on any interface", typeName)
+	}
+
+	names := make([]string, len(ifaces))
+	for i, iface := range ifaces {
+		names[i] = iface.Obj().Name()
+	}
+	m.addImports(names)
+
+	mocks := make([]template.MockData, len(ifaces))
+	sources := make([]string, len(ifaces))
+	for i, iface := range ifaces {
+		// Instances of generic interfaces get the generic mock.
+		iface = iface.Origin()
+		name := iface.Obj().Name()
+		sources[i] = iface.Obj().Pkg().Path() + "." + name
+		if err := m.preflight(m.checkTypes(iface.Obj().Pkg(), name, iface.Underlying().(*types.Interface))); err != nil {
+			return err
+		}
+		mocks[i] = m.mockData(iface.Obj().Pkg(), name, m.mockName(name), iface.Underlying().(*types.Interface).Complete())
+	}
+
+	if err := m.checkMockNames(mocks); err != nil {
+		return err
+	}
+	if err := m.checkGeneric(mocks); err != nil {
+		return err
+	}
+	m.nameHelpers(mocks)
+
+	data := m.dataOf(mocks)
+	data.Stamp = m.stamp(sources)
+	var buf bytes.Buffer
+	if err := m.tmpl.Execute(&buf, data); err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
+
+// dependencies returns the interfaces among the fields of the type and
+// the parameters of its constructors, in order of appearance and without
+// duplicates.
+func dependencies(named *types.Named, constructors []*types.Signature) []*types.Named {
+	var ifaces []*types.Named
+	seen := make(map[*types.TypeName]bool)
+	add := func(t types.Type) {
+		iface, ok := mockableInterface(t)
+		if !ok || seen[iface.Obj()] || iface.TypeArgs().Len() > 0 {
+			return
+		}
+		seen[iface.Obj()] = true
+		ifaces = append(ifaces, iface)
+	}
+
+	if st, ok := named.Underlying().(*types.Struct); ok {
+		for i := 0; i < st.NumFields(); i++ {
+			add(st.Field(i).Type())
+		}
+	}
+
+	for _, sig := range constructors {
+		for i := 0; i < sig.Params().Len(); i++ {
+			add(sig.Params().At(i).Type())
+		}
+	}
+
+	return ifaces
+}
@@ -0,0 +1,82 @@
+package mirip
+
+import (
+	"bytes"
+	"go/token"
+	"go/types"
+	"io"
+	"sort"
+	"time"
+)
+
+// Forms of the assertions that the generated types implement their
+// interface.
+const (
+	EnsureAddr = "addr"
+	EnsureNil  = "nil"
+)
+
+// Ensure generates the assertions that the generated types of the given
+// interfaces implement them, to be written to a file of the mock package,
+// such as a _test.go file, instead of the generated files, which are then
+// generated with SkipEnsure.
+func (m Mocker) Ensure(out io.Writer, namePairs ...string) error {
+	// The assertions refer to the interfaces, which are then imported.
+	m.cfg.SkipEnsure = false
+	data, err := m.data(namePairs)
+	if err != nil {
+		return err
+	}
+	pkgs, err := m.interfacePackages(namePairs)
+	if err != nil {
+		return err
+	}
+	data.Imports = m.registry.ImportsOf(nil, pkgs...)
+
+	start := time.Now()
+	var buf bytes.Buffer
+	err = m.tmpl.ExecuteEnsure(&buf, data)
+	m.cfg.Timings.since(phaseRender, start)
+	if err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
+
+// interfacePackages returns the packages of the given interfaces which the
+// generated code qualifies them with, for it to import them.
+func (m Mocker) interfacePackages(namePairs []string) ([]*types.Package, error) {
+	var pkgs []*types.Package
+	for _, np := range namePairs {
+		name, _ := parseInterfaceName(np, m.mockName)
+		pkg, _, _, err := m.lookupInterface(name)
+		if err != nil {
+			return nil, err
+		}
+		if m.interfaceQualifier(pkg) != "" {
+			pkgs = append(pkgs, pkg)
+		}
+	}
+	return pkgs, nil
+}
+
+// interfaceAliases returns the names of the aliases of the interface of
+// the given object declared in its package, sorted, which the generated
+// code can refer to.
+func (m Mocker) interfaceAliases(obj types.Object) []string {
+	var aliases []string
+	scope := obj.Pkg().Scope()
+	for _, name := range scope.Names() {
+		alias, ok := scope.Lookup(name).(*types.TypeName)
+		if !ok || !alias.IsAlias() || alias == obj || !types.Identical(alias.Type(), obj.Type()) {
+			continue
+		}
+		if m.interfaceQualifier(obj.Pkg()) != "" && !token.IsExported(name) {
+			continue
+		}
+		aliases = append(aliases, name)
+	}
+	sort.Strings(aliases)
+	return aliases
+}
@@ -0,0 +1,42 @@
+package mirip
+
+import (
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// builtinFakes are the packages imported by the fakes of the interfaces
+// of the standard library which kind fake-builtin generates, besides the
+// package of the interface, keyed by interface in the format 'path.Name'.
+var builtinFakes = map[string][]*types.Package{
+	"io.Reader": nil,
+	"io/fs.FS": {
+		types.NewPackage("testing/fstest", "fstest"),
+	},
+	"net/http.RoundTripper": {
+		types.NewPackage("bytes", "bytes"),
+		types.NewPackage("io", "io"),
+		types.NewPackage("strconv", "strconv"),
+		types.NewPackage("strings", "strings"),
+		types.NewPackage("sync", "sync"),
+	},
+}
+
+// addBuiltinFake imports the packages of the fake of the interface, in
+// the format 'path.Name', failing if kind fake-builtin has none.
+func (m Mocker) addBuiltinFake(source string) error {
+	pkgs, ok := builtinFakes[source]
+	if !ok {
+		fakes := make([]string, 0, len(builtinFakes))
+		for fake := range builtinFakes {
+			fakes = append(fakes, fake)
+		}
+		sort.Strings(fakes)
+		return configErrorf("kind %s has no fake of %s, only of %s", KindFakeBuiltin, source, strings.Join(fakes, ", "))
+	}
+	for _, pkg := range pkgs {
+		m.registry.AddImport(pkg)
+	}
+	return nil
+}
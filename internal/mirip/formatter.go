@@ -1,9 +1,15 @@
 package mirip
 
 import (
+	"bytes"
 	"fmt"
+	"go/ast"
 	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
 	"golang.org/x/tools/imports"
+	"io"
 )
 
 func goimports(src []byte) ([]byte, error) {
@@ -20,11 +26,43 @@ func goimports(src []byte) ([]byte, error) {
 	return formatted, nil
 }
 
-func gofmt(src []byte) ([]byte, error) {
-	formatted, err := format.Source(src)
+// printerNormalizeNumbers is the mode of go/printer normalizing number
+// literals, which go/format sets without exporting it.
+const printerNormalizeNumbers printer.Mode = 1 << 30
+
+// gofmt formats src, which must be a complete file, into out as
+// format.Source does. format.Node isn't used, as it prints the files with
+// grouped imports twice for sorting them, which the mocks all have.
+func gofmt(out io.Writer, src []byte) error {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments|parser.SkipObjectResolution)
+	if err != nil {
+		return fmt.Errorf("go/format: %s", err)
+	}
+	ast.SortImports(fset, file)
+
+	cfg := printer.Config{Mode: printer.UseSpaces | printer.TabIndent | printerNormalizeNumbers, Tabwidth: 8}
+	if err := cfg.Fprint(out, fset, file); err != nil {
+		return fmt.Errorf("go/format: %s", err)
+	}
+	return nil
+}
+
+// transform gives the syntax tree of src to f, returning the source of
+// the tree it edited.
+func transform(src []byte, f func(*token.FileSet, *ast.File) error) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
 	if err != nil {
 		return nil, fmt.Errorf("go/format: %s", err)
 	}
+	if err := f(fset, file); err != nil {
+		return nil, fmt.Errorf("transform: %w", err)
+	}
 
-	return formatted, nil
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, fmt.Errorf("go/format: %s", err)
+	}
+	return buf.Bytes(), nil
 }
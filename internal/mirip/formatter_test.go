@@ -0,0 +1,79 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io"
+	"strings"
+	"testing"
+)
+
+// largeMockSource returns the unformatted source of the mock of an
+// interface of n methods, as rendered by the templates.
+func largeMockSource(n int) []byte {
+	var b strings.Builder
+	b.WriteString("package mocks\n\nimport (\n\t\"sync\"\n\t\"context\"\n)\n\nvar _ context.Context\n\ntype LargeMock struct {\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "\tMethod%dFunc func(ctx  interface{}, id string, n int) (string,error)\n", i)
+	}
+	b.WriteString("\tcalls struct {\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "\t\tMethod%d []struct{ Ctx interface{}; ID string; N int }\n", i)
+	}
+	b.WriteString("\t}\n\tlock sync.RWMutex\n}\n")
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, `
+// Method%[1]d calls Method%[1]dFunc.
+func (m *LargeMock) Method%[1]d(ctx interface{}, id string, n int) (string, error) {
+	callInfo := struct{ Ctx interface{}; ID string; N int }{Ctx: ctx,ID: id,N: n}
+	m.lock.Lock()
+	m.calls.Method%[1]d = append(m.calls.Method%[1]d, callInfo)
+	m.lock.Unlock()
+	if m.Method%[1]dFunc == nil {
+		panic("LargeMock.Method%[1]dFunc: method is nil but Large.Method%[1]d was just called")
+	}
+	return m.Method%[1]dFunc(ctx, id, n)
+}
+`, i)
+	}
+	return []byte(b.String())
+}
+
+// BenchmarkGenerateGofmt measures gofmt printing the formatted source of
+// a large mock straight into the output.
+func BenchmarkGenerateGofmt(b *testing.B) {
+	src := largeMockSource(2000)
+	var buf bytes.Buffer
+	if err := gofmt(&buf, src); err != nil {
+		b.Fatal(err)
+	}
+	if want, err := format.Source(src); err != nil || !bytes.Equal(buf.Bytes(), want) {
+		b.Fatalf("gofmt differs from format.Source: %v", err)
+	}
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := gofmt(io.Discard, src); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+// BenchmarkGenerateFormatSource measures the former path, formatting the
+// source of the same mock into a copy with format.Source before writing
+// it, for comparison.
+func BenchmarkGenerateFormatSource(b *testing.B) {
+	src := largeMockSource(2000)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		formatted, err := format.Source(src)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if _, err := io.Discard.Write(formatted); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
@@ -0,0 +1,245 @@
+package mirip
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// Warning is a problem with the interfaces to mock which mirip worked
+// around, such as by renaming the generated code.
+type Warning struct {
+	Interface string
+	Msg       string
+}
+
+func (w Warning) String() string {
+	return w.Interface + ": " + w.Msg
+}
+
+// warn reports the warning with Warn, if set.
+func (cfg Config) warn(iface, format string, args ...interface{}) {
+	if cfg.Warn != nil {
+		cfg.Warn(Warning{Interface: iface, Msg: fmt.Sprintf(format, args...)})
+	}
+}
+
+// fixedHelpers returns the names of the fields and methods the mocks get
+// regardless of the methods of their interface, along with why they are
+// generated.
+func (m Mocker) fixedHelpers() map[string]string {
+	helpers := make(map[string]string)
+	if m.cfg.Kind != KindStub {
+		rename := "which -calls-field and -lock-field rename"
+		helpers[m.cfg.CallsField] = rename
+		helpers[m.cfg.LockField] = rename
+	}
+
+	switch m.cfg.Kind {
+	case KindChaos:
+		for _, name := range []string{"Impl", "Policy", "MethodPolicy", "Rand", "inject"} {
+			helpers[name] = "generated for the chaos kind"
+		}
+	case KindRecorder:
+		for _, name := range []string{"Impl", "WriteFixture", "record", "recordError", "recordErr", "replay", "replayError", "decode", "replayed"} {
+			helpers[name] = "generated for the recorder kind"
+		}
+	case KindMock:
+		if m.cfg.Stub == StubRandom {
+			helpers["Rand"] = "generated for the random stub"
+			helpers["randomize"] = "generated for the random stub"
+		}
+		if m.cfg.Report {
+			for _, name := range []string{"MiripCalls", "reportCall", "reportCalls", "reportLock"} {
+				helpers[name] = "generated for reporting the calls"
+			}
+		}
+		if m.cfg.Clock {
+			helpers["Clock"] = "generated for the clock"
+		}
+		if m.cfg.Stub == StubFuzz {
+			for _, name := range []string{"Fuzz", "fuzz", "fuzzValue", "fuzzBytes"} {
+				helpers[name] = "generated for the fuzz stub"
+			}
+		}
+		if m.cfg.Stub == StubFixture {
+			helpers["Fixture"] = "generated for the fixture stub"
+			helpers["fixtureCall"] = "generated for the fixture stub"
+			helpers["fixtureCalls"] = "generated for the fixture stub"
+			helpers["fixtureError"] = "generated for the fixture stub"
+		}
+		if m.cfg.Coverage {
+			helpers["writeCoverage"] = "generated for coverage"
+		}
+		if m.cfg.Golden {
+			for _, name := range []string{"SaveCallsGolden", "AssertCallsGolden", "callsGolden"} {
+				helpers[name] = "generated for the golden calls"
+			}
+		}
+		if m.cfg.RecordSwitch {
+			for _, name := range []string{"MaxCalls", "DisableRecording", "EnableRecording", "recordingDisabled", "setRecordingDisabled", "firstCall"} {
+				helpers[name] = "generated for toggling the recording"
+			}
+		}
+		if m.cfg.Assert != "" {
+			helpers["CmpOptions"] = "generated for the assertions"
+			helpers["assertCalledWith"] = "generated for the assertions"
+		}
+		if m.cfg.Flavor == FlavorGinkgo {
+			helpers["Reset"] = "generated for the ginkgo flavor"
+		}
+	}
+	return helpers
+}
+
+// nameHelpers names the fixed helpers of each mock, then the Func field
+// and the Calls accessor of each of its methods, along with the helpers
+// of their iterators and channels, after the method. The methods of the
+// interface keep their names, while a helper whose name is taken, by a
+// method or a helper named before, gets the lowest number making it
+// unique appended, with a warning. The fixed helpers are named in the
+// order of their names, and those of the methods in the order of the
+// methods, so that the names only change with the methods.
+func (m Mocker) nameHelpers(mocks []template.MockData) {
+	fixed := m.fixedHelpers()
+	names := make([]string, 0, len(fixed))
+	for name := range fixed {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for i := range mocks {
+		mock := &mocks[i]
+		taken := make(map[string]bool, len(fixed)+3*len(mock.Methods))
+		methods := make(map[string]bool, len(mock.Methods))
+		for _, method := range mock.Methods {
+			taken[method.Name] = true
+			methods[method.Name] = true
+		}
+		// The methods delegated to the embedded interface, and its field,
+		// can't be shadowed either.
+		for _, name := range mock.Delegated {
+			taken[name] = true
+			methods[name] = true
+		}
+		if len(mock.Delegated) != 0 {
+			taken[mock.InterfaceName] = true
+			methods[mock.InterfaceName] = true
+		}
+		for name := range fixed {
+			taken[name] = true
+		}
+
+		mock.Helpers = make(map[string]string, len(fixed))
+		for _, name := range names {
+			unique := name
+			for n := 1; methods[name] && taken[unique]; n++ {
+				unique = registry.Disambiguate(m.cfg.Conflicts, name, n)
+			}
+			if unique != name {
+				m.cfg.warn(mock.InterfaceName, "%s of %s, %s, is named %s, as %s is taken by a method",
+					name, mock.MockName, fixed[name], unique, name)
+			}
+			taken[unique] = true
+			mock.Helpers[name] = unique
+		}
+
+		free := func(method, name string) string {
+			unique := name
+			for n := 1; taken[unique]; n++ {
+				unique = registry.Disambiguate(m.cfg.Conflicts, name, n)
+			}
+			if unique != name {
+				m.cfg.warn(mock.InterfaceName, "%s of method %s is named %s, as %s is taken", name, method, unique, name)
+			}
+			taken[unique] = true
+			return unique
+		}
+
+		for i := range mock.Methods {
+			method := &mock.Methods[i]
+			method.FuncName = free(method.Name, method.Name+"Func")
+			if m.cfg.Kind == KindStub {
+				continue
+			}
+			if m.cfg.Light {
+				method.CountName = free(method.Name, method.Name+"CallCount")
+				continue
+			}
+			method.CallsName = free(method.Name, method.Name+"Calls")
+		}
+		if m.cfg.Kind != KindMock {
+			continue
+		}
+		for i := range mock.Methods {
+			method := &mock.Methods[i]
+			if !m.cfg.Light {
+				method.SnapshotName = free(method.Name, method.Name+"CallsSnapshot")
+			}
+			switch len(method.SeqReturn()) {
+			case 1:
+				method.SeqName = free(method.Name, "Return"+method.Name+"SeqFromSlice")
+			case 2:
+				method.SeqName = free(method.Name, "Return"+method.Name+"Seq2FromSlices")
+			}
+			if method.ChanReturn() != "" {
+				method.ChanName = free(method.Name, "Return"+method.Name+"ChanFromSlice")
+			}
+			for j := range method.Params {
+				p := &method.Params[j]
+				if p.Var.SeqTypes() != nil && !m.cfg.Light {
+					p.SeqName = free(method.Name, "Collect"+method.Name+template.Exported(p.Name()))
+				}
+			}
+			if m.cfg.CallTypes && !m.cfg.Light {
+				method.CallTypeName = callTypeName(*mock, *method)
+				method.CallType = method.CallTypeName + mock.TypeArgs
+			}
+			if m.cfg.Assert != "" && len(method.Params) != 0 {
+				method.AssertName = free(method.Name, "Assert"+method.Name+"CalledWith")
+			}
+			if !m.cfg.Light && len(method.Params) != 0 && method.Params[0].Var.IsContext() {
+				if m.cfg.AssertContext {
+					method.ContextDeadlineName = free(method.Name, "Assert"+method.Name+"CalledWithContextDeadline")
+					method.ContextValueName = free(method.Name, "Assert"+method.Name+"CalledWithContextValue")
+				}
+				if len(m.cfg.ContextKeys) != 0 {
+					method.ContextValuesName = free(method.Name, method.Name+"ContextValues")
+				}
+			}
+			if m.cfg.HonorContext && len(method.Params) != 0 && method.Params[0].Var.IsContext() && method.ReturnsError() {
+				method.DoneContext = method.Params[0].Name()
+			}
+			if m.cfg.Stub == StubFixture {
+				nameFixtureFields(method.Returns)
+			}
+		}
+	}
+}
+
+// callTypeName returns the name of the exported type of the calls of the
+// method of the mock, such as StoreMockGetCall.
+func callTypeName(mock template.MockData, method template.MethodData) string {
+	return mock.MockName + method.Name + "Call"
+}
+
+// nameFixtureFields names the fields of the results in the fixtures of
+// mocks after the results, without the suffix of their names. The whole
+// names are kept when that collides.
+func nameFixtureFields(results []template.ParamData) {
+	taken := make(map[string]bool, len(results))
+	for i := range results {
+		name := strings.TrimSuffix(results[i].Name(), "Out")
+		if taken[template.Exported(name)] {
+			for j := range results {
+				results[j].FixtureName = results[j].Name()
+			}
+			return
+		}
+		taken[template.Exported(name)] = true
+		results[i].FixtureName = name
+	}
+}
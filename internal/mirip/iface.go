@@ -0,0 +1,63 @@
+package mirip
+
+import (
+	"bytes"
+	"errors"
+	"go/types"
+	"io"
+
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// Interface generates interface declarations from the exported method
+// sets of the given concrete types. The interface name can be given with
+// the format 'Type:Interface' and defaults to the type name with an
+// Interface suffix.
+func (m Mocker) Interface(out io.Writer, namePairs ...string) error {
+	if len(namePairs) == 0 {
+		return errors.New("must specify one type")
+	}
+
+	ifaces := make([]template.ExtractData, len(namePairs))
+	for i, np := range namePairs {
+		name, ifaceName := parseInterfaceName(np, func(name string) string {
+			return name + "Interface"
+		})
+		named, err := m.registry.LookupConcreteType(name)
+		if err != nil {
+			return err
+		}
+
+		// The method set of the pointer includes the methods with value
+		// receivers.
+		var methods []template.MethodData
+		mset := types.NewMethodSet(types.NewPointer(named))
+		for j := 0; j < mset.Len(); j++ {
+			if fn := mset.At(j).Obj().(*types.Func); fn.Exported() {
+				methods = append(methods, m.methodData(fn))
+			}
+		}
+
+		ifaces[i] = template.ExtractData{
+			TypeName:      name,
+			InterfaceName: ifaceName,
+			Methods:       methods,
+		}
+	}
+
+	data := template.InterfaceData{
+		PkgName:    m.mockPkgName(),
+		Interfaces: ifaces,
+	}
+	if m.registry.SrcPkgName() != m.mockPkgName() {
+		data.SrcPkgQualifier = m.registry.SrcPkgName() + "."
+	}
+	data.Imports = m.registry.Imports()
+
+	var buf bytes.Buffer
+	if err := m.tmpl.ExecuteInterface(&buf, data); err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
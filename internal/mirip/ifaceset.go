@@ -0,0 +1,159 @@
+package mirip
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// Operators of interface sets, which mock the methods of an interface
+// minus, or in common with, those of other interfaces.
+const (
+	setMinus     = '-'
+	setIntersect = '&'
+)
+
+// setOperand is an interface of an interface set, along with the operator
+// applying it to the methods of the previous operands, zero for the first.
+type setOperand struct {
+	op   byte
+	name string
+}
+
+// parseInterfaceSet splits the name of an interface set, in the format
+// 'A-B' for the methods of A which aren't methods of B, or 'A&B' for the
+// methods of A which are methods of B too, into its operands, applied
+// from left to right. The name of an interface is a single operand. An
+// operator only follows the name of an interface, so that the dashes of
+// package paths aren't operators, ex: github.com/go-yaml/yaml.Node.
+func parseInterfaceSet(name string) []setOperand {
+	var operands []setOperand
+	var op byte
+	start, depth := 0, 0
+	for i := 0; i < len(name); i++ {
+		switch c := name[i]; c {
+		case '[':
+			depth++
+		case ']':
+			depth--
+		case setMinus, setIntersect:
+			if depth != 0 || !isSetOperand(name[start:i]) {
+				continue
+			}
+			operands = append(operands, setOperand{op: op, name: name[start:i]})
+			op, start = c, i+1
+		}
+	}
+	return append(operands, setOperand{op: op, name: name[start:]})
+}
+
+// isSetOperand reports whether the name is the name of an interface, in
+// the format 'Interface' or 'path.Interface'.
+func isSetOperand(name string) bool {
+	name, _, _ = strings.Cut(name, "[")
+	_, iface := splitQualified(name)
+	return token.IsIdentifier(iface)
+}
+
+// lookupInterfaceSet returns the interface of the methods of the interface
+// set of the given operands, along with the package and the name of its
+// first interface, which its mock implements by embedding it, and the
+// methods of the first interface which aren't mocked, delegated to the
+// embedded interface.
+func (m Mocker) lookupInterfaceSet(set string, operands []setOperand) (*types.Package, string, *types.Interface, []string, error) {
+	if m.cfg.Kind != KindMock && m.cfg.Kind != KindStub {
+		return nil, "", nil, nil, fmt.Errorf("interface set %s: only the mock and stub kinds mock interface sets", set)
+	}
+
+	pkg, name, first, err := m.lookupInterface(operands[0].name)
+	if err != nil {
+		return nil, "", nil, nil, err
+	}
+	if isGenericInterface(pkg, name) {
+		return nil, "", nil, nil, fmt.Errorf("interface set %s: generic interface %s can't be in interface sets", set, operands[0].name)
+	}
+	methods := make([]*types.Func, first.NumMethods())
+	for i := range methods {
+		methods[i] = first.Method(i)
+	}
+
+	for _, operand := range operands[1:] {
+		opPkg, opName, iface, err := m.lookupInterface(operand.name)
+		if err != nil {
+			return nil, "", nil, nil, err
+		}
+		if isGenericInterface(opPkg, opName) {
+			return nil, "", nil, nil, fmt.Errorf("interface set %s: generic interface %s can't be in interface sets", set, operand.name)
+		}
+		others := make(map[string]*types.Func, iface.NumMethods())
+		for i := 0; i < iface.NumMethods(); i++ {
+			others[iface.Method(i).Name()] = iface.Method(i)
+		}
+
+		var kept []*types.Func
+		for _, method := range methods {
+			other, ok := others[method.Name()]
+			if ok && !types.Identical(other.Type(), method.Type()) {
+				return nil, "", nil, nil, fmt.Errorf("interface set %s: method %s of %s and %s have different signatures",
+					set, method.Name(), operands[0].name, operand.name)
+			}
+			if ok == (operand.op == setIntersect) {
+				kept = append(kept, method)
+			}
+		}
+		methods = kept
+	}
+	if len(methods) == 0 {
+		return nil, "", nil, nil, fmt.Errorf("interface set %s has no methods", set)
+	}
+
+	mocked := make(map[string]bool, len(methods))
+	for _, method := range methods {
+		if method.Name() == name {
+			return nil, "", nil, nil, fmt.Errorf("interface set %s: %s can't be embedded in its mock, which has a method of the same name",
+				set, operands[0].name)
+		}
+		mocked[method.Name()] = true
+	}
+	var delegated []string
+	for i := 0; i < first.NumMethods(); i++ {
+		if !mocked[first.Method(i).Name()] {
+			delegated = append(delegated, first.Method(i).Name())
+		}
+	}
+	return pkg, name, types.NewInterfaceType(methods, nil).Complete(), delegated, nil
+}
+
+// withoutForeignMethods returns the interface without its unexported
+// methods of another package than the output package, such as those of an
+// interface of another package sealing its implementations, which mocks
+// can't declare, along with their names, delegated to the interface
+// embedded in the mock. It fails for the kinds not embedding it.
+func (m Mocker) withoutForeignMethods(name string, iface *types.Interface) (*types.Interface, []string, error) {
+	var methods []*types.Func
+	var foreign []string
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		if method.Exported() || m.registry.IsOutputPkg(method.Pkg()) {
+			methods = append(methods, method)
+			continue
+		}
+		if m.cfg.Kind != KindMock && m.cfg.Kind != KindStub {
+			return nil, nil, fmt.Errorf("interface %s has the unexported method %s of package %s, which only the mock and stub kinds implement, by embedding %s",
+				name, method.Name(), method.Pkg().Path(), name)
+		}
+		foreign = append(foreign, method.Name())
+	}
+	if len(foreign) == 0 {
+		return iface, nil, nil
+	}
+	return types.NewInterfaceType(methods, nil).Complete(), foreign, nil
+}
+
+// isGenericInterface reports whether the interface of the given name in
+// the package has type parameters.
+func isGenericInterface(pkg *types.Package, name string) bool {
+	named, ok := pkg.Scope().Lookup(name).Type().(*types.Named)
+	return ok && named.TypeParams().Len() != 0
+}
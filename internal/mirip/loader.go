@@ -0,0 +1,21 @@
+package mirip
+
+import (
+	"context"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// Loader loads the source packages of mocks in place of go/packages, given
+// as Config.Loader.
+type Loader = registry.Loader
+
+// LoaderFunc is a function used as a Loader.
+type LoaderFunc func(ctx context.Context, dirs ...string) ([]*packages.Package, error)
+
+// Load calls f(ctx, dirs...).
+func (f LoaderFunc) Load(ctx context.Context, dirs ...string) ([]*packages.Package, error) {
+	return f(ctx, dirs...)
+}
@@ -0,0 +1,234 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// checkMethods returns an error unless each of the Methods is a method of
+// one of the mocks of the data.
+func (m Mocker) checkMethods(data ...template.Data) error {
+	for _, name := range m.cfg.Methods {
+		found := false
+		for _, d := range data {
+			for _, mock := range d.Mocks {
+				for _, method := range mock.Methods {
+					found = found || method.Name == name
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("method %s isn't a method of the mocked interfaces", name)
+		}
+	}
+	return nil
+}
+
+// renderMethods renders the data like render, but only the declarations
+// of the existing output file which the Methods affect are regenerated,
+// the others are left as they are. The output file is rendered in full if
+// it doesn't exist.
+//
+// The declarations the methods affect are those rendered differently
+// without them, such as their Func fields and accessors, and the mock
+// struct they are fields of.
+func (m Mocker) renderMethods(out io.Writer, data template.Data, outFile string) error {
+	existing, err := os.ReadFile(outFile)
+	if os.IsNotExist(err) {
+		return m.render(out, data)
+	}
+	if err != nil {
+		return err
+	}
+
+	var full, reduced bytes.Buffer
+	if err := m.render(&full, data); err != nil {
+		return err
+	}
+	if err := m.render(&reduced, withoutMethods(data, m.cfg.Methods)); err != nil {
+		return err
+	}
+
+	merged, err := mergeDecls(existing, full.Bytes(), reduced.Bytes())
+	if err != nil {
+		return fmt.Errorf("can't merge the methods into %s: %s", outFile, err)
+	}
+	_, err = out.Write(merged)
+	return err
+}
+
+// withoutMethods returns a copy of the data whose mocks don't have the
+// methods of the given names.
+func withoutMethods(data template.Data, names []string) template.Data {
+	skipped := make(map[string]bool, len(names))
+	for _, name := range names {
+		skipped[name] = true
+	}
+
+	mocks := make([]template.MockData, len(data.Mocks))
+	for i, mock := range data.Mocks {
+		methods := make([]template.MethodData, 0, len(mock.Methods))
+		for _, method := range mock.Methods {
+			if !skipped[method.Name] {
+				methods = append(methods, method)
+			}
+		}
+		mock.Methods = methods
+		mocks[i] = mock
+	}
+	data.Mocks = mocks
+	return data
+}
+
+// mergeDecls returns the declarations of full, in its order, following its
+// header: those which differ in reduced, or which existing doesn't have,
+// as they are in full, and the others as they are in existing. The
+// declarations of existing which full doesn't have are dropped.
+func mergeDecls(existing, full, reduced []byte) ([]byte, error) {
+	existingDecls, _, err := parseDecls(existing)
+	if err != nil {
+		return nil, err
+	}
+	fullDecls, header, err := parseDecls(full)
+	if err != nil {
+		return nil, err
+	}
+	reducedDecls, _, err := parseDecls(reduced)
+	if err != nil {
+		return nil, err
+	}
+
+	byKey := func(decls []decl) map[string]decl {
+		keyed := make(map[string]decl, len(decls))
+		for _, d := range decls {
+			keyed[d.key] = d
+		}
+		return keyed
+	}
+	existingByKey, reducedByKey := byKey(existingDecls), byKey(reducedDecls)
+
+	merged := []byte(header)
+	for _, d := range fullDecls {
+		e, ok := existingByKey[d.key]
+		if r, reduced := reducedByKey[d.key]; ok && reduced && r.text == d.text {
+			d = e
+		}
+		merged = append(merged, "\n\n"+d.chunk...)
+	}
+	return format.Source(merged)
+}
+
+// decl is a top-level declaration of a generated file: the key naming it,
+// its text, along with its doc comment, and the chunk of the file up to
+// its end from the end of the previous one, which has the comments in
+// between too.
+type decl struct {
+	key   string
+	text  string
+	chunk string
+}
+
+// parseDecls returns the top-level declarations of the source but the
+// imports, along with the header of the file, up to the end of its
+// package clause and imports.
+func parseDecls(src []byte) ([]decl, string, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, "", err
+	}
+	offset := func(pos token.Pos) int {
+		return fset.Position(pos).Offset
+	}
+
+	var decls []decl
+	headerEnd := offset(file.Name.End())
+	prevEnd := headerEnd
+	seen := make(map[string]int)
+	for _, d := range file.Decls {
+		if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT {
+			headerEnd = offset(d.End())
+			prevEnd = headerEnd
+			continue
+		}
+
+		start := d.Pos()
+		if doc := declDoc(d); doc != nil {
+			start = doc.Pos()
+		}
+		key := declKey(d)
+		seen[key]++
+		if n := seen[key]; n > 1 {
+			key = fmt.Sprintf("%s#%d", key, n)
+		}
+		decls = append(decls, decl{
+			key:   key,
+			text:  string(src[offset(start):offset(d.End())]),
+			chunk: strings.TrimLeft(string(src[prevEnd:offset(d.End())]), "\n"),
+		})
+		prevEnd = offset(d.End())
+	}
+	return decls, string(src[:headerEnd]), nil
+}
+
+func declDoc(d ast.Decl) *ast.CommentGroup {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		return d.Doc
+	case *ast.GenDecl:
+		return d.Doc
+	}
+	return nil
+}
+
+// declKey returns the key naming the declaration: 'Recv.Name' for the
+// methods, the name of the other funcs, and the names declared by the
+// other declarations, ex: 'type StoreMock', 'var _'.
+func declKey(d ast.Decl) string {
+	switch d := d.(type) {
+	case *ast.FuncDecl:
+		if d.Recv == nil || len(d.Recv.List) == 0 {
+			return d.Name.Name
+		}
+		return recvName(d.Recv.List[0].Type) + "." + d.Name.Name
+	case *ast.GenDecl:
+		var names []string
+		for _, spec := range d.Specs {
+			switch spec := spec.(type) {
+			case *ast.TypeSpec:
+				names = append(names, spec.Name.Name)
+			case *ast.ValueSpec:
+				for _, name := range spec.Names {
+					names = append(names, name.Name)
+				}
+			}
+		}
+		return d.Tok.String() + " " + strings.Join(names, ",")
+	}
+	return ""
+}
+
+// recvName returns the name of the type of a receiver, without the
+// pointer and the type parameters.
+func recvName(expr ast.Expr) string {
+	switch e := expr.(type) {
+	case *ast.StarExpr:
+		return recvName(e.X)
+	case *ast.IndexExpr:
+		return recvName(e.X)
+	case *ast.IndexListExpr:
+		return recvName(e.X)
+	case *ast.Ident:
+		return e.Name
+	}
+	return ""
+}
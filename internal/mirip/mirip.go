@@ -2,23 +2,587 @@ package mirip
 
 import (
 	"bytes"
+	"context"
 	"errors"
+	"fmt"
+	"go/ast"
+	"go/build/constraint"
+	"go/token"
 	"go/types"
 	"io"
+	"path/filepath"
+	"sort"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"golang.org/x/tools/go/packages"
 
 	"github.com/gmhafiz/mirip/internal/registry"
 	"github.com/gmhafiz/mirip/internal/template"
 )
 
+// Kinds of code which can be generated for an interface.
+const (
+	KindMock     = "mock"
+	KindChaos    = "chaos"
+	KindRecorder = "recorder"
+	KindStub     = "stub"
+
+	KindFakeBuiltin = "fake-builtin"
+)
+
+// Strategies of naming the imports whose qualifiers collide.
+const (
+	QualifierPath   = registry.QualifierPath
+	QualifierNumber = registry.QualifierNumber
+)
+
+// Styles of naming unnamed parameters.
+const (
+	ParamNamesType     = registry.ParamNamesType
+	ParamNamesPosition = registry.ParamNamesPosition
+)
+
+// Strategies of naming the parameters and the generated identifiers whose
+// names collide.
+const (
+	ConflictNumber     = registry.ConflictNumber
+	ConflictUnderscore = registry.ConflictUnderscore
+	ConflictLetter     = registry.ConflictLetter
+)
+
+// DefaultConflictSuffix is the default of Config.ConflictSuffix.
+const DefaultConflictSuffix = registry.DefaultConflictSuffix
+
+// kindVars are the names of the variables declared by the methods of a
+// kind, which the parameters and results are renamed not to shadow,
+// keyed by kind.
+var kindVars = map[string][]string{
+	KindRecorder: {"results"},
+}
+
+// kindLocals are the names of the variables declared by the methods of
+// the types of a kind, which the receiver would be shadowed by, keyed by
+// kind. Those of the helpers added by the options are listed next to the
+// imports of the helpers.
+var kindLocals = map[string][]string{
+	KindMock:        {"calls", "call", "i", "v", "ch", "seq", "keys", "values", "k"},
+	KindChaos:       {"method", "policy", "fault", "ok", "err"},
+	KindRecorder:    {"w", "enc", "method", "args", "results", "call", "calls", "err", "i", "j", "msg", "encoded", "match", "data", "v"},
+	KindFakeBuiltin: {"p", "n", "req", "recorded", "body", "err", "canned", "ok", "status", "header"},
+}
+
+// kindSuffixes are the suffixes appended to the interface name for the
+// default name of the generated type, keyed by kind.
+var kindSuffixes = map[string]string{
+	KindMock:     "Mock",
+	KindChaos:    "Chaos",
+	KindRecorder: "Recorder",
+	KindStub:     "Stub",
+
+	KindFakeBuiltin: "Fake",
+}
+
+// kindImports are the packages imported by the template of a kind,
+// keyed by kind.
+var kindImports = map[string][]*types.Package{
+	KindMock: {
+		types.NewPackage("sync", "sync"),
+	},
+	KindChaos: {
+		types.NewPackage("math/rand", "rand"),
+		types.NewPackage("sync", "sync"),
+		types.NewPackage("time", "time"),
+	},
+	KindRecorder: {
+		types.NewPackage("bytes", "bytes"),
+		types.NewPackage("encoding/json", "json"),
+		types.NewPackage("errors", "errors"),
+		types.NewPackage("fmt", "fmt"),
+		types.NewPackage("io", "io"),
+		types.NewPackage("sync", "sync"),
+	},
+}
+
+// Providers of the dependency injection helpers which can be generated
+// alongside the mocks.
+const (
+	ProviderFunc = "func"
+	ProviderWire = "wire"
+	ProviderFx   = "fx"
+)
+
+// providerImports are the packages imported for the dependency injection
+// helpers, keyed by provider.
+var providerImports = map[string]*types.Package{
+	ProviderWire: types.NewPackage("github.com/google/wire", "wire"),
+	ProviderFx:   types.NewPackage("go.uber.org/fx", "fx"),
+}
+
+// Flavors of mocks, adding helpers for a test framework.
+const (
+	FlavorGinkgo  = "ginkgo"
+	FlavorTestify = "testify"
+)
+
+// flavorImports are the packages imported for the helpers of a flavor,
+// keyed by flavor.
+var flavorImports = map[string][]*types.Package{
+	FlavorGinkgo: {
+		types.NewPackage("github.com/onsi/gomega", "gomega"),
+		types.NewPackage("github.com/onsi/gomega/types", "types"),
+	},
+	FlavorTestify: {
+		types.NewPackage("github.com/stretchr/testify/suite", "suite"),
+	},
+}
+
+// Stubs of the return values of mocked methods without a Func.
+const (
+	StubZero    = "zero"
+	StubRandom  = "random"
+	StubFixture = "fixture"
+	StubFuzz    = "fuzz"
+)
+
+// stubImports are the packages imported for the stubs of methods, keyed
+// by stub.
+var stubImports = map[string][]*types.Package{
+	StubRandom: {
+		types.NewPackage("math/rand", "rand"),
+		types.NewPackage("reflect", "reflect"),
+		types.NewPackage("testing/quick", "quick"),
+		types.NewPackage("time", "time"),
+	},
+	StubFixture: {
+		types.NewPackage("errors", "errors"),
+		types.NewPackage("fmt", "fmt"),
+		types.NewPackage("sync/atomic", "atomic"),
+	},
+	StubFuzz: {
+		types.NewPackage("errors", "errors"),
+		types.NewPackage("math", "math"),
+		types.NewPackage("reflect", "reflect"),
+	},
+}
+
+// runtimePkg is the runtime of the generated code, which its features
+// needing it import.
+var runtimePkg = types.NewPackage("github.com/gmhafiz/mirip/runtime", "mirip")
+
+// stubVars are the names of the variables declared by the methods of
+// mocks for a stub, which the parameters and results are renamed not to
+// shadow, keyed by stub.
+var stubVars = map[string][]string{
+	StubFixture: {"results"},
+}
+
+// stubLocals are the names of the variables declared by the helpers of
+// mocks for a stub, which the receiver would be shadowed by, keyed by
+// stub.
+var stubLocals = map[string][]string{
+	StubRandom:  {"ptrs", "ptr", "v", "value", "ok"},
+	StubFixture: {"method", "calls", "results", "msg"},
+	StubFuzz:    {"ptrs", "ptr", "v", "depth", "size", "u", "i", "b", "n", "k", "key", "elem", "uint64Of"},
+}
+
+// lightImports are the packages imported for counting the calls of light
+// mocks.
+var lightImports = []*types.Package{
+	types.NewPackage("sync/atomic", "atomic"),
+}
+
+// builderImports are the packages imported for the builders of mocks.
+var builderImports = []*types.Package{
+	types.NewPackage("testing", "testing"),
+}
+
+// sharedImports are the packages imported for the shared mocks of tests.
+var sharedImports = []*types.Package{
+	types.NewPackage("sync", "sync"),
+	types.NewPackage("testing", "testing"),
+}
+
+// registryVar is the name of the registry of the constructors of mocks.
+const registryVar = "Mocks"
+
+// verifyAllNames are the names of the declarations of VerifyAll.
+var verifyAllNames = []string{"VerifyAll", "verifyAllTB", "verifyAllStop"}
+
+// verifyAllImports are the packages imported by VerifyAll.
+var verifyAllImports = []*types.Package{
+	types.NewPackage("fmt", "fmt"),
+	types.NewPackage("strings", "strings"),
+	types.NewPackage("testing", "testing"),
+}
+
+// sharedPrefix prefixes the names of the mocks for their accessors of the
+// mocks shared in a test.
+const sharedPrefix = "Shared"
+
+// Assertions generated for the arguments of the calls recorded by mocks.
+const AssertCmp = "cmp"
+
+// assertImports are the packages imported for the assertions of mocks,
+// keyed by assertion.
+var assertImports = map[string][]*types.Package{
+	AssertCmp: {
+		types.NewPackage("fmt", "fmt"),
+		types.NewPackage("reflect", "reflect"),
+		types.NewPackage("testing", "testing"),
+		types.NewPackage("github.com/google/go-cmp/cmp", "cmp"),
+	},
+}
+
+// assertContextImports are the packages imported for the assertions of the
+// contexts of calls.
+var assertContextImports = []*types.Package{
+	types.NewPackage("reflect", "reflect"),
+	types.NewPackage("testing", "testing"),
+}
+
+// assertContextLocals are the names of the variables declared by the
+// assertions of the contexts of calls, which the receiver would be
+// shadowed by.
+var assertContextLocals = []string{"t", "calls", "call", "i", "ok", "key", "want"}
+
+// assertVars are the names of the parameters of the assertions of mocks,
+// which the parameters of the asserted methods are renamed not to shadow.
+var assertVars = []string{"t"}
+
+// assertLocals are the names of the variables declared by the assertions
+// of mocks, which the receiver would be shadowed by, keyed by assertion.
+var assertLocals = map[string][]string{
+	AssertCmp: {"t", "method", "want", "calls", "v", "diffs", "i", "call"},
+}
+
+// builderVars are the names of the variables of the Build methods of the
+// builders of mocks, which the parameters of the methods are renamed not
+// to shadow.
+var builderVars = []string{"t", "f", "got", "want", "mock"}
+
+// goldenImports are the packages imported for saving the calls of mocks
+// to golden files and comparing them.
+var goldenImports = []*types.Package{
+	types.NewPackage("bytes", "bytes"),
+	types.NewPackage("encoding/json", "json"),
+	types.NewPackage("flag", "flag"),
+	types.NewPackage("os", "os"),
+	types.NewPackage("path/filepath", "filepath"),
+	types.NewPackage("testing", "testing"),
+}
+
+// goldenLocals are the names of the variables declared by the methods of
+// mocks saving their calls to golden files and comparing them, which the
+// receiver would be shadowed by.
+var goldenLocals = []string{"t", "path", "f", "want", "got", "err", "calls", "call", "data"}
+
+// recordSwitchLocals are the names of the variables declared by the
+// methods of mocks switching the recording of calls, and reportLocals by
+// those recording the calls for Report, which the receiver would be
+// shadowed by.
+var (
+	recordSwitchLocals = []string{"disabled"}
+	reportLocals       = []string{"method", "args", "call"}
+)
+
+// coverageImports are the packages imported for writing the coverage of
+// mocks.
+var coverageImports = []*types.Package{
+	types.NewPackage("os", "os"),
+	types.NewPackage("strings", "strings"),
+}
+
 // Config specifies details about how interfaces should be mocked.
 // SrcDir is the only field which needs be specified.
+//
+// The default name of a mock is the interface name between Prefix and
+// Suffix, where Suffix defaults to the suffix of the kind unless Prefix
+// is set. Aliases maps interface names to other mock names instead, and a
+// name given with the format 'interface:alias' takes precedence over both.
+// OutFile is the file the mocks are written to, if any, for checking their
+// names don't conflict with the output package. It may contain
+// InterfacePlaceholder, replaced with the interface name in FileCase,
+// snake case by default, or FilePlaceholder, replaced with the name of
+// the source file declaring the interface, and KindPlaceholder, replaced
+// with the kind. OutDir writes each mock to its own '<interface>_mock.go'
+// file in that directory instead, or the file of OutDir named by OutFile
+// if set, in the package of the directory, or named after the directory
+// if it holds none, unless PkgName is set. Both may contain
+// PackagePlaceholder, replaced with the source dir relative to the
+// current directory, for the mocks of each source package to be in a
+// package of a parallel tree.
+//
+// Qualifiers is the strategy naming the imports of the mocks whose
+// qualifiers collide, QualifierPath by default. ImportAliases gives the
+// qualifiers of imports by path instead, which are never renamed. SrcAlias
+// gives the qualifier of the source package, when the mocks import it,
+// without spelling its path, such as domain for the name of the package
+// not to collide with the names of parameters. RuntimeAlias gives the
+// qualifier of the runtime imported by the mocks of NotImplemented or
+// Report, mirip by default unless the package of the mocks or another
+// import already uses the name, in which case it is aliased after its
+// path, such as miripruntime.
+//
+// ConflictSuffix is appended to the names of the parameters of mocks which
+// would conflict with an import or shadow a keyword or a type,
+// DefaultConflictSuffix by default. Conflicts is the strategy naming the
+// parameters whose names collide, and the fields and methods generated
+// along with the methods named like them: ConflictNumber by default, such
+// as s1 and s2, or ConflictUnderscore or ConflictLetter, such as s_ and
+// s__ or sA and sB, for the linters forbidding names ending in digits.
+//
+// ParamNames is the style of naming unnamed parameters, ParamNamesType
+// by default, or ParamNamesPosition for arg1, arg2 and so on, which are
+// stable when the types of parameters change.
+//
+// TypeNames gives the names of the unnamed parameters of the types they
+// are keyed by, in the format 'path.Name' or '*path.Name', which extend
+// and override the idiomatic names of well-known types, such as ctx for
+// context.Context.
+//
+// Receiver is the name of the receivers of the methods of mocks, "m" by
+// default, and CallsField and LockField the names of their fields
+// recording calls, "calls" and "lock" by default. Parameters are renamed
+// so as not to shadow the receiver. LockPerMethod locks the calls of each
+// method with its own lock, rather than the whole mock, so that the
+// concurrent calls of different methods don't wait on each other.
+//
+// AnonymousTypes declares an alias of the type of each parameter of an
+// anonymous struct or interface type, named after the mock, the method and
+// the parameter, so that the calls recorded by mocks are easier to assert.
+//
+// Fluent makes the methods of mocks without a Func return the mock itself
+// for the results of the type of the mocked interface, such as those of
+// builders, along with the zero values of the other results.
+//
+// NotImplemented makes the methods without a Func of stubs, either of the
+// stub kind or with the zero or random Stub, return an error wrapping
+// ErrNotImplemented of the runtime, github.com/gmhafiz/mirip/runtime, for
+// their error results, rather than nil.
+//
+// Clock adds a Clock field to the mocks of the methods returning a
+// time.Time, which, if set, gives these results when the methods have no
+// Func, overriding the results of Stub, such as a fixed time for "now",
+// except those given by the fixture. It requires Stub.
+//
+// Light mocks only count the calls of their methods, atomically, rather
+// than recording their arguments, for the tests calling them so often
+// that the recording would allocate too much. They have CallCount
+// accessors instead of Calls accessors, and support neither Assert,
+// Flavor nor LockPerMethod.
+//
+// RecordSwitch generates the DisableRecording and EnableRecording
+// methods of mocks switching the recording of calls, and their MaxCalls
+// field bounding the calls recorded of each method, for the memory of
+// long-running tests not to grow with their calls. It supports neither
+// Light nor coverage.
+//
+// Report makes mocks record their calls along with their order among the
+// calls to every mock, returned by their MiripCalls method, for Report of
+// the runtime, github.com/gmhafiz/mirip/runtime, to print a table of the
+// calls to several mocks in the order they happened. It supports neither
+// Light nor RecordSwitch.
+//
+// Registry declares Mocks, a map of a constructor of each generated type
+// by the name of the interface it implements, for test frameworks and
+// fuzzing harnesses to instantiate them by name. The mocks of a package
+// must then be generated in a single file.
+//
+// Shared generates a SharedXMock(t) accessor for each mock, returning the
+// mock of the test, created on its first call and dropped by t.Cleanup,
+// for the package-level fixtures of legacy suites to reach the mock the
+// test configures without sharing it between tests.
+//
+// Builder generates an XMockBuilder for each mock, with chainable
+// With<Method> and Expect<Method>Called methods, which Build turns into a
+// mock with these Funcs and the verification of the number of its calls,
+// for configuring mocks in the cases of tables of tests. BuilderSpec
+// describes their chains of calls for editor completion.
+//
+// VerifyAll declares VerifyAll, running the verifications returned by the
+// builders of the file in order and reporting all their failures at once,
+// each along with the verification it comes from, for a single deferred
+// call to verify the mocks of a test. It requires Builder, and the mocks
+// of a package must then be generated in a single file.
+//
+// Golden generates SaveCallsGolden and AssertCallsGolden for each mock,
+// saving its calls to a golden file as JSON and comparing them with it,
+// for snapshot tests of the interactions with the mock. The arguments
+// which can't be encoded are left out. It requires recording the calls,
+// unlike Light mocks.
+//
+// CallTypes declares the recorded calls of each method as an exported
+// type, such as StoreMockGetCall for the calls of Get by StoreMock, with
+// a documented field for each argument, for the assertion helpers of test
+// utilities to take them. The calls are of anonymous struct types
+// otherwise. It requires recording the calls, unlike Light mocks.
+//
+// Assert, if set, generates an Assert<Method>CalledWith method for each
+// method with parameters, failing the test unless a recorded call has the
+// given arguments, compared with github.com/google/go-cmp and the
+// CmpOptions field of the mock, and reporting the diff of each call
+// otherwise. Only AssertCmp is supported, which the mocks depend on.
+//
+// AssertContext generates, for each method whose first parameter is a
+// context.Context, an Assert<Method>CalledWithContextDeadline method
+// failing the test unless every call had a context with a deadline, and
+// an Assert<Method>CalledWithContextValue method failing it unless the
+// context of a call holds the value of a key. ContextKeys, in the format
+// 'path.Name', are the variables or constants keying context values,
+// which <Method>ContextValues returns the values of for each call.
+//
+// HonorContext makes the methods whose first parameter is a
+// context.Context and last result an error return the error of the
+// context once it is done, after recording the call but before calling
+// their Func, for the paths of cancellations and timeouts to be tested
+// without a Func checking the context in every test.
+//
+// Template, if set, is the text of a text/template replacing the template
+// of the kind, executed with the same template.Data. The templates of the
+// kinds, such as "header", can be used by it. CheckTemplate checks it.
+//
+// Stamp, if set, records the provenance of the mocks in their header,
+// see Stamp.
+//
+// Each generated type is asserted to implement its interface, unless
+// SkipEnsure is set, with the address of a new value by default, or with
+// a nil pointer if EnsureForm is EnsureNil. EnsureAliases asserts it to
+// implement the aliases of the interface declared in its package too.
+// Ensure writes the assertions to another file instead.
+//
+// Overlay replaces the content of the files of the source packages keyed
+// by their absolute path, such as unsaved editor buffers. The cache is
+// not used along with an overlay. ReadOverlay reads the overlays of go
+// build -overlay.
+//
+// CacheDir is the directory of the cache of the type information of
+// source packages, if any, which spares loading them again while their
+// module is unchanged.
+//
+// PackageCache, if set, keeps the loaded source packages in memory for
+// the later calls of long-running processes, before CacheDir is looked up.
+//
+// Loader, if set, loads the source packages instead of go/packages, such
+// as from the export data of a build system or the snapshot of a language
+// server, see Loader. The caches are then not used, nor the source dirs
+// read for telling the empty ones, and the loader applies GOOS, GOARCH,
+// Env and Overlay itself, if needed. The other packages, such as that of
+// the output dir, are still loaded with go/packages, when they are.
+//
+// AllowErrors generates mocks from source packages with errors, such as
+// mid-refactor, as long as the mocked interfaces are fully resolved.
+//
+// GOOS and GOARCH, if set, load the source package for another platform
+// than the one of the environment, for mocking the interfaces declared
+// only in, or differing in, the files of that platform. Env adds
+// variables to the environment of the go command loading the packages,
+// which is otherwise the one of the process, so that settings such as
+// GOFLAGS, GOPROXY or GOPRIVATE apply like they do to go build.
+// Constraint, if set, is the expression of the go:build line of the
+// output files, such as 'windows && amd64', constraining them to the
+// platforms whose source packages the mocks were generated from.
+// Otherwise, when build tags are given by the -tags flag of GOFLAGS or a
+// mocked interface is also declared in files the build excludes, the
+// output files get the go:build lines of the files declaring the mocked
+// interfaces, so that the mocks of each variant of an interface declared
+// once per build tag only build along with it. Without a platform nor
+// build tags, the variants which the build excludes are warned about.
+//
+// Timings, if set, accumulates the time spent in each phase, and Logf, if
+// set, is given a line about each step of the generation. Warn, if set, is
+// given the problems which were worked around, such as a method of an
+// interface named like the helper generated for another, and the
+// constructs of the interfaces which the mocks degrade on, such as the
+// arguments kind recorder can't record, which fail the generation instead
+// if Strict is set. See preflight.
+//
+// Transform, if set, is given the syntax tree of each generated file
+// before it is formatted, which it may edit, such as to add pragmas,
+// methods or assertions without forking the template. The goimports
+// Formatter adds the imports of the code it adds. Its error fails the
+// generation of the file.
+//
+// CalledBy, if set, is the directory of the package under test, whose
+// code and tests are analysed for the methods of the interfaces they call:
+// only these are mocked, the others being delegated to the interface
+// embedded in the mocks like the unexported methods of the interfaces of
+// other packages, to be set before calling them. It reduces the mocks of
+// very large interfaces, such as the clients of cloud SDKs, to the
+// methods the tests exercise. See registry.CalledMethods.
+//
+// Methods, if set, are the only methods whose declarations are regenerated
+// in the existing output files, which are merged with the others left as
+// they are, for regenerating the large mocks of the interfaces of which a
+// few methods are edited. The output files which don't exist are
+// generated in full. See renderMethods.
 type Config struct {
-	SrcDir     string
-	PkgName    string
-	Formatter  string
-	StubImpl   bool
-	SkipEnsure bool
+	SrcDir         string
+	PkgName        string
+	OutFile        string
+	OutDir         string
+	FileCase       string
+	Prefix         string
+	Suffix         string
+	Aliases        map[string]string
+	Kind           string
+	Provider       string
+	Flavor         string
+	Formatter      string
+	Stub           string
+	Coverage       bool
+	Fluent         bool
+	Clock          bool
+	NotImplemented bool
+	Light          bool
+	RecordSwitch   bool
+	Report         bool
+	Builder        bool
+	VerifyAll      bool
+	Golden         bool
+	CallTypes      bool
+	Registry       bool
+	Shared         bool
+	Assert         string
+	AssertContext  bool
+	HonorContext   bool
+	ContextKeys    []string
+	Template       string
+	Stamp          *Stamp
+	Qualifiers     string
+	ImportAliases  map[string]string
+	SrcAlias       string
+	RuntimeAlias   string
+	ConflictSuffix string
+	Conflicts      string
+	ParamNames     string
+	TypeNames      map[string]string
+	Receiver       string
+	CallsField     string
+	LockField      string
+	LockPerMethod  bool
+	SkipEnsure     bool
+	EnsureForm     string
+	EnsureAliases  bool
+	AnonymousTypes bool
+	CacheDir       string
+	PackageCache   *registry.PackageCache
+	Loader         Loader
+	AllowErrors    bool
+	GOOS           string
+	GOARCH         string
+	Constraint     string
+	Overlay        map[string][]byte
+	Env            map[string]string
+	Timings        *Timings
+	Logf           func(format string, args ...interface{})
+	Warn           func(Warning)
+	Strict         bool
+	Transform      func(fset *token.FileSet, file *ast.File) error
+	Methods        []string
+	CalledBy       string
 }
 
 // Mocker can generate mock structs.
@@ -27,106 +591,956 @@ type Mocker struct {
 
 	registry *registry.Registry
 	tmpl     template.Template
+
+	// vars are the names of the variables declared by the code generated
+	// along with the mocks, such as benchmarks, which the parameters and
+	// results are renamed not to shadow.
+	vars []string
 }
 
 func (m Mocker) Mock(out io.Writer, namePairs ...string) error {
-	if len(namePairs) == 0 {
-		return errors.New("must specify one interface")
+	data, err := m.data(namePairs)
+	if err != nil {
+		return err
 	}
 
-	mocks := make([]template.MockData, len(namePairs))
-	for i, np := range namePairs {
-		name, mockName := parseInterfaceName(np)
-		iface, err := m.registry.LookupInterface(name)
+	if len(m.cfg.Methods) != 0 {
+		if err := m.checkMethods(data); err != nil {
+			return err
+		}
+		return m.renderMethods(out, data, m.OutFile(namePairs[0]))
+	}
+	return m.render(out, data)
+}
+
+// Job is the mocking of some interfaces, in the format 'interface' or
+// 'interface:alias', into Out.
+type Job struct {
+	Out       io.Writer
+	NamePairs []string
+}
+
+// MockAll does the given jobs. The interfaces are resolved one job at a
+// time, while up to n jobs are rendered and formatted concurrently. The
+// error of the first failing job is returned. The jobs are resolved, and
+// report their warnings, in order, and each writes to its own Out, so
+// that neither the output nor the warnings depend on n or on the order
+// the jobs are scheduled in.
+func (m Mocker) MockAll(n int, jobs ...Job) error {
+	for _, err := range m.MockEach(n, jobs...) {
 		if err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		methods := make([]template.MethodData, iface.NumMethods())
-		for j := 0; j < iface.NumMethods(); j++ {
-			methods[j] = m.methodData(iface.Method(j))
+// MockEach does the given jobs as MockAll does, regardless of the
+// failures of the others, and returns the error of each job, nil for the
+// jobs done. A job which fails writes nothing to its Out.
+func (m Mocker) MockEach(n int, jobs ...Job) []error {
+	data := make([]template.Data, len(jobs))
+	errs := make([]error, len(jobs))
+	for i, job := range jobs {
+		data[i], errs[i] = m.data(job.NamePairs)
+	}
+	if len(m.cfg.Methods) != 0 {
+		var resolved []template.Data
+		for i := range jobs {
+			if errs[i] == nil {
+				resolved = append(resolved, data[i])
+			}
 		}
+		if err := m.checkMethods(resolved...); err != nil && len(resolved) != 0 {
+			for i := range errs {
+				if errs[i] == nil {
+					errs[i] = err
+				}
+			}
+		}
+	}
 
-		mocks[i] = template.MockData{
-			InterfaceName: name,
-			MockName:      mockName,
-			Methods:       methods,
+	if n < 1 {
+		n = 1
+	}
+	sem := make(chan struct{}, n)
+	var wg sync.WaitGroup
+	for i := range jobs {
+		if errs[i] != nil {
+			continue
 		}
+		sem <- struct{}{}
+		wg.Add(1)
+		go func(i int) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			if len(m.cfg.Methods) != 0 {
+				errs[i] = m.renderMethods(jobs[i].Out, data[i], m.OutFile(jobs[i].NamePairs[0]))
+				return
+			}
+			errs[i] = m.render(jobs[i].Out, data[i])
+		}(i)
+	}
+	wg.Wait()
+	return errs
+}
+
+// render executes the template of the kind for the data and writes the
+// formatted source out.
+func (m Mocker) render(out io.Writer, data template.Data) error {
+	start := time.Now()
+	var buf bytes.Buffer
+	err := m.tmpl.Execute(&buf, data)
+	m.cfg.Timings.since(phaseRender, start)
+	if err != nil {
+		return err
 	}
 
-	data := template.Data{
-		PkgName:    m.mockPkgName(),
-		Mocks:      mocks,
-		StubImpl:   m.cfg.StubImpl,
-		SkipEnsure: m.cfg.SkipEnsure,
+	return m.write(out, buf.Bytes())
+}
+
+// Example generates runnable Example functions for the mocks of the
+// given interfaces, to be written to a test file of the mock package.
+func (m Mocker) Example(out io.Writer, namePairs ...string) error {
+	if m.cfg.Kind != KindMock {
+		return fmt.Errorf("examples are not supported for kind %s", m.cfg.Kind)
 	}
 
-	if m.registry.SrcPkgName() != m.mockPkgName() {
-		data.SrcPkgQualifier = m.registry.SrcPkgName() + "."
-		if !m.cfg.SkipEnsure {
-			imprt := m.registry.AddImport(m.registry.SrcPkg())
-			data.SrcPkgQualifier = imprt.Qualifier() + "."
+	data, err := m.data(namePairs)
+	if err != nil {
+		return err
+	}
+	for _, mock := range data.Mocks {
+		if mock.TypeParams != "" {
+			return fmt.Errorf("interface %s is generic, which examples don't support", mock.InterfaceName)
 		}
 	}
 
-	data.Imports = m.registry.Imports()
+	var vars []*registry.Var
+	for _, mock := range data.Mocks {
+		for _, method := range mock.Methods {
+			for _, p := range append(method.Params, method.Returns...) {
+				vars = append(vars, p.Var)
+			}
+		}
+	}
+	data.Imports = m.registry.ImportsOf(vars, types.NewPackage("fmt", "fmt"))
 
+	start := time.Now()
 	var buf bytes.Buffer
-	if err := m.tmpl.Execute(&buf, data); err != nil {
+	err = m.tmpl.ExecuteExample(&buf, data)
+	m.cfg.Timings.since(phaseRender, start)
+	if err != nil {
 		return err
 	}
 
-	formatted, err := m.format(buf.Bytes())
+	return m.write(out, buf.Bytes())
+}
+
+// Doc generates the doc.go file of a new package of mocks, describing the
+// mocks of the given interfaces it contains and the command generating
+// them. The interfaces may be of other source packages than the one of
+// the Mocker.
+func (m Mocker) Doc(out io.Writer, command string, namePairs ...string) error {
+	names := make([]string, len(namePairs))
+	for i, np := range namePairs {
+		names[i], _ = parseInterfaceName(np, m.mockName)
+	}
+
+	var list string
+	switch len(names) {
+	case 0:
+		return errors.New("must specify one interface")
+	case 1:
+		list = "the interface " + names[0]
+	default:
+		list = "the interfaces " + strings.Join(names[:len(names)-1], ", ") + " and " + names[len(names)-1]
+	}
+
+	pkgName := m.mockPkgName()
+	var buf bytes.Buffer
+	err := m.tmpl.ExecuteDoc(&buf, template.DocData{
+		PkgName: pkgName,
+		Summary: wrap("Package "+pkgName+" contains the mocks of "+list+", generated by mirip.", 70),
+		Command: command,
+	})
 	if err != nil {
 		return err
 	}
 
-	if _, err := out.Write(formatted); err != nil {
+	return m.write(out, buf.Bytes())
+}
+
+// wrap splits the text into lines of at most width characters, unless a
+// word is longer.
+func wrap(text string, width int) []string {
+	var lines []string
+	var line string
+	for _, word := range strings.Fields(text) {
+		switch {
+		case line == "":
+			line = word
+		case len(line)+1+len(word) > width:
+			lines = append(lines, line)
+			line = word
+		default:
+			line += " " + word
+		}
+	}
+	return append(lines, line)
+}
+
+// Interfaces returns the names of the interfaces which can be mocked in
+// the source package, sorted.
+func (m Mocker) Interfaces() []string {
+	return m.registry.Interfaces()
+}
+
+// InterfaceFile returns the path of the file of the source package
+// declaring the interface of the given name, if any.
+func (m Mocker) InterfaceFile(name string) (string, bool) {
+	return m.registry.DeclFile(name)
+}
+
+// InterfacePos returns the position of the declaration of the interface
+// of the given name in the source package, if it is declared there.
+func (m Mocker) InterfacePos(name string) (token.Position, bool) {
+	return m.registry.DeclPos(name)
+}
+
+// data resolves the given interfaces into the template data of their
+// mocks.
+func (m Mocker) data(namePairs []string) (template.Data, error) {
+	if len(namePairs) == 0 {
+		return template.Data{}, errors.New("must specify one interface")
+	}
+
+	defer m.cfg.Timings.since(phaseResolve, time.Now())
+	m.addImports(namePairs)
+
+	mocks := make([]template.MockData, len(namePairs))
+	pkgs := make([]*types.Package, len(namePairs))
+	sources := make([]string, len(namePairs))
+	var limits []limitation
+	var constraints []string
+	var called map[string]bool
+	if m.cfg.CalledBy != "" {
+		var err error
+		opts := registry.LoadOptions{GOOS: m.cfg.GOOS, GOARCH: m.cfg.GOARCH, Overlay: m.cfg.Overlay, Env: m.cfg.Env}
+		if called, err = registry.CalledMethods(m.cfg.CalledBy, opts); err != nil {
+			return template.Data{}, fmt.Errorf("failed to analyse the calls of %s: %w", m.cfg.CalledBy, err)
+		}
+	}
+	for i, np := range namePairs {
+		if _, _, err := ParseNamePair(np); err != nil {
+			return template.Data{}, err
+		}
+		name, mockName := parseInterfaceName(np, m.mockName)
+		var pkg *types.Package
+		var iface *types.Interface
+		var delegated []string
+		var err error
+		if operands := parseInterfaceSet(name); len(operands) > 1 {
+			if _, alias, _ := ParseNamePair(np); alias == "" {
+				return template.Data{}, configErrorf("the mock of interface set %s needs a name, in the format '%s:Name'", name, name)
+			}
+			pkg, name, iface, delegated, err = m.lookupInterfaceSet(name, operands)
+		} else {
+			pkg, name, iface, err = m.lookupInterface(name)
+			if err == nil && pkg == m.registry.SrcPkg() {
+				m.warnVariants(name)
+				constraints = append(constraints, m.variantConstraint(name))
+			}
+		}
+		if err != nil {
+			return template.Data{}, err
+		}
+		// The interfaces which can't be mocked are all reported at once.
+		if unsupported := m.checkTypes(pkg, name, iface); len(unsupported) != 0 {
+			limits = append(limits, unsupported...)
+			continue
+		}
+		mocked, foreign, err := m.withoutForeignMethods(name, iface)
+		if err != nil {
+			return template.Data{}, err
+		}
+		var uncalled []string
+		if called != nil {
+			mocked, uncalled = m.calledMethods(name, mocked, called)
+		}
+
+		mocks[i] = m.mockData(pkg, name, mockName, mocked)
+		mocks[i].Delegated = append(append(delegated, foreign...), uncalled...)
+		mocks[i].TypeSet = !iface.IsMethodSet()
+		limits = append(limits, m.degradations(mocks[i], foreign)...)
+		pkgs[i] = pkg
+		sources[i] = pkg.Path() + "." + name
+		if m.cfg.Kind == KindFakeBuiltin {
+			if err := m.addBuiltinFake(sources[i]); err != nil {
+				return template.Data{}, err
+			}
+			mocks[i].Builtin = sources[i]
+		}
+		m.cfg.logf("resolved interface %s.%s with %d methods, mocked as %s",
+			pkg.Path(), name, mocked.NumMethods(), mockName)
+	}
+	if err := m.preflight(limits); err != nil {
+		return template.Data{}, err
+	}
+	m.disambiguate(mocks, pkgs, namePairs)
+	if m.cfg.NotImplemented && !m.cfg.Report && !returnErrors(mocks) {
+		m.registry.DropImport(runtimePkg.Path())
+	}
+	// The qualifiers of the interfaces are only final once all of their
+	// packages are imported.
+	for i := range mocks {
+		mocks[i].SrcPkgQualifier = m.interfaceQualifier(pkgs[i])
+	}
+
+	if err := m.checkMockNames(mocks); err != nil {
+		return template.Data{}, err
+	}
+	if err := m.checkGeneric(mocks); err != nil {
+		return template.Data{}, err
+	}
+	m.nameHelpers(mocks)
+	keys, err := m.contextKeys()
+	if err != nil {
+		return template.Data{}, err
+	}
+
+	if err := m.checkImportCycle(); err != nil {
+		return template.Data{}, err
+	}
+
+	data := m.dataOf(mocks)
+	data.ContextKeys = keys
+	if data.Constraint == "" {
+		data.Constraint = joinConstraints(constraints)
+	}
+	data.Stamp = m.stamp(sources)
+	return data, nil
+}
+
+// checkImportCycle ensures that no import of the mocks imports the output
+// package back, which the go command rejects as an import cycle, such as
+// a package of another interface importing the source package when the
+// mocks are written to the source package. The mocks of an external test
+// package can import any package.
+func (m Mocker) checkImportCycle() error {
+	if strings.HasSuffix(m.mockPkgName(), "_test") {
+		return nil
+	}
+	chain, err := m.registry.ImportCycle(m.registry.Imports())
+	if err != nil || chain == nil {
 		return err
 	}
+	if len(chain) == 1 {
+		return fmt.Errorf("the mocks import the package %s of the output file itself, an import cycle: generate them into another package, such as an external test package with -pkg %s_test, or a mocks package with -out-dir",
+			chain[0], m.mockPkgName())
+	}
+	var through string
+	if len(chain) > 2 {
+		through = " through " + strings.Join(chain[1:len(chain)-1], ", ")
+	}
+	return fmt.Errorf("the mocks import %s, which imports the package %s of the output file%s, an import cycle: generate them into another package, such as an external test package with -pkg %s_test, or a mocks package with -out-dir",
+		chain[0], chain[len(chain)-1], through, m.mockPkgName())
+}
+
+// contextKeys returns the context keys of the config, qualified for the
+// mocks, importing their packages.
+func (m Mocker) contextKeys() ([]string, error) {
+	keys := make([]string, len(m.cfg.ContextKeys))
+	for i, key := range m.cfg.ContextKeys {
+		path, name := splitQualified(key)
+		pkg, err := m.registry.LookupQualifiedValue(path, name)
+		if err != nil {
+			return nil, err
+		}
+		keys[i] = name
+		if imprt := m.registry.AddImport(pkg); imprt != nil {
+			if !token.IsExported(name) {
+				return nil, fmt.Errorf("context key %s is unexported", key)
+			}
+			keys[i] = imprt.Qualifier() + "." + name
+		}
+	}
+	return keys, nil
+}
+
+// lookupInterface returns the interface of the given name, in the format
+// 'Interface' for the interfaces of the source package or 'path.Interface'
+// for those of any package, along with its package and unqualified name.
+func (m Mocker) lookupInterface(name string) (*types.Package, string, *types.Interface, error) {
+	path, name := splitQualified(name)
+	if path == "" && m.registry.IsEmpty() {
+		return nil, "", nil, fmt.Errorf("interface not found: %s\n\t%s holds no Go files, give the interface with the path of its package, ex: io.Reader",
+			name, m.cfg.SrcDir)
+	}
+	if path == "" {
+		iface, err := m.registry.LookupInterface(name)
+		return m.registry.SrcPkg(), name, iface, err
+	}
+
+	pkg, iface, err := m.registry.LookupQualifiedInterface(path, name)
+	return pkg, name, iface, err
+}
+
+// warnVariants warns about the declarations of the interface of the
+// source package in the files its build excludes, whose variant isn't
+// mocked, unless the build configuration is given explicitly with build
+// tags or a platform.
+func (m Mocker) warnVariants(name string) {
+	opts := registry.LoadOptions{GOOS: m.cfg.GOOS, GOARCH: m.cfg.GOARCH, Env: m.cfg.Env}
+	if opts.SelectsBuild() {
+		return
+	}
+	for _, v := range m.registry.Variants(name) {
+		m.cfg.warn(name, "mocking the declaration of the build of the environment, also declared at %s: select it with -tags, -goos or -goarch", v)
+	}
+}
+
+// calledMethods returns the interface with only the methods which the
+// package under test calls, along with the names of the others.
+func (m Mocker) calledMethods(name string, iface *types.Interface, called map[string]bool) (*types.Interface, []string) {
+	var methods []*types.Func
+	var uncalled []string
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		if called[method.Pkg().Path()+"."+method.Name()] {
+			methods = append(methods, method)
+		} else {
+			uncalled = append(uncalled, method.Name())
+		}
+	}
+	if len(uncalled) == 0 {
+		return iface, nil
+	}
+	if len(methods) == 0 {
+		m.cfg.warn(name, "%s calls none of its methods, which are all delegated to the embedded interface", m.cfg.CalledBy)
+	}
+	m.cfg.logf("mocking %d of the %d methods of %s called by %s", len(methods), iface.NumMethods(), name, m.cfg.CalledBy)
+	return types.NewInterfaceType(methods, nil).Complete(), uncalled
+}
+
+// variantConstraint returns the build constraint of the file declaring
+// the interface of the source package when build tags are given or the
+// interface has variants, for the mocks of each variant to only build
+// along with it, and "" otherwise.
+func (m Mocker) variantConstraint(name string) string {
+	opts := registry.LoadOptions{Env: m.cfg.Env}
+	if opts.Tags() == "" && len(m.registry.Variants(name)) == 0 {
+		return ""
+	}
+	return m.registry.DeclConstraint(name)
+}
+
+// joinConstraints returns the conjunction of the distinct non-empty build
+// constraints, "" if there are none.
+func joinConstraints(exprs []string) string {
+	var joined constraint.Expr
+	seen := make(map[string]bool)
+	for _, e := range exprs {
+		if e == "" || seen[e] {
+			continue
+		}
+		seen[e] = true
+		expr, err := constraint.Parse("//go:build " + e)
+		if err != nil {
+			continue
+		}
+		if joined == nil {
+			joined = expr
+		} else {
+			joined = &constraint.AndExpr{X: joined, Y: expr}
+		}
+	}
+	if joined == nil {
+		return ""
+	}
+	return joined.String()
+}
+
+// disambiguate renames the mocks of the interfaces of the same name
+// declared in different packages, which would have the same default mock
+// name, after as many of the last elements of the paths of their packages
+// as needed for the names to differ, ex: APkgClientMock and
+// BPkgClientMock for github.com/a/pkg.Client and github.com/b/pkg.Client.
+// Mocks given a name with 'interface:alias' are left as is.
+func (m Mocker) disambiguate(mocks []template.MockData, pkgs []*types.Package, namePairs []string) {
+	byName := make(map[string][]int)
+	for i, np := range namePairs {
+		if _, alias, _ := ParseNamePair(np); alias == "" {
+			byName[mocks[i].MockName] = append(byName[mocks[i].MockName], i)
+		}
+	}
+
+	for name, same := range byName {
+		paths := make(map[string]bool)
+		for _, i := range same {
+			paths[pkgs[i].Path()] = true
+		}
+		if len(paths) < 2 {
+			continue
+		}
+
+		for lvl := 1; ; lvl++ {
+			names := make(map[string]bool)
+			exhausted := true
+			for _, i := range same {
+				prefix, more := pathPrefix(pkgs[i].Path(), lvl)
+				names[prefix+name] = true
+				exhausted = exhausted && !more
+			}
+			if len(names) < len(paths) && !exhausted {
+				continue
+			}
+
+			for _, i := range same {
+				prefix, _ := pathPrefix(pkgs[i].Path(), lvl)
+				mocks[i].MockName = prefix + name
+				m.cfg.warn(namePairs[i], "%s of %s is named %s, as interfaces of other packages are named %s too",
+					name, pkgs[i].Path(), mocks[i].MockName, mocks[i].InterfaceName)
+			}
+			break
+		}
+	}
+}
+
+// pathPrefix returns the last lvl elements of the package path, exported
+// and without the characters which identifiers can't have, ex: APkg for
+// github.com/a/pkg at level 2, along with whether the path has more
+// elements.
+func pathPrefix(path string, lvl int) (string, bool) {
+	elems := strings.Split(path, "/")
+	more := len(elems) > lvl
+	if more {
+		elems = elems[len(elems)-lvl:]
+	}
+
+	var prefix string
+	for _, elem := range elems {
+		prefix += template.Exported(strings.Map(func(r rune) rune {
+			if unicode.IsLetter(r) || unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, elem))
+	}
+	return prefix, more
+}
+
+// splitQualified splits the interface name in the format 'path.Interface'
+// into the path of its package and its name. The path is empty for the
+// interface names without a package.
+func splitQualified(name string) (path, iface string) {
+	i := strings.LastIndex(name, ".")
+	if i < 0 {
+		return "", name
+	}
+	return name[:i], name[i+1:]
+}
+
+// outsideSrcDir returns whether the mocks are written to another directory
+// than the source directory, in another package even if named the same.
+func (m Mocker) outsideSrcDir() bool {
+	return m.cfg.OutFile != "" && !sameDir(filepath.Dir(m.cfg.OutFile), m.cfg.SrcDir)
+}
+
+// mockName returns the default name of the generated type for the
+// interface of the given name.
+func (m Mocker) mockName(interfaceName string) string {
+	if alias, ok := m.cfg.Aliases[interfaceName]; ok {
+		return alias
+	}
+
+	suffix := m.cfg.Suffix
+	if suffix == "" && m.cfg.Prefix == "" {
+		suffix = kindSuffixes[m.cfg.Kind]
+	}
+	return m.cfg.Prefix + interfaceName + suffix
+}
+
+// checkMockNames ensures that the names of the mocks are unique and do
+// not conflict with the declarations of the output package, other than
+// those of the output file.
+func (m Mocker) checkMockNames(mocks []template.MockData) error {
+	seen := make(map[string]string)
+	for _, mock := range mocks {
+		if other, ok := seen[mock.MockName]; ok {
+			return fmt.Errorf("both %s and %s would be mocked as %s", other, mock.InterfaceName, mock.MockName)
+		}
+		seen[mock.MockName] = mock.InterfaceName
+	}
+	if m.cfg.Registry {
+		registered := make(map[string]string)
+		for _, mock := range mocks {
+			if other, ok := registered[mock.InterfaceName]; ok {
+				return fmt.Errorf("both %s and %s would be registered as %s", other, mock.MockName, mock.InterfaceName)
+			}
+			registered[mock.InterfaceName] = mock.MockName
+		}
+	}
+
+	names := make([]string, len(mocks))
+	for i, mock := range mocks {
+		names[i] = mock.InterfaceName
+	}
+	decls := m.outDeclarations(names)
+
+	for _, mock := range mocks {
+		if pos, ok := decls[mock.MockName]; ok {
+			return fmt.Errorf("mock %s of %s conflicts with the declaration at %s, use -prefix, -suffix or an alias to rename it",
+				mock.MockName, mock.InterfaceName, pos)
+		}
+	}
+	if pos, ok := decls[registryVar]; ok && m.cfg.Registry {
+		return fmt.Errorf("the registry %s conflicts with the declaration at %s", registryVar, pos)
+	}
+	for _, name := range verifyAllNames {
+		if pos, ok := decls[name]; ok && m.cfg.VerifyAll {
+			return fmt.Errorf("%s of VerifyAll conflicts with the declaration at %s", name, pos)
+		}
+	}
+	for _, mock := range mocks {
+		for _, method := range mock.Methods {
+			if pos, ok := decls[callTypeName(mock, method)]; ok && m.cfg.CallTypes {
+				return fmt.Errorf("the call type %s of %s conflicts with the declaration at %s",
+					callTypeName(mock, method), mock.MockName, pos)
+			}
+		}
+		if pos, ok := decls[sharedPrefix+mock.MockName]; ok && m.cfg.Shared {
+			return fmt.Errorf("the accessor %s of %s conflicts with the declaration at %s",
+				sharedPrefix+mock.MockName, mock.MockName, pos)
+		}
+	}
 	return nil
 }
 
-func (m *Mocker) mockPkgName() string {
-	if m.cfg.PkgName != "" {
-		return m.cfg.PkgName
+// outDeclarations returns the declarations of the package of the mocks
+// of the given interfaces, besides those of the files of the mocks, if
+// the package is known: the one of the output files, or the source
+// package when the mocks are generated into it.
+func (m Mocker) outDeclarations(names []string) map[string]token.Position {
+	switch {
+	case m.cfg.OutFile != "":
+		outFiles := make([]string, len(names))
+		for i, name := range names {
+			outFiles[i] = m.OutFile(name)
+		}
+		return m.registry.Declarations(filepath.Dir(outFiles[0]), outFiles...)
+	case m.mockPkgName() == m.registry.SrcPkgName():
+		return m.registry.Declarations(m.cfg.SrcDir)
 	}
+	return nil
+}
 
-	return m.registry.SrcPkgName()
+// addImports resets the imports to the ones needed by the generated code
+// of the mocks of the given interfaces, besides the ones of the mocked
+// methods. It must be called before resolving the methods for their
+// variables not to conflict with the imports.
+func (m Mocker) addImports(names []string) {
+	m.registry.ResetImports()
+	for _, pkg := range kindImports[m.cfg.Kind] {
+		m.registry.AddImport(pkg)
+	}
+	if pkg, ok := providerImports[m.cfg.Provider]; ok {
+		m.registry.AddImport(pkg)
+	}
+	for _, pkg := range flavorImports[m.cfg.Flavor] {
+		m.registry.AddImport(pkg)
+	}
+	for _, pkg := range stubImports[m.cfg.Stub] {
+		m.registry.AddImport(pkg)
+	}
+	if m.cfg.NotImplemented || m.cfg.Report {
+		// The name of the runtime isn't the last element of its path, and
+		// it is aliased otherwise when the package of the mocks, or their
+		// imports, already use it.
+		var taken []string
+		for name := range m.outDeclarations(names) {
+			taken = append(taken, name)
+		}
+		m.registry.AddYieldingImport(runtimePkg, runtimePkg.Name(), taken...)
+	}
+	if m.cfg.Report {
+		m.registry.AddImport(types.NewPackage("sync", "sync"))
+	}
+	if m.cfg.Light {
+		for _, pkg := range lightImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	if m.cfg.Builder {
+		for _, pkg := range builderImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	if m.cfg.VerifyAll {
+		for _, pkg := range verifyAllImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	for _, pkg := range assertImports[m.cfg.Assert] {
+		m.registry.AddImport(pkg)
+	}
+	if m.cfg.AssertContext {
+		for _, pkg := range assertContextImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	if m.cfg.Coverage {
+		for _, pkg := range coverageImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	if m.cfg.Golden {
+		for _, pkg := range goldenImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+	if m.cfg.Shared {
+		for _, pkg := range sharedImports {
+			m.registry.AddImport(pkg)
+		}
+	}
+}
+
+// returnErrors reports whether a method of the mocks has an error result.
+func returnErrors(mocks []template.MockData) bool {
+	for _, mock := range mocks {
+		for _, method := range mock.Methods {
+			for _, r := range method.Returns {
+				if r.TypeString() == "error" {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// dataOf returns the template data for the given mocks.
+func (m Mocker) dataOf(mocks []template.MockData) template.Data {
+	return template.Data{
+		Kind:           m.cfg.Kind,
+		Provider:       m.cfg.Provider,
+		Flavor:         m.cfg.Flavor,
+		WithResets:     m.cfg.Flavor == FlavorGinkgo,
+		PkgName:        m.mockPkgName(),
+		Imports:        m.registry.Imports(),
+		Mocks:          mocks,
+		Stub:           m.cfg.Stub,
+		Clock:          m.cfg.Clock,
+		NotImplemented: m.cfg.NotImplemented,
+		Coverage:       m.cfg.Coverage,
+		Light:          m.cfg.Light,
+		RecordSwitch:   m.cfg.RecordSwitch,
+		Report:         m.cfg.Report,
+		Builder:        m.cfg.Builder,
+		VerifyAll:      m.cfg.VerifyAll,
+		Golden:         m.cfg.Golden,
+		Registry:       m.cfg.Registry,
+		Shared:         m.cfg.Shared,
+		Assert:         m.cfg.Assert,
+		AssertContext:  m.cfg.AssertContext,
+		SkipEnsure:     m.cfg.SkipEnsure,
+		Receiver:       m.cfg.Receiver,
+		CallsField:     m.cfg.CallsField,
+		LockField:      m.cfg.LockField,
+		LockPerMethod:  m.cfg.LockPerMethod,
+		Constraint:     m.cfg.Constraint,
+	}
+}
+
+// mockData returns the template data for mocking the given interface,
+// declared in pkg.
+func (m Mocker) mockData(pkg *types.Package, name, mockName string, iface *types.Interface) template.MockData {
+	methods := make([]template.MethodData, iface.NumMethods())
+	self := pkg.Scope().Lookup(name)
+	reserved := append(kindVars[m.cfg.Kind], stubVars[m.cfg.Stub]...)
+	if m.cfg.Assert != "" {
+		reserved = append(reserved, assertVars...)
+	}
+	if m.cfg.Builder {
+		reserved = append(reserved, builderVars...)
+	}
+	reserved = append(reserved, m.vars...)
+	for j := 0; j < iface.NumMethods(); j++ {
+		methods[j] = m.methodData(iface.Method(j), reserved...)
+
+		if m.cfg.Fluent {
+			results := iface.Method(j).Type().(*types.Signature).Results()
+			for k := 0; k < results.Len(); k++ {
+				if named, ok := results.At(k).Type().(*types.Named); ok && named.Obj() == self && ownTypeArgs(named) {
+					methods[j].Returns[k].Self = true
+				}
+			}
+		}
+		if !m.cfg.AnonymousTypes {
+			continue
+		}
+		for k, p := range methods[j].Params {
+			if !p.Variadic && p.Var.IsAnonymous() {
+				methods[j].Params[k].Helper = mockName + methods[j].Name + template.Exported(p.Name())
+			}
+		}
+	}
+
+	typeParams, typeArgs := m.registry.TypeParams(self.Type())
+	var aliases []string
+	if m.cfg.EnsureAliases && typeParams == "" {
+		aliases = m.interfaceAliases(self)
+	}
+	return template.MockData{
+		InterfaceName:    name,
+		SrcPkgQualifier:  m.interfaceQualifier(pkg),
+		MockName:         mockName,
+		Methods:          methods,
+		TypeParams:       typeParams,
+		TypeArgs:         typeArgs,
+		InterfaceAliases: aliases,
+		EnsureNil:        m.cfg.EnsureForm == EnsureNil,
+	}
+}
+
+// ownTypeArgs returns whether the instance of a generic type has the type
+// parameters of the type as type arguments, such as the Tree[T] returned
+// by the methods of Tree[T any], which mocks of the type satisfy.
+func ownTypeArgs(named *types.Named) bool {
+	args, params := named.TypeArgs(), named.Origin().TypeParams()
+	for i := 0; i < args.Len(); i++ {
+		if args.At(i) != params.At(i) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkGeneric ensures that the options of the mocks support the generic
+// interfaces among them, which get generic mocks.
+func (m Mocker) checkGeneric(mocks []template.MockData) error {
+	for _, mock := range mocks {
+		if mock.TypeParams == "" {
+			continue
+		}
+		switch {
+		case m.cfg.Kind != KindMock && m.cfg.Kind != KindStub:
+			return fmt.Errorf("interface %s is generic, which kind %s doesn't support", mock.InterfaceName, m.cfg.Kind)
+		case m.cfg.Provider != "":
+			return fmt.Errorf("interface %s is generic, which providers don't support", mock.InterfaceName)
+		case m.cfg.Flavor != "":
+			return fmt.Errorf("interface %s is generic, which flavor %s doesn't support", mock.InterfaceName, m.cfg.Flavor)
+		case m.cfg.Coverage:
+			return fmt.Errorf("interface %s is generic, which coverage doesn't support", mock.InterfaceName)
+		case m.cfg.Registry:
+			return fmt.Errorf("interface %s is generic, which the registry doesn't support", mock.InterfaceName)
+		case m.cfg.Shared:
+			return fmt.Errorf("interface %s is generic, which shared mocks don't support", mock.InterfaceName)
+		case m.cfg.Stub == StubFixture:
+			return fmt.Errorf("interface %s is generic, which stub %s doesn't support", mock.InterfaceName, m.cfg.Stub)
+		}
+	}
+	return nil
+}
+
+// interfaceQualifier returns the qualifier, with the trailing dot, used
+// to refer to the interfaces declared in pkg. The package is imported
+// when the generated code refers to the interfaces.
+func (m Mocker) interfaceQualifier(pkg *types.Package) string {
+	if pkg == m.registry.SrcPkg() && m.registry.SrcPkgName() == m.mockPkgName() && !m.outsideSrcDir() {
+		return ""
+	}
+
+	if m.cfg.SkipEnsure && !m.refersToInterface() {
+		return pkg.Name() + "."
+	}
+
+	if imprt := m.registry.AddImport(pkg); imprt != nil {
+		return imprt.Qualifier() + "."
+	}
+	return ""
 }
 
-func (m *Mocker) format(src []byte) ([]byte, error) {
+// write formats the generated source and writes it out. With gofmt, the
+// source is formatted straight into out rather than into yet another
+// buffer, as the source generated for large interfaces is big.
+func (m Mocker) write(out io.Writer, src []byte) error {
+	defer m.cfg.Timings.since(phaseFormat, time.Now())
+
+	if m.cfg.Transform != nil {
+		transformed, err := transform(src, m.cfg.Transform)
+		if err != nil {
+			return err
+		}
+		src = transformed
+	}
+
 	switch m.cfg.Formatter {
 	case "goimports":
-		return goimports(src)
+		formatted, err := goimports(src)
+		if err != nil {
+			return err
+		}
+
+		_, err = out.Write(formatted)
+		return err
 
 	case "noop":
-		return src, nil
+		_, err := out.Write(src)
+		return err
+	}
+
+	return gofmt(out, src)
+}
+
+// refersToInterface returns whether the generated code refers to the
+// mocked interfaces, in which case the source package must be imported.
+func (m *Mocker) refersToInterface() bool {
+	return m.cfg.Kind == KindChaos || m.cfg.Kind == KindRecorder || m.cfg.Kind == KindFakeBuiltin || m.cfg.Provider != ""
+}
+
+func (m *Mocker) mockPkgName() string {
+	if m.cfg.PkgName != "" {
+		return m.cfg.PkgName
 	}
 
-	return gofmt(src)
+	return m.registry.SrcPkgName()
 }
 
-func (m *Mocker) methodData(f *types.Func) template.MethodData {
+func (m *Mocker) methodData(f *types.Func, reserved ...string) template.MethodData {
 	sig := f.Type().(*types.Signature)
 
-	scope := m.registry.MethodScope()
+	// The parameters and results are named together, for their names not
+	// to depend on the order they are added in.
 	n := sig.Params().Len()
+	vrs := make([]*types.Var, 0, n+sig.Results().Len())
+	suffixes := make([]string, 0, cap(vrs))
+	for i := 0; i < n; i++ {
+		vrs, suffixes = append(vrs, sig.Params().At(i)), append(suffixes, "")
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		vrs, suffixes = append(vrs, sig.Results().At(i)), append(suffixes, "Out")
+	}
+	scope := m.registry.MethodScope(append([]string{m.cfg.Receiver}, reserved...)...)
+	vars := scope.AddVars(vrs, suffixes)
+
 	params := make([]template.ParamData, n)
 	for i := 0; i < n; i++ {
 		p := template.ParamData{
-			Var: scope.AddVar(sig.Params().At(i), ""),
+			Var: vars[i],
 		}
 		p.Variadic = sig.Variadic() && i == n-1 && p.Var.IsSlice() // check for final variadic argument
 
 		params[i] = p
 	}
 
-	n = sig.Results().Len()
-	results := make([]template.ParamData, n)
-	for i := 0; i < n; i++ {
+	results := make([]template.ParamData, len(vars)-n)
+	for i := range results {
 		results[i] = template.ParamData{
-			Var: scope.AddVar(sig.Results().At(i), "Out"),
+			Var: vars[n+i],
 		}
 	}
 
@@ -139,15 +1553,173 @@ func (m *Mocker) methodData(f *types.Func) template.MethodData {
 
 // New makes a new Mocker for the specified package directory.
 func New(cfg Config) (*Mocker, error) {
-	reg, err := registry.New(cfg.SrcDir, cfg.PkgName)
+	mockers, err := NewAll(cfg)
 	if err != nil {
 		return nil, err
 	}
 
+	return mockers[0], nil
+}
+
+// NewAll makes a new Mocker for each of the given configs, in the same
+// order. The source packages are loaded together, which is much faster
+// than making each Mocker with New when they share dependencies. The
+// time spent loading is accounted to the Timings of the first config,
+// and its AllowErrors, GOOS, GOARCH and Overlay apply to all the
+// packages.
+func NewAll(cfgs ...Config) ([]*Mocker, error) {
+	return NewAllContext(context.Background(), cfgs...)
+}
+
+// NewAllContext is NewAll with a context cancelling the loading of the
+// source packages.
+func NewAllContext(ctx context.Context, cfgs ...Config) ([]*Mocker, error) {
+	var opts registry.LoadOptions
+	if len(cfgs) != 0 {
+		defer cfgs[0].Timings.since(phaseLoad, time.Now())
+		opts = registry.LoadOptions{
+			Loader:      cfgs[0].Loader,
+			AllowErrors: cfgs[0].AllowErrors,
+			GOOS:        cfgs[0].GOOS,
+			GOARCH:      cfgs[0].GOARCH,
+			Context:     ctx,
+			Overlay:     cfgs[0].Overlay,
+			Env:         cfgs[0].Env,
+		}
+	}
+
+	pkgs := make([]*packages.Package, len(cfgs))
+	var uncached []int
+	var srcDirs []string
+	// The configs of the same source dir, such as of different kinds,
+	// share its package.
+	loadIndex := make(map[string]int)
+	for i, cfg := range cfgs {
+		valid, err := cfg.validate()
+		if err != nil {
+			return nil, err
+		}
+		cfgs[i] = valid
+
+		// The interfaces of other packages may be mocked in a directory
+		// holding no package yet.
+		if opts.Loader == nil && isEmptyDir(valid.SrcDir) {
+			name := valid.PkgName
+			if name == "" {
+				name = dirPkgName(valid.SrcDir, opts)
+			}
+			valid.logf("source dir %s holds no Go files, mocking in the new package %s", valid.SrcDir, name)
+			pkgs[i] = registry.EmptyPackage(valid.SrcDir, name)
+			continue
+		}
+
+		// The caches only know the files on disk, not the overlay.
+		if cfg.PackageCache != nil && len(opts.Overlay) == 0 && opts.Loader == nil {
+			if pkg, ok := cfg.PackageCache.Package(cfg.SrcDir, opts); ok {
+				valid.logf("loaded package %s from memory", pkg.PkgPath)
+				pkgs[i] = pkg
+				continue
+			}
+		}
+		if cfg.CacheDir != "" && len(opts.Overlay) == 0 && opts.Loader == nil {
+			if pkg, ok := registry.CachedPackage(cfg.CacheDir, cfg.SrcDir, opts); ok {
+				valid.logf("loaded package %s from the cache in %s", pkg.PkgPath, cfg.CacheDir)
+				pkgs[i] = pkg
+				continue
+			}
+		}
+		uncached = append(uncached, i)
+		if _, ok := loadIndex[cfg.SrcDir]; !ok {
+			loadIndex[cfg.SrcDir] = len(srcDirs)
+			srcDirs = append(srcDirs, cfg.SrcDir)
+		}
+	}
+
+	if len(srcDirs) != 0 {
+		cfgs[0].logf("loading packages in %s", strings.Join(srcDirs, ", "))
+		loaded, err := registry.Load(opts, srcDirs...)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, i := range uncached {
+			pkg := loaded[loadIndex[cfgs[i].SrcDir]]
+			cfgs[i].logf("loaded package %s from %s (%d files, %d errors)",
+				pkg.PkgPath, cfgs[i].SrcDir, len(pkg.GoFiles), len(pkg.Errors))
+			pkgs[i] = pkg
+			if cfgs[i].PackageCache != nil && len(opts.Overlay) == 0 && opts.Loader == nil && len(pkg.Errors) == 0 {
+				cfgs[i].PackageCache.Store(cfgs[i].SrcDir, opts, pkg)
+			}
+			if cfgs[i].CacheDir != "" && len(opts.Overlay) == 0 && opts.Loader == nil && len(pkg.Errors) == 0 {
+				// The cache only speeds up later runs, so failing to
+				// store the package doesn't fail this one.
+				_ = registry.CachePackage(cfgs[i].CacheDir, cfgs[i].SrcDir, opts, pkg)
+			}
+		}
+	}
+
+	mockers := make([]*Mocker, len(cfgs))
+	for i, cfg := range cfgs {
+		reg := registry.NewWithPackage(pkgs[i], cfg.PkgName)
+		reg.SetLoadOptions(opts)
+		reg.SetSrcDir(cfg.SrcDir)
+		if cfg.OutFile != "" {
+			reg.SetOutDir(filepath.Dir(cfg.OutFile))
+			if err := reg.CheckOutModule(filepath.Dir(cfg.OutFile)); err != nil {
+				return nil, err
+			}
+		}
+		m, err := newMocker(cfg, reg)
+		if err != nil {
+			return nil, err
+		}
+		mockers[i] = m
+	}
+	return mockers, nil
+}
+
+func newMocker(cfg Config, reg *registry.Registry) (*Mocker, error) {
 	tmpl, err := template.New()
 	if err != nil {
 		return nil, err
 	}
+	if cfg.Template != "" {
+		if tmpl, err = tmpl.Override(cfg.Kind, cfg.Template); err != nil {
+			return nil, configErrorf("invalid template: %s", err)
+		}
+	}
+	importAliases := cfg.ImportAliases
+	if cfg.SrcAlias != "" {
+		srcPath := reg.SrcPkg().Path()
+		importAliases = map[string]string{srcPath: cfg.SrcAlias}
+		for path, alias := range cfg.ImportAliases {
+			if path == srcPath {
+				continue
+			}
+			if alias == cfg.SrcAlias {
+				return nil, configErrorf("alias %s is given to both the source package and import %s", alias, path)
+			}
+			importAliases[path] = alias
+		}
+	}
+	if cfg.RuntimeAlias != "" {
+		aliases := map[string]string{runtimePkg.Path(): cfg.RuntimeAlias}
+		for path, alias := range importAliases {
+			if path == runtimePkg.Path() {
+				continue
+			}
+			if alias == cfg.RuntimeAlias {
+				return nil, configErrorf("alias %s is given to both the runtime and import %s", alias, path)
+			}
+			aliases[path] = alias
+		}
+		importAliases = aliases
+	}
+	reg.SetQualifiers(cfg.Qualifiers, importAliases)
+	reg.SetConflictSuffix(cfg.ConflictSuffix)
+	reg.SetConflicts(cfg.Conflicts)
+	reg.SetParamNames(cfg.ParamNames)
+	reg.SetTypeNames(cfg.TypeNames)
 
 	return &Mocker{
 		cfg:      cfg,
@@ -156,12 +1728,317 @@ func New(cfg Config) (*Mocker, error) {
 	}, nil
 }
 
-func parseInterfaceName(namePair string) (interfaceName, mockName string) {
-	parts := strings.SplitN(namePair, ":", 2)
-	if len(parts) == 2 {
-		return parts[0], parts[1]
+// ConfigError is returned for an invalid Config.
+type ConfigError struct {
+	msg string
+}
+
+func (e *ConfigError) Error() string {
+	return e.msg
+}
+
+func configErrorf(format string, args ...interface{}) error {
+	return &ConfigError{msg: fmt.Sprintf(format, args...)}
+}
+
+// logf logs a line with Logf, if set.
+func (cfg Config) logf(format string, args ...interface{}) {
+	if cfg.Logf != nil {
+		cfg.Logf(format, args...)
+	}
+}
+
+// validate returns the config with its defaults set, or an error if it
+// isn't valid.
+func (cfg Config) validate() (Config, error) {
+	if cfg.Kind == "" {
+		cfg.Kind = KindMock
+	}
+	if _, ok := kindSuffixes[cfg.Kind]; !ok {
+		return cfg, configErrorf("unknown kind: %s", cfg.Kind)
+	}
+	switch cfg.Provider {
+	case "", ProviderFunc, ProviderWire, ProviderFx:
+	default:
+		return cfg, configErrorf("unknown provider: %s", cfg.Provider)
+	}
+	switch cfg.Flavor {
+	case "":
+	case FlavorGinkgo, FlavorTestify:
+		if cfg.Kind != KindMock {
+			return cfg, configErrorf("flavor %s is only supported for kind %s", cfg.Flavor, KindMock)
+		}
+	default:
+		return cfg, configErrorf("unknown flavor: %s", cfg.Flavor)
+	}
+	switch cfg.Stub {
+	case "":
+	case StubZero, StubRandom, StubFixture, StubFuzz:
+		if cfg.Kind != KindMock {
+			return cfg, configErrorf("stub %s is only supported for kind %s", cfg.Stub, KindMock)
+		}
+	default:
+		return cfg, configErrorf("unknown stub: %s", cfg.Stub)
+	}
+	if cfg.Coverage && cfg.Kind != KindMock {
+		return cfg, configErrorf("coverage is only supported for kind %s", KindMock)
+	}
+	if cfg.Fluent && cfg.Kind != KindMock {
+		return cfg, configErrorf("fluent is only supported for kind %s", KindMock)
+	}
+	if cfg.Constraint != "" {
+		if _, err := constraint.Parse("//go:build " + cfg.Constraint); err != nil {
+			return cfg, configErrorf("invalid build constraint %s: %v", cfg.Constraint, err)
+		}
+	}
+	if cfg.Clock && cfg.Stub == "" {
+		return cfg, configErrorf("clock requires a stub")
+	}
+	if cfg.NotImplemented && cfg.Kind != KindStub && cfg.Stub != StubZero && cfg.Stub != StubRandom {
+		return cfg, configErrorf("not implemented requires kind %s, or stub %s or %s", KindStub, StubZero, StubRandom)
+	}
+	if cfg.Light {
+		switch {
+		case cfg.Kind != KindMock:
+			return cfg, configErrorf("light is only supported for kind %s", KindMock)
+		case cfg.Assert != "":
+			return cfg, configErrorf("light mocks don't record the arguments of assert %s", cfg.Assert)
+		case cfg.AssertContext || len(cfg.ContextKeys) != 0:
+			return cfg, configErrorf("light mocks don't record the contexts of calls")
+		case cfg.Flavor != "":
+			return cfg, configErrorf("light mocks don't record the calls of flavor %s", cfg.Flavor)
+		case cfg.LockPerMethod:
+			return cfg, configErrorf("light mocks have no locks per method")
+		}
+	}
+	if cfg.RecordSwitch {
+		switch {
+		case cfg.Kind != KindMock:
+			return cfg, configErrorf("toggling the recording is only supported for kind %s", KindMock)
+		case cfg.Light:
+			return cfg, configErrorf("light mocks don't record calls to toggle")
+		case cfg.Coverage:
+			return cfg, configErrorf("coverage requires the recording of every call")
+		}
+	}
+	if cfg.Report {
+		switch {
+		case cfg.Kind != KindMock:
+			return cfg, configErrorf("reporting the calls is only supported for kind %s", KindMock)
+		case cfg.Light:
+			return cfg, configErrorf("light mocks don't record the calls to report")
+		case cfg.RecordSwitch:
+			return cfg, configErrorf("reporting the calls requires the recording of every call")
+		}
+	}
+	switch cfg.EnsureForm {
+	case "", EnsureAddr, EnsureNil:
+	default:
+		return cfg, configErrorf("unknown ensure form: %s", cfg.EnsureForm)
+	}
+	if cfg.Builder && cfg.Kind != KindMock {
+		return cfg, configErrorf("builder is only supported for kind %s", KindMock)
+	}
+	if cfg.VerifyAll && !cfg.Builder {
+		return cfg, configErrorf("VerifyAll runs the verifications of the builders of Builder")
+	}
+	if cfg.Golden {
+		switch {
+		case cfg.Kind != KindMock:
+			return cfg, configErrorf("golden calls are only supported for kind %s", KindMock)
+		case cfg.Light:
+			return cfg, configErrorf("light mocks don't record the calls to save to golden files")
+		}
+	}
+	if cfg.CallTypes {
+		switch {
+		case cfg.Kind != KindMock:
+			return cfg, configErrorf("call types are only supported for kind %s", KindMock)
+		case cfg.Light:
+			return cfg, configErrorf("light mocks don't record the calls to declare types of")
+		}
+	}
+	if cfg.CalledBy != "" && cfg.Kind != KindMock && cfg.Kind != KindStub {
+		return cfg, configErrorf("mocking the called methods only is only supported for kinds %s and %s", KindMock, KindStub)
+	}
+	if cfg.LockPerMethod && cfg.Kind != KindMock {
+		return cfg, configErrorf("lock per method is only supported for kind %s", KindMock)
+	}
+	switch cfg.Assert {
+	case "":
+	case AssertCmp:
+		if cfg.Kind != KindMock {
+			return cfg, configErrorf("assert %s is only supported for kind %s", cfg.Assert, KindMock)
+		}
+	default:
+		return cfg, configErrorf("unknown assert: %s", cfg.Assert)
+	}
+	if (cfg.AssertContext || len(cfg.ContextKeys) != 0) && cfg.Kind != KindMock {
+		return cfg, configErrorf("the assertions of contexts are only supported for kind %s", KindMock)
+	}
+	if cfg.HonorContext && cfg.Kind != KindMock {
+		return cfg, configErrorf("honoring contexts is only supported for kind %s", KindMock)
+	}
+	for _, key := range cfg.ContextKeys {
+		if path, name := splitQualified(key); path == "" || !token.IsIdentifier(name) {
+			return cfg, configErrorf("invalid context key %s, expected 'path.Name'", key)
+		}
+	}
+	if _, ok := fileCaseSeparators[cfg.FileCase]; !ok && cfg.FileCase != "" {
+		return cfg, configErrorf("unknown file case: %s", cfg.FileCase)
+	}
+	if strings.Contains(cfg.OutFile+cfg.OutDir, PackagePlaceholder) {
+		rel, err := relDir(cfg.SrcDir)
+		if err != nil {
+			return cfg, configErrorf("can't mirror the source dir in the output: %s", err)
+		}
+		cfg.OutFile = strings.ReplaceAll(cfg.OutFile, PackagePlaceholder, rel)
+		cfg.OutDir = strings.ReplaceAll(cfg.OutDir, PackagePlaceholder, rel)
+	}
+	cfg.OutFile = strings.ReplaceAll(cfg.OutFile, KindPlaceholder, cfg.Kind)
+	if cfg.OutDir != "" {
+		name := outDirFile
+		if cfg.OutFile != "" {
+			if filepath.Base(cfg.OutFile) != cfg.OutFile {
+				return cfg, configErrorf("the output file %s of the output directory isn't a file name", cfg.OutFile)
+			}
+			name = cfg.OutFile
+		}
+		cfg.OutFile = filepath.Join(cfg.OutDir, name)
+		// The mocks written to the source directory are in the source
+		// package, which is the default.
+		if cfg.PkgName == "" && !sameDir(cfg.OutDir, cfg.SrcDir) {
+			cfg.PkgName = dirPkgName(cfg.OutDir, registry.LoadOptions{GOOS: cfg.GOOS, GOARCH: cfg.GOARCH, Env: cfg.Env})
+		}
+	}
+	if len(cfg.Methods) != 0 && cfg.OutFile == "" {
+		return cfg, configErrorf("the methods to regenerate require an output file or directory to merge them into")
+	}
+	for _, method := range cfg.Methods {
+		if !token.IsIdentifier(method) {
+			return cfg, configErrorf("invalid method: %s", method)
+		}
+	}
+	if cfg.ConflictSuffix != "" && !token.IsIdentifier("v"+cfg.ConflictSuffix) {
+		return cfg, configErrorf("invalid conflict suffix: %s", cfg.ConflictSuffix)
+	}
+	if cfg.Receiver == "" {
+		cfg.Receiver = "m"
+	}
+	if cfg.CallsField == "" {
+		cfg.CallsField = "calls"
+	}
+	if cfg.LockField == "" {
+		cfg.LockField = "lock"
+	}
+	for _, ident := range []string{cfg.Receiver, cfg.CallsField, cfg.LockField} {
+		if !token.IsIdentifier(ident) {
+			return cfg, configErrorf("invalid identifier: %s", ident)
+		}
+	}
+	switch cfg.ParamNames {
+	case "", ParamNamesType, ParamNamesPosition:
+	default:
+		return cfg, configErrorf("unknown param names: %s", cfg.ParamNames)
+	}
+	for t, name := range cfg.TypeNames {
+		if !token.IsIdentifier(name) {
+			return cfg, configErrorf("invalid name of type %s: %s", t, name)
+		}
+	}
+	for _, name := range cfg.receiverLocals() {
+		if name == cfg.Receiver {
+			return cfg, configErrorf("receiver %s conflicts with a variable of the generated code", cfg.Receiver)
+		}
+	}
+	if cfg.CallsField == cfg.LockField {
+		return cfg, configErrorf("calls and lock fields are both named %s", cfg.CallsField)
+	}
+	switch cfg.Conflicts {
+	case "", ConflictNumber, ConflictUnderscore, ConflictLetter:
+	default:
+		return cfg, configErrorf("unknown conflicts: %s", cfg.Conflicts)
+	}
+	switch cfg.Qualifiers {
+	case "", QualifierPath, QualifierNumber:
+	default:
+		return cfg, configErrorf("unknown qualifiers: %s", cfg.Qualifiers)
+	}
+	var importPaths []string
+	for path := range cfg.ImportAliases {
+		importPaths = append(importPaths, path)
+	}
+	sort.Strings(importPaths)
+	paths := make(map[string]string, len(importPaths))
+	for _, path := range importPaths {
+		alias := cfg.ImportAliases[path]
+		if !token.IsIdentifier(alias) {
+			return cfg, configErrorf("invalid alias of import %s: %s", path, alias)
+		}
+		if other, ok := paths[alias]; ok {
+			return cfg, configErrorf("alias %s is given to both imports %s and %s", alias, other, path)
+		}
+		paths[alias] = path
+	}
+	if cfg.SrcAlias != "" && !token.IsIdentifier(cfg.SrcAlias) {
+		return cfg, configErrorf("invalid alias of the source package: %s", cfg.SrcAlias)
+	}
+	if cfg.RuntimeAlias != "" && !token.IsIdentifier(cfg.RuntimeAlias) {
+		return cfg, configErrorf("invalid alias of the runtime: %s", cfg.RuntimeAlias)
+	}
+	return cfg, nil
+}
+
+// receiverLocals returns the names of the variables declared by the
+// methods generated for the config, those of its kind and of the helpers
+// of its options, which the receiver would be shadowed by.
+func (cfg Config) receiverLocals() []string {
+	locals := append(append([]string(nil), kindLocals[cfg.Kind]...), stubLocals[cfg.Stub]...)
+	locals = append(locals, assertLocals[cfg.Assert]...)
+	if cfg.AssertContext {
+		locals = append(locals, assertContextLocals...)
+	}
+	if cfg.Golden {
+		locals = append(locals, goldenLocals...)
+	}
+	if cfg.RecordSwitch {
+		locals = append(locals, recordSwitchLocals...)
+	}
+	if cfg.Report {
+		locals = append(locals, reportLocals...)
+	}
+	return locals
+}
+
+func parseInterfaceName(namePair string, defaultName func(string) string) (interfaceName, mockName string) {
+	interfaceName, alias, _ := ParseNamePair(namePair)
+	if alias != "" {
+		return interfaceName, alias
 	}
 
-	interfaceName = parts[0]
-	return interfaceName, interfaceName + "Mock"
+	_, name := splitQualified(interfaceName)
+	return interfaceName, defaultName(name)
+}
+
+// ParseNamePair parses the name pair of an interface, in the format
+// 'interface' or 'interface:alias', into the name of the interface and
+// the alias of its mock, empty if none. The alias follows the last colon
+// and must be an identifier, while the colons followed by a path, such as
+// the drive of a Windows path, are part of the name of the interface.
+func ParseNamePair(namePair string) (name, alias string, err error) {
+	i := strings.LastIndex(namePair, ":")
+	if i < 0 {
+		return namePair, "", nil
+	}
+
+	name, alias = namePair[:i], namePair[i+1:]
+	switch {
+	case token.IsIdentifier(alias) && name != "":
+		return name, alias, nil
+	case strings.ContainsAny(alias, `./\`):
+		return namePair, "", nil
+	case name == "":
+		return namePair, "", configErrorf("missing interface name of alias %s", alias)
+	}
+	return namePair, "", configErrorf("invalid alias of %s: %q", name, alias)
 }
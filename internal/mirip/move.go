@@ -0,0 +1,132 @@
+package mirip
+
+import (
+	"bytes"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"path"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// MovePackage rewrites the generated file for the packages of the import
+// path from, and those nested in it, moved to to: the imports of these
+// packages and the interfaces recorded in the stamp. The imports keep
+// their qualifier, aliased to it when the new path suggests another
+// name, so that the file builds without renaming the references to the
+// package. It returns whether the file changed.
+func MovePackage(content []byte, from, to string) ([]byte, bool, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", content, parser.ParseComments)
+	if err != nil {
+		return nil, false, err
+	}
+
+	changed := false
+	for _, spec := range file.Imports {
+		oldPath, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return nil, false, err
+		}
+		newPath, ok := movedPath(oldPath, from, to)
+		if !ok {
+			continue
+		}
+		if name := assumedName(oldPath); spec.Name == nil && name != assumedName(newPath) {
+			spec.Name = &ast.Ident{NamePos: spec.Path.Pos(), Name: name}
+		}
+		spec.Path.Value = strconv.Quote(newPath)
+		changed = true
+	}
+
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if text := moveStamp(c.Text, from, to); text != c.Text {
+				c.Text = text
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return content, false, nil
+	}
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, false, err
+	}
+	sorted, err := sortImports(buf.Bytes())
+	return sorted, true, err
+}
+
+// sortImports returns the source with its imports sorted by path, like
+// gofmt does for those in consecutive lines.
+func sortImports(src []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "", src, parser.ParseComments)
+	if err != nil {
+		return nil, err
+	}
+	ast.SortImports(fset, file)
+	var buf bytes.Buffer
+	if err := format.Node(&buf, fset, file); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// movedPath returns the path of the package of the import path p once
+// the packages of from are moved to to, if p is one of them.
+func movedPath(p, from, to string) (string, bool) {
+	if p != from && !strings.HasPrefix(p, from+"/") {
+		return "", false
+	}
+	return to + strings.TrimPrefix(p, from), true
+}
+
+// moveStamp returns the line of the stamp with the interfaces of the
+// moved packages, in the format 'path.Interface', given by their new
+// path, both in their source and the arguments of mirip.
+func moveStamp(line, from, to string) string {
+	for _, prefix := range []string{"// mirip:source ", "// mirip:args "} {
+		if !strings.HasPrefix(line, prefix) {
+			continue
+		}
+		words := strings.Split(strings.TrimPrefix(line, prefix), " ")
+		for i, word := range words {
+			if p, name := splitQualified(word); p != "" {
+				if moved, ok := movedPath(p, from, to); ok {
+					words[i] = moved + "." + name
+				}
+			}
+		}
+		return prefix + strings.Join(words, " ")
+	}
+	return line
+}
+
+// assumedName returns the name which the package of the import path is
+// assumed to have, like goimports: the last element of the path, but the
+// major version suffix, without a go- prefix and the characters following
+// an invalid one, ex: yaml for gopkg.in/yaml.v3.
+func assumedName(p string) string {
+	base := path.Base(p)
+	if strings.HasPrefix(base, "v") {
+		if _, err := strconv.Atoi(base[1:]); err == nil && path.Dir(p) != "." {
+			base = path.Base(path.Dir(p))
+		}
+	}
+	base = strings.TrimPrefix(base, "go-")
+	if i := strings.IndexFunc(base, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '_'
+	}); i >= 0 {
+		base = base[:i]
+	}
+	return base
+}
@@ -0,0 +1,193 @@
+package mirip
+
+import (
+	"fmt"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+)
+
+// InterfacePlaceholder is replaced by the name of the interface in the
+// name of an output file, which makes for one file per interface.
+const InterfacePlaceholder = "{interface}"
+
+// FilePlaceholder is replaced by the name of the source file declaring
+// the interface, without its extension, in the name of an output file,
+// which makes for one file per source file, such as {file}_mock.go.
+const FilePlaceholder = "{file}"
+
+// PackagePlaceholder is replaced by the source dir, relative to the
+// current directory, in the output file and directory, which mirrors the
+// tree of the source packages, such as internal/mocks/{pkg}.
+const PackagePlaceholder = "{pkg}"
+
+// KindPlaceholder is replaced by the kind of the mocks in the name of an
+// output file, which makes for one file per kind, such as {kind}s.go.
+const KindPlaceholder = "{kind}"
+
+// outDirFile is the name of the output files of the interfaces in the
+// output directory.
+const outDirFile = InterfacePlaceholder + "_mock.go"
+
+// Cases of the interface names in the names of output files.
+const (
+	FileCaseSnake = "snake"
+	FileCaseKebab = "kebab"
+	FileCaseLower = "lower"
+)
+
+// fileCaseSeparators are the separators between the words of interface
+// names in file names, keyed by file case.
+var fileCaseSeparators = map[string]string{
+	FileCaseSnake: "_",
+	FileCaseKebab: "-",
+	FileCaseLower: "",
+}
+
+// IsOutFilePattern returns whether the output file name is a pattern for
+// one file per interface or per source file.
+func IsOutFilePattern(name string) bool {
+	return strings.Contains(name, InterfacePlaceholder) || strings.Contains(name, FilePlaceholder)
+}
+
+// OutFile returns the name of the output file of the given interface,
+// in the format 'interface' or 'interface:alias'. It is the output file
+// of the config, with the interface name in its file case replacing the
+// interface placeholder, if any, and the source file declaring it the
+// file placeholder. The interfaces of other packages than the source
+// package, or not found, are in the file named after them instead.
+func (m Mocker) OutFile(namePair string) string {
+	name, _ := parseInterfaceName(namePair, m.mockName)
+	// The mocks of interface sets are named after their first interface.
+	path, name := splitQualified(parseInterfaceSet(name)[0].name)
+	outFile := strings.ReplaceAll(m.cfg.OutFile, InterfacePlaceholder, fileName(name, m.cfg.FileCase))
+	if !strings.Contains(outFile, FilePlaceholder) {
+		return outFile
+	}
+
+	srcFile := fileName(name, m.cfg.FileCase)
+	if file, ok := m.registry.DeclFile(name); ok && path == "" {
+		srcFile = strings.TrimSuffix(filepath.Base(file), ".go")
+	}
+	return strings.ReplaceAll(outFile, FilePlaceholder, srcFile)
+}
+
+// IsPattern reports whether the source dir is a package pattern, such as
+// ./..., matching the directories of several packages.
+func IsPattern(srcDir string) bool {
+	return strings.Contains(srcDir, "...")
+}
+
+// PatternDirs returns the source dirs of the packages matched by the
+// package pattern, relative to the current directory when under it. The
+// GOOS and GOARCH of the config select the packages matched.
+func PatternDirs(pattern string, cfg Config) ([]string, error) {
+	dirs, err := registry.PatternDirs(pattern, registry.LoadOptions{GOOS: cfg.GOOS, GOARCH: cfg.GOARCH, Overlay: cfg.Overlay, Env: cfg.Env})
+	if err != nil {
+		return nil, err
+	}
+
+	for i, dir := range dirs {
+		if rel, err := relDir(dir); err == nil {
+			dirs[i] = "." + string(filepath.Separator) + rel
+		}
+	}
+	return dirs, nil
+}
+
+// relDir returns dir relative to the current directory, or an error if it
+// is outside of it.
+func relDir(dir string) (string, error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+	wd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	rel, err := filepath.Rel(wd, abs)
+	if err != nil {
+		return "", err
+	}
+	if rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of the current directory", dir)
+	}
+	return rel, nil
+}
+
+// dirPkgName returns the name of the package of the mocks written to dir:
+// the name of the package it holds, or else its base name with the
+// characters which can't be in a package name left out, such as mocks for
+// mocks/ or mockstore for mock-store/.
+func dirPkgName(dir string, opts registry.LoadOptions) string {
+	if name, ok := registry.DirPkgName(dir, opts); ok {
+		return name
+	}
+
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		abs = dir
+	}
+	name := strings.Map(func(r rune) rune {
+		if r == '_' || unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, filepath.Base(abs))
+	if !token.IsIdentifier(name) {
+		return "mocks"
+	}
+	return name
+}
+
+// isEmptyDir reports whether dir is a directory holding no Go files.
+func isEmptyDir(dir string) bool {
+	if info, err := os.Stat(dir); err != nil || !info.IsDir() {
+		return false
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	return len(matches) == 0
+}
+
+// sameDir reports whether both paths are of the same directory.
+func sameDir(a, b string) bool {
+	absA, errA := filepath.Abs(a)
+	absB, errB := filepath.Abs(b)
+	return errA == nil && errB == nil && absA == absB
+}
+
+// fileName returns the name in the given case, splitting it into words
+// at the case changes. Acronyms are kept as one word, so that HTTPClient
+// is http_client in snake case.
+func fileName(name, fileCase string) string {
+	sep, ok := fileCaseSeparators[fileCase]
+	if !ok {
+		sep = fileCaseSeparators[FileCaseSnake]
+	}
+
+	runes := []rune(name)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || (unicode.IsUpper(prev) && nextLower) {
+				b.WriteString(sep)
+			}
+		}
+		b.WriteRune(unicode.ToLower(r))
+	}
+	return b.String()
+}
+
+// VerifyBuild type-checks the packages of the given generated files, keyed
+// by path, with the files overlaid on the disk. It returns a
+// *registry.BuildError with the compiler errors in the files, if any.
+func VerifyBuild(files map[string][]byte, cfg Config) error {
+	return registry.TypeCheck(files, registry.LoadOptions{GOOS: cfg.GOOS, GOARCH: cfg.GOARCH, Overlay: cfg.Overlay, Env: cfg.Env})
+}
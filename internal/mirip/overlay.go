@@ -0,0 +1,39 @@
+package mirip
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// ReadOverlay reads an overlay in the JSON format of go build -overlay,
+// for Config.Overlay: the files keyed by path in its Replace field have
+// the content of the files they map to. Relative paths are relative to
+// the current directory, and deleting files isn't supported.
+func ReadOverlay(r io.Reader) (map[string][]byte, error) {
+	var overlay struct {
+		Replace map[string]string
+	}
+	if err := json.NewDecoder(r).Decode(&overlay); err != nil {
+		return nil, fmt.Errorf("invalid overlay: %w", err)
+	}
+
+	files := make(map[string][]byte, len(overlay.Replace))
+	for name, replacement := range overlay.Replace {
+		if replacement == "" {
+			return nil, fmt.Errorf("invalid overlay: deleting %s isn't supported", name)
+		}
+		abs, err := filepath.Abs(name)
+		if err != nil {
+			return nil, err
+		}
+		content, err := os.ReadFile(replacement)
+		if err != nil {
+			return nil, err
+		}
+		files[abs] = content
+	}
+	return files, nil
+}
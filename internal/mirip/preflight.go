@@ -0,0 +1,150 @@
+package mirip
+
+import (
+	"errors"
+	"fmt"
+	"go/types"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// limitation is a construct of an interface which mirip can't mock, or
+// which its mock degrades on if degraded is set.
+type limitation struct {
+	iface    string
+	msg      string
+	degraded bool
+}
+
+// preflight reports the limitations of the interfaces before their mocks
+// are generated, for users to learn about them all at once rather than
+// from the compiler. It fails on the constructs which can't be mocked,
+// and warns about those the mocks degrade on, or fails on them too if
+// Strict is set.
+func (m Mocker) preflight(limits []limitation) error {
+	var msgs []string
+	for _, l := range limits {
+		if l.degraded && !m.cfg.Strict {
+			m.cfg.warn(l.iface, "%s", l.msg)
+			continue
+		}
+		msgs = append(msgs, l.msg)
+	}
+	if len(msgs) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(msgs, "\n"))
+}
+
+// checkTypes returns the constructs of the interface of the given name in
+// pkg which mocks can't implement: C types, the unexported types of other
+// packages than the output package, and the type sets which no mock is
+// in, or which the generated code would refer to outside of type
+// constraints.
+func (m Mocker) checkTypes(pkg *types.Package, name string, iface *types.Interface) []limitation {
+	var limits []limitation
+	unsupported := func(format string, args ...interface{}) {
+		limits = append(limits, limitation{iface: name, msg: fmt.Sprintf(format, args...)})
+	}
+
+	if cType, ok := registry.CgoType(iface); ok {
+		unsupported("interface %s refers to the cgo type %s, which mocks can't refer to, wrap it in a Go type to mock the interface",
+			name, cType)
+	}
+
+	if _, path, ok := m.registry.UnexportedType(pkg.Scope().Lookup(name).Type()); ok {
+		unsupported("interface %s is unexported, which mocks can't refer to outside of package %s, export it or generate the mock in that package",
+			name, path)
+	}
+	for i := 0; i < iface.NumMethods(); i++ {
+		method := iface.Method(i)
+		if typ, path, ok := m.registry.UnexportedType(method.Type()); ok {
+			unsupported("method %s of interface %s refers to the unexported type %s, which mocks can't refer to outside of package %s, export it or generate the mock in that package",
+				method.Name(), name, typ, path)
+		}
+	}
+
+	switch {
+	case iface.IsMethodSet():
+	case hasTypeTerms(iface):
+		unsupported("interface %s has type terms, such as ~int, which no mock satisfies", name)
+	case isGenericInterface(pkg, name):
+		unsupported("interface %s is generic and has a type set, which mocks don't support", name)
+	case m.cfg.Provider != "":
+		unsupported("interface %s has a type set, which only constrains type parameters, while providers return it", name)
+	case m.refersToInterface():
+		unsupported("interface %s has a type set, which only constrains type parameters, while kind %s refers to it as a type",
+			name, m.cfg.Kind)
+	}
+	return limits
+}
+
+// hasTypeTerms returns whether the interface, or one it embeds, has type
+// terms, such as ~int or string, rather than only methods and comparable.
+func hasTypeTerms(iface *types.Interface) bool {
+	for i := 0; i < iface.NumEmbeddeds(); i++ {
+		embedded, ok := iface.EmbeddedType(i).Underlying().(*types.Interface)
+		if !ok || hasTypeTerms(embedded) {
+			return true
+		}
+	}
+	return false
+}
+
+// degradations returns the constructs of the interface of the mock which
+// it degrades on: the type sets it isn't asserted to be in, the foreign
+// methods delegated to the embedded interface, and the values which kind
+// recorder, golden calls, the fixtures of stubs and the fuzz stub can't
+// encode.
+func (m Mocker) degradations(mock template.MockData, foreign []string) []limitation {
+	var limits []limitation
+	degraded := func(format string, args ...interface{}) {
+		limits = append(limits, limitation{iface: mock.InterfaceName, msg: fmt.Sprintf(format, args...), degraded: true})
+	}
+
+	if mock.TypeSet {
+		degraded("interface %s has a type set, so %s isn't asserted to implement it, and only satisfies it as a type argument",
+			mock.InterfaceName, mock.MockName)
+	}
+	for _, name := range foreign {
+		degraded("unexported method %s of interface %s of another package isn't mocked, as mocks can't declare it, but delegated to the embedded %s",
+			name, mock.InterfaceName, mock.InterfaceName)
+	}
+
+	recorder, fixture := m.cfg.Kind == KindRecorder, m.cfg.Kind == KindMock && m.cfg.Stub == StubFixture
+	fuzz := m.cfg.Kind == KindMock && m.cfg.Stub == StubFuzz
+	golden := m.cfg.Kind == KindMock && m.cfg.Golden
+	if !recorder && !fixture && !fuzz && !golden {
+		return limits
+	}
+	for _, method := range mock.Methods {
+		for _, p := range method.Params {
+			switch {
+			case p.Var.IsContext() || p.Var.Encodable():
+			case recorder:
+				degraded("argument %s of method %s of interface %s, of type %s, isn't recorded, as it can't be encoded",
+					p.Name(), method.Name, mock.InterfaceName, p.TypeString())
+			case golden:
+				degraded("argument %s of method %s of interface %s, of type %s, isn't saved to golden files, as it can't be encoded",
+					p.Name(), method.Name, mock.InterfaceName, p.TypeString())
+			}
+		}
+		for i, r := range method.Returns {
+			switch {
+			case r.TypeString() == "error" || r.Var.Encodable(), fuzz && r.Var.IsTypeParam():
+			case recorder:
+				degraded("result %d of method %s of interface %s, of type %s, isn't recorded, as it can't be encoded, replayers return its zero value",
+					i+1, method.Name, mock.InterfaceName, r.TypeString())
+			case fixture:
+				degraded("result %d of method %s of interface %s, of type %s, can't be decoded from fixtures, which leave it zero",
+					i+1, method.Name, mock.InterfaceName, r.TypeString())
+			case fuzz:
+				degraded("result %d of method %s of interface %s, of type %s, can't be decoded from the input of fuzz targets, and is left zero",
+					i+1, method.Name, mock.InterfaceName, r.TypeString())
+			}
+		}
+	}
+	return limits
+}
@@ -0,0 +1,231 @@
+package mirip
+
+import (
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// receiverTargets are the interfaces of the corpus mocked with every
+// receiver, taking contexts, returning channels and errors, and generic.
+// Those an option set doesn't support are skipped.
+var receiverTargets = []string{"Fetcher", "Catalog", "Inventory", "Ledger", "Collisions", "Queue", "Mapper"}
+
+// receiverOptions are the option sets the mocks are generated with for
+// each receiver, along with the interfaces they mock if not
+// receiverTargets. The providers and the flavors are left out, their
+// helpers declaring no receiver.
+var receiverOptions = []struct {
+	name    string
+	set     func(cfg *Config)
+	targets []string
+}{
+	{name: "default", set: func(cfg *Config) {}},
+	{name: "light", set: func(cfg *Config) { cfg.Light = true }},
+	{name: "toggle-recording", set: func(cfg *Config) { cfg.RecordSwitch = true; cfg.LockPerMethod = true }},
+	{name: "stub-zero", set: func(cfg *Config) { cfg.Stub = StubZero; cfg.Clock = true }},
+	{name: "stub-random", set: func(cfg *Config) { cfg.Stub = StubRandom }},
+	{name: "stub-fixture", set: func(cfg *Config) { cfg.Stub = StubFixture }},
+	{name: "stub-fuzz", set: func(cfg *Config) { cfg.Stub = StubFuzz; cfg.LockPerMethod = true }},
+	{name: "chaos", set: func(cfg *Config) { cfg.Kind = KindChaos }},
+	{name: "recorder", set: func(cfg *Config) { cfg.Kind = KindRecorder }},
+	{name: "stub", set: func(cfg *Config) { cfg.Kind = KindStub; cfg.NotImplemented = true }},
+	{name: "fake-builtin", set: func(cfg *Config) { cfg.Kind = KindFakeBuiltin }, targets: []string{"io.Reader", "net/http.RoundTripper", "io/fs.FS"}},
+	{name: "assert", set: func(cfg *Config) { cfg.Assert = AssertCmp; cfg.CallTypes = true }},
+	{name: "assert-context", set: func(cfg *Config) { cfg.AssertContext = true; cfg.HonorContext = true }},
+	{name: "builder", set: func(cfg *Config) { cfg.Builder = true; cfg.VerifyAll = true }},
+	{name: "golden", set: func(cfg *Config) { cfg.Golden = true }},
+	{name: "report", set: func(cfg *Config) { cfg.Report = true; cfg.Stub = StubZero; cfg.NotImplemented = true }},
+	{name: "shared", set: func(cfg *Config) { cfg.Shared = true; cfg.Registry = true }},
+	{name: "fluent", set: func(cfg *Config) { cfg.Fluent = true; cfg.Coverage = true }},
+}
+
+// receiverImports are the packages of the corpus and those the mocks
+// generated with the option sets may import, but go-cmp.
+var receiverImports = []string{
+	".",
+	"bytes",
+	"encoding/json",
+	"errors",
+	"flag",
+	"fmt",
+	"io",
+	"io/fs",
+	"math",
+	"math/rand",
+	"net/http",
+	"os",
+	"path/filepath",
+	"reflect",
+	"strconv",
+	"strings",
+	"sync",
+	"sync/atomic",
+	"testing",
+	"testing/fstest",
+	"testing/quick",
+	"time",
+}
+
+// cmpStub declares the API of go-cmp the assertions use, which the module
+// doesn't require.
+const cmpStub = `package cmp
+
+type Option interface{}
+
+func Equal(x, y interface{}, opts ...Option) bool { return false }
+
+func Diff(x, y interface{}, opts ...Option) string { return "" }
+`
+
+// TestReceiverNames generates the mocks of the corpus with each option set
+// and, as the receiver, each variable declared by the methods of the mocks
+// generated with it, along with each name reserved for any option. Either
+// the receiver is rejected, or the mocks must type-check.
+func TestReceiverNames(t *testing.T) {
+	if testing.Short() {
+		t.Skip("generates the corpus with every receiver")
+	}
+	srcDir, err := filepath.Abs(filepath.Join("..", "..", "generate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	imports := make(map[string]*types.Package)
+	// The runtime is a module of its own, loaded from its directory.
+	for dir, patterns := range map[string][]string{srcDir: receiverImports, filepath.Join(srcDir, "..", "runtime"): {"."}} {
+		pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedTypes | packages.NeedImports | packages.NeedDeps, Dir: dir}, patterns...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		packages.Visit(pkgs, nil, func(pkg *packages.Package) {
+			imports[pkg.PkgPath] = pkg.Types
+		})
+	}
+	fset := token.NewFileSet()
+	cmp, err := parser.ParseFile(fset, "cmp.go", cmpStub, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if imports["github.com/google/go-cmp/cmp"], err = new(types.Config).Check("github.com/google/go-cmp/cmp", fset, []*ast.File{cmp}, nil); err != nil {
+		t.Fatal(err)
+	}
+	check := func(name string, src []byte) (*types.Info, error) {
+		file, err := parser.ParseFile(fset, name, src, 0)
+		if err != nil {
+			return nil, err
+		}
+		info := &types.Info{Defs: make(map[*ast.Ident]types.Object)}
+		conf := types.Config{Importer: importerFunc(func(path string) (*types.Package, error) {
+			if pkg, ok := imports[path]; ok {
+				return pkg, nil
+			}
+			return nil, fmt.Errorf("%s isn't among the imports loaded", path)
+		})}
+		_, err = conf.Check("receivermock", fset, []*ast.File{file}, info)
+		return info, err
+	}
+
+	// Every name reserved for an option is tried with every option set.
+	var reserved []string
+	for kind := range kindLocals {
+		for stub := range stubLocals {
+			cfg := Config{Kind: kind, Stub: stub, Assert: AssertCmp, AssertContext: true, Golden: true, RecordSwitch: true, Report: true}
+			reserved = append(reserved, cfg.receiverLocals()...)
+		}
+	}
+
+	for _, opts := range receiverOptions {
+		opts := opts
+		t.Run(opts.name, func(t *testing.T) {
+			newConfig := func(receiver string) Config {
+				cfg := Config{
+					SrcDir:   srcDir,
+					PkgName:  "receivermock",
+					OutFile:  filepath.Join(srcDir, "receivermock", "receiver_mock.go"),
+					Receiver: receiver,
+					Prefix:   "Receiver",
+				}
+				opts.set(&cfg)
+				return cfg
+			}
+			mockers, err := NewAll(newConfig("m"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			candidates := opts.targets
+			if candidates == nil {
+				candidates = receiverTargets
+			}
+			var targets []string
+			for _, target := range candidates {
+				if err := mockers[0].Mock(io.Discard, target); err != nil {
+					t.Logf("skipping %s: %v", target, err)
+					continue
+				}
+				targets = append(targets, target)
+			}
+			var buf bytes.Buffer
+			if err := mockers[0].Mock(&buf, targets...); err != nil {
+				t.Fatal(err)
+			}
+			info, err := check("m", buf.Bytes())
+			if err != nil {
+				t.Fatalf("mocks don't type-check with the receiver m: %v", err)
+			}
+
+			names := map[string]bool{}
+			for _, name := range reserved {
+				names[name] = true
+			}
+			for id, obj := range info.Defs {
+				if v, ok := obj.(*types.Var); ok && !v.IsField() && v.Parent() != nil && v.Parent() != v.Pkg().Scope() {
+					names[id.Name] = true
+				}
+			}
+			delete(names, "_")
+
+			var receivers []string
+			var cfgs []Config
+			for name := range names {
+				cfg := newConfig(name)
+				if _, err := cfg.validate(); err != nil {
+					if !strings.Contains(err.Error(), "receiver "+name+" ") {
+						t.Errorf("receiver %s: %v", name, err)
+					}
+					continue
+				}
+				receivers, cfgs = append(receivers, name), append(cfgs, cfg)
+			}
+			mockers, err = NewAll(cfgs...)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for i, m := range mockers {
+				var buf bytes.Buffer
+				if err := m.Mock(&buf, targets...); err != nil {
+					t.Errorf("receiver %s: %v", receivers[i], err)
+					continue
+				}
+				if _, err := check(receivers[i], buf.Bytes()); err != nil {
+					t.Errorf("mocks don't type-check with the receiver %s, which isn't rejected: %v", receivers[i], err)
+				}
+			}
+		})
+	}
+}
+
+// importerFunc is a types.Importer of a func.
+type importerFunc func(path string) (*types.Package, error)
+
+func (f importerFunc) Import(path string) (*types.Package, error) {
+	return f(path)
+}
@@ -0,0 +1,64 @@
+package mirip
+
+import (
+	"errors"
+	"go/types"
+	"strings"
+)
+
+// Snapshot returns the method sets of the given interfaces, in the format
+// 'Interface' or 'path.Interface' like Mock, keyed by the path of their
+// package and their name, such as 'example.com/store.Store', for checking
+// that their API didn't change incompatibly. Each method is mapped to its
+// signature without the parameter names, its types qualified by the path
+// of their package, ex: '(context.Context, string) (example.com/store.User, error)',
+// so that renaming parameters or moving methods to an embedded interface
+// leaves the snapshot as it is.
+func (m Mocker) Snapshot(names ...string) (map[string]map[string]string, error) {
+	if len(names) == 0 {
+		return nil, errors.New("must specify one interface")
+	}
+
+	snapshot := make(map[string]map[string]string, len(names))
+	for _, name := range names {
+		pkg, ifaceName, iface, err := m.lookupInterface(name)
+		if err != nil {
+			return nil, err
+		}
+
+		methods := make(map[string]string, iface.NumMethods())
+		for i := 0; i < iface.NumMethods(); i++ {
+			method := iface.Method(i)
+			methods[method.Name()] = signatureTypes(method.Type().(*types.Signature))
+		}
+		snapshot[pkg.Path()+"."+ifaceName] = methods
+	}
+	return snapshot, nil
+}
+
+// signatureTypes returns the types of the parameters and results of the
+// signature, qualified by the path of their package.
+func signatureTypes(sig *types.Signature) string {
+	tupleTypes := func(tuple *types.Tuple, variadic bool) []string {
+		list := make([]string, tuple.Len())
+		for i := range list {
+			t := tuple.At(i).Type()
+			if s, ok := t.(*types.Slice); ok && variadic && i == len(list)-1 {
+				list[i] = "..." + types.TypeString(s.Elem(), nil)
+				continue
+			}
+			list[i] = types.TypeString(t, nil)
+		}
+		return list
+	}
+
+	params := "(" + strings.Join(tupleTypes(sig.Params(), sig.Variadic()), ", ") + ")"
+	switch results := tupleTypes(sig.Results(), false); len(results) {
+	case 0:
+		return params
+	case 1:
+		return params + " " + results[0]
+	default:
+		return params + " (" + strings.Join(results, ", ") + ")"
+	}
+}
@@ -0,0 +1,99 @@
+package mirip
+
+import (
+	"bytes"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// Stamp is the provenance of the generated code recorded in the header of
+// the mocks: the version and the arguments of mirip which generated them,
+// from which interfaces, along with the absolute path of the source
+// directory and the time of the generation unless Reproducible is set.
+// MinVersion, if set, is the earliest version of mirip generating the
+// same code, for the mocks of other versions to be told apart.
+type Stamp struct {
+	Version      string
+	MinVersion   string
+	Args         []string
+	Reproducible bool
+}
+
+// stampPrefixes are the prefixes of the lines of the stamps which differ
+// between runs, machines or the versions of mirip generating the same
+// code.
+var stampPrefixes = []string{"// mirip:version ", "// mirip:dir ", "// mirip:time "}
+
+// stamp returns the lines of the stamp of the mocks of the given
+// interfaces, in the format 'path.Interface', without the comment marker.
+func (m Mocker) stamp(sources []string) []string {
+	s := m.cfg.Stamp
+	if s == nil {
+		return nil
+	}
+
+	lines := []string{"mirip:version " + s.Version}
+	if s.MinVersion != "" {
+		lines = append(lines, "mirip:min-version "+s.MinVersion)
+	}
+	for _, src := range sources {
+		lines = append(lines, "mirip:source "+src)
+	}
+	lines = append(lines, "mirip:args "+strings.Join(s.Args, " "))
+	if s.Reproducible {
+		return lines
+	}
+
+	if dir, err := filepath.Abs(m.cfg.SrcDir); err == nil {
+		lines = append(lines, "mirip:dir "+filepath.ToSlash(dir))
+	}
+	return append(lines, "mirip:time "+time.Now().UTC().Format(time.RFC3339))
+}
+
+// Unstamped returns the content of a generated file without the lines of
+// its stamp which differ between runs, the version, the absolute path and
+// the time, so that files generated from the same code compare equal.
+// The minimum version is kept, which differs along with the code.
+func Unstamped(content []byte) []byte {
+	var out []byte
+	header := true
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if header && bytes.HasPrefix(line, []byte("package ")) {
+			header = false
+		}
+		if header && hasStampPrefix(line) {
+			continue
+		}
+		out = append(out, line...)
+	}
+	return out
+}
+
+// StampVersions returns the version of mirip recorded in the stamp of the
+// generated file, along with the earliest version generating the same
+// code, empty if they aren't recorded.
+func StampVersions(content []byte) (version, minVersion string) {
+	for _, line := range strings.Split(string(content), "\n") {
+		if strings.HasPrefix(line, "package ") {
+			break
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(line, "// mirip:version "):
+			version = strings.TrimPrefix(line, "// mirip:version ")
+		case strings.HasPrefix(line, "// mirip:min-version "):
+			minVersion = strings.TrimPrefix(line, "// mirip:min-version ")
+		}
+	}
+	return version, minVersion
+}
+
+func hasStampPrefix(line []byte) bool {
+	for _, prefix := range stampPrefixes {
+		if bytes.HasPrefix(line, []byte(prefix)) {
+			return true
+		}
+	}
+	return false
+}
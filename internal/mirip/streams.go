@@ -0,0 +1,81 @@
+package mirip
+
+import "go/types"
+
+// Streams returns the interfaces of the gRPC streams used by the methods
+// of the given interfaces, such as the Svc_WatchClient returned by the
+// streaming methods of the clients generated by protoc-gen-go-grpc, to be
+// mocked along with them. The names are qualified with the path of their
+// package unless it is the source package, while the instances of generic
+// streams, such as grpc.ServerStreamingClient[T], give their generic type.
+// The interfaces already among the given ones are left out.
+func (m Mocker) Streams(namePairs ...string) ([]string, error) {
+	seen := make(map[string]bool)
+	for _, np := range namePairs {
+		name, _ := parseInterfaceName(np, m.mockName)
+		seen[name] = true
+	}
+
+	var streams []string
+	for _, np := range namePairs {
+		name, _ := parseInterfaceName(np, m.mockName)
+		_, _, iface, err := m.lookupInterface(name)
+		if err != nil {
+			return nil, err
+		}
+
+		for i := 0; i < iface.NumMethods(); i++ {
+			sig := iface.Method(i).Type().(*types.Signature)
+			for _, vars := range []*types.Tuple{sig.Params(), sig.Results()} {
+				for j := 0; j < vars.Len(); j++ {
+					stream, ok := m.streamName(vars.At(j).Type())
+					if ok && !seen[stream] {
+						seen[stream] = true
+						streams = append(streams, stream)
+					}
+				}
+			}
+		}
+	}
+	return streams, nil
+}
+
+// streamName returns the name of the interface of t, as given to mirip,
+// if it is a named gRPC stream, which has the SendMsg and RecvMsg methods
+// of grpc.ClientStream and grpc.ServerStream.
+func (m Mocker) streamName(t types.Type) (string, bool) {
+	iface, ok := t.Underlying().(*types.Interface)
+	if !ok || !hasMethods(iface, "SendMsg", "RecvMsg") {
+		return "", false
+	}
+
+	var obj *types.TypeName
+	switch t := t.(type) {
+	case *types.Named:
+		obj = t.Origin().Obj()
+	case interface{ Obj() *types.TypeName }: // type alias
+		obj = t.Obj()
+	}
+	if obj == nil || obj.Pkg() == nil || !obj.Exported() {
+		return "", false
+	}
+
+	if obj.Pkg() == m.registry.SrcPkg() {
+		return obj.Name(), true
+	}
+	return obj.Pkg().Path() + "." + obj.Name(), true
+}
+
+// hasMethods returns whether the interface has all the given methods.
+func hasMethods(iface *types.Interface, names ...string) bool {
+	for _, name := range names {
+		found := false
+		for i := 0; i < iface.NumMethods(); i++ {
+			found = found || iface.Method(i).Name() == name
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
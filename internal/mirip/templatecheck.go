@@ -0,0 +1,89 @@
+package mirip
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/gmhafiz/mirip/internal/registry"
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// syntheticSource is the package of the interface the templates are
+// checked with, with methods of all the kinds of signatures mocks have.
+const syntheticSource = `package synthetic
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+type User struct {
+	ID   string
+	Name string
+}
+
+type Synthetic interface {
+	Get(ctx context.Context, id string) (User, error)
+	List(ctx context.Context, ids ...string) ([]User, error)
+	Put(*User) error
+	Ping()
+	Since(t time.Time) time.Duration
+	Open(name string) (io.ReadCloser, error)
+	Each(fn func(User) bool)
+	Watch() <-chan User
+	Index() map[string]User
+	Pair(a, b int) (sum int, ok bool)
+	Tag(struct{ Name string }) interface{ Close() error }
+}
+`
+
+// CheckTemplate checks the text of a template for Config.Template, named
+// name in the errors, along with the other settings of cfg but SrcDir:
+// the fields and methods it refers to are checked against template.Data,
+// then the code it generates
+// for a synthetic interface, with methods of all the kinds of signatures,
+// must format and build. The errors of the fields and methods are a
+// *template.CheckError and those of the build a *registry.BuildError.
+func CheckTemplate(name, text string, cfg Config) error {
+	if err := template.Check(name, text); err != nil {
+		return err
+	}
+
+	dir, err := os.MkdirTemp("", "mirip-template")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(dir)
+
+	files := map[string]string{
+		"go.mod":       "module synthetic\n\ngo 1.18\n",
+		"synthetic.go": syntheticSource,
+	}
+	for name, content := range files {
+		if err := os.WriteFile(filepath.Join(dir, name), []byte(content), 0600); err != nil {
+			return err
+		}
+	}
+
+	cfg.SrcDir, cfg.OutFile, cfg.OutDir, cfg.PkgName = dir, "", "", ""
+	cfg.Template = text
+	m, err := New(cfg)
+	if err != nil {
+		return err
+	}
+	var buf bytes.Buffer
+	if err := m.Mock(&buf, "Synthetic"); err != nil {
+		return err
+	}
+	err = VerifyBuild(map[string][]byte{filepath.Join(dir, "synthetic_mock.go"): buf.Bytes()}, cfg)
+	// The temporary directory is of no use to the reader of the errors.
+	if buildErr, ok := err.(*registry.BuildError); ok {
+		for i, e := range buildErr.Errors {
+			buildErr.Errors[i] = strings.TrimPrefix(e, dir+string(filepath.Separator))
+		}
+	}
+	return err
+}
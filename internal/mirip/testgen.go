@@ -0,0 +1,111 @@
+package mirip
+
+import (
+	"bytes"
+	"go/types"
+	"io"
+	"strconv"
+
+	"github.com/gmhafiz/mirip/internal/template"
+)
+
+// TestSkeleton generates a table-driven test skeleton for the given
+// function of the source package. Parameters of the function with an
+// interface type are expected to be mocked in the package with their
+// default mock name.
+func (m Mocker) TestSkeleton(out io.Writer, funcName string) error {
+	sig, err := m.registry.LookupFunc(funcName)
+	if err != nil {
+		return err
+	}
+
+	m.registry.AddImport(types.NewPackage("testing", "testing"))
+
+	// The method data of the mocks is gathered first so that their imports
+	// are known before allocating the names of the function parameters.
+	mocks := make(map[int]*template.MockData)
+	for i := 0; i < sig.Params().Len(); i++ {
+		named, ok := mockableInterface(sig.Params().At(i).Type())
+		if !ok {
+			continue
+		}
+
+		iface := named.Underlying().(*types.Interface).Complete()
+		methods := make([]template.MethodData, iface.NumMethods())
+		for j := 0; j < iface.NumMethods(); j++ {
+			methods[j] = m.methodData(iface.Method(j))
+		}
+		mock := []template.MockData{{
+			InterfaceName: named.Obj().Name(),
+			MockName:      m.mockName(named.Obj().Name()),
+			Methods:       methods,
+		}}
+		m.nameHelpers(mock)
+		mocks[i] = &mock[0]
+	}
+
+	n := sig.Params().Len()
+	vrs := make([]*types.Var, n)
+	for i := range vrs {
+		vrs[i] = sig.Params().At(i)
+	}
+	vars := m.registry.MethodScope().AddVars(vrs, make([]string, n))
+	params := make([]template.TestParamData, n)
+	for i := 0; i < n; i++ {
+		p := template.ParamData{
+			Var: vars[i],
+		}
+		p.Variadic = sig.Variadic() && i == n-1 && p.Var.IsSlice()
+
+		params[i] = template.TestParamData{ParamData: p, Mock: mocks[i]}
+	}
+
+	data := template.TestData{
+		PkgName:  m.mockPkgName(),
+		FuncName: funcName,
+		Params:   params,
+		Results:  resultNames(sig.Results()),
+	}
+	data.ReturnsError = len(data.Results) > 0 && data.Results[len(data.Results)-1] == "err"
+	data.Imports = m.registry.Imports()
+
+	var buf bytes.Buffer
+	if err := m.tmpl.ExecuteTest(&buf, data); err != nil {
+		return err
+	}
+
+	return m.write(out, buf.Bytes())
+}
+
+// mockableInterface returns the named interface type of a parameter
+// which should be mocked in a test. Errors and contexts are left for the
+// test cases to supply.
+func mockableInterface(t types.Type) (*types.Named, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || !types.IsInterface(named) || named.Obj().Pkg() == nil {
+		return nil, false
+	}
+
+	if named.Obj().Pkg().Path() == "context" {
+		return nil, false
+	}
+
+	return named, true
+}
+
+// resultNames names the results of a tested function, ex: 'got', 'got,
+// got1, err'.
+func resultNames(results *types.Tuple) []string {
+	names := make([]string, results.Len())
+	for i := range names {
+		switch {
+		case i == len(names)-1 && types.Identical(results.At(i).Type(), types.Universe.Lookup("error").Type()):
+			names[i] = "err"
+		case i == 0:
+			names[i] = "got"
+		default:
+			names[i] = "got" + strconv.Itoa(i)
+		}
+	}
+	return names
+}
@@ -0,0 +1,57 @@
+package mirip
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+// Timings accumulates the time spent in each phase of generation: loading
+// the source packages, resolving the interfaces, rendering the templates
+// and formatting the generated source. It is safe for concurrent use.
+type Timings struct {
+	Load    time.Duration
+	Resolve time.Duration
+	Render  time.Duration
+	Format  time.Duration
+
+	mu sync.Mutex
+}
+
+// phase is a phase of generation.
+type phase int
+
+const (
+	phaseLoad phase = iota
+	phaseResolve
+	phaseRender
+	phaseFormat
+)
+
+// since adds the time elapsed since start to the given phase. It is a
+// no-op on nil Timings.
+func (t *Timings) since(p phase, start time.Time) {
+	if t == nil {
+		return
+	}
+
+	d := time.Since(start)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	switch p {
+	case phaseLoad:
+		t.Load += d
+	case phaseResolve:
+		t.Resolve += d
+	case phaseRender:
+		t.Render += d
+	case phaseFormat:
+		t.Format += d
+	}
+}
+
+func (t *Timings) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return fmt.Sprintf("load %s, resolve %s, render %s, format %s", t.Load, t.Resolve, t.Render, t.Format)
+}
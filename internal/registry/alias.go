@@ -0,0 +1,31 @@
+package registry
+
+import "go/types"
+
+// alias is implemented by types.Alias, which go/types uses for type
+// aliases from Go 1.22, along with *types.Named and *types.TypeParam. It
+// is declared here as mirip still builds with older versions of Go.
+type alias interface {
+	types.Type
+	Obj() *types.TypeName
+}
+
+// asAlias returns the type alias t, if it is one, so that it is rendered
+// as written rather than as the type it stands for.
+func asAlias(t types.Type) (alias, bool) {
+	switch t.(type) {
+	case *types.Named, *types.TypeParam:
+		return nil, false
+	}
+	a, ok := t.(alias)
+	return a, ok
+}
+
+// typeArgs returns the type arguments of the instance of a generic type
+// or type alias, if any.
+func typeArgs(t types.Type) *types.TypeList {
+	if t, ok := t.(interface{ TypeArgs() *types.TypeList }); ok {
+		return t.TypeArgs()
+	}
+	return nil
+}
@@ -0,0 +1,336 @@
+package registry
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/gob"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+
+	"golang.org/x/tools/go/gcexportdata"
+	"golang.org/x/tools/go/packages"
+)
+
+// cacheVersion is bumped whenever the format of the cache entries changes.
+const cacheVersion = "mirip-cache-1"
+
+// cacheEnv are the environment variables which affect how packages load.
+var cacheEnv = []string{"GOOS", "GOARCH", "GOFLAGS", "CGO_ENABLED", "GOWORK"}
+
+// moduleHashes memoizes the hashes of the modules, keyed by their root
+// directory, so that a run hashes each module once.
+var moduleHashes = struct {
+	sync.Mutex
+	m map[string]string
+}{m: make(map[string]string)}
+
+// ForgetModules forgets the memoized hashes of the modules, for
+// long-running processes to notice the changes of the modules between
+// runs.
+func ForgetModules() {
+	moduleHashes.Lock()
+	defer moduleHashes.Unlock()
+	moduleHashes.m = make(map[string]string)
+}
+
+// PackageCache keeps loaded source packages in memory for long-running
+// processes. Its entries are keyed like those of the cache on disk, so
+// that packages are loaded again once their module changes.
+type PackageCache struct {
+	mu   sync.Mutex
+	pkgs map[string]*packages.Package
+}
+
+// NewPackageCache returns an empty PackageCache.
+func NewPackageCache() *PackageCache {
+	return &PackageCache{pkgs: make(map[string]*packages.Package)}
+}
+
+// Package returns the source package of srcDir loaded with opts, if it is
+// cached for the current state of its module.
+func (c *PackageCache) Package(srcDir string, opts LoadOptions) (*packages.Package, bool) {
+	key, err := cacheKey(srcDir, opts)
+	if err != nil {
+		return nil, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	pkg, ok := c.pkgs[key]
+	return pkg, ok
+}
+
+// Store caches the source package of srcDir loaded with opts.
+func (c *PackageCache) Store(srcDir string, opts LoadOptions, pkg *packages.Package) {
+	key, err := cacheKey(srcDir, opts)
+	if err != nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pkgs[key] = pkg
+}
+
+// cacheEntry is the type information of a source package in the cache.
+type cacheEntry struct {
+	Name    string
+	PkgPath string
+	GoFiles []string
+	Export  []byte
+
+	// IgnoredFiles are kept for the variants of the interfaces declared
+	// in the files the build configuration excludes.
+	IgnoredFiles []string
+}
+
+// CachedPackage returns the source package of srcDir from the cache in
+// cacheDir, if there is an entry for the current state of its module
+// loaded with opts.
+func CachedPackage(cacheDir, srcDir string, opts LoadOptions) (*packages.Package, bool) {
+	key, err := cacheKey(srcDir, opts)
+	if err != nil {
+		return nil, false
+	}
+
+	f, err := os.Open(filepath.Join(cacheDir, key))
+	if err != nil {
+		return nil, false
+	}
+	defer f.Close()
+
+	var entry cacheEntry
+	if err := gob.NewDecoder(f).Decode(&entry); err != nil {
+		return nil, false
+	}
+
+	fset := token.NewFileSet()
+	pkg, err := gcexportdata.Read(bytes.NewReader(entry.Export), fset, make(map[string]*types.Package), entry.PkgPath)
+	if err != nil {
+		return nil, false
+	}
+
+	// Only the imports of the files are needed, for their aliases.
+	syntax := make([]*ast.File, len(entry.GoFiles))
+	for i, name := range entry.GoFiles {
+		if syntax[i], err = parser.ParseFile(fset, name, nil, parser.ImportsOnly); err != nil {
+			return nil, false
+		}
+	}
+
+	return &packages.Package{
+		ID:           entry.PkgPath,
+		Name:         entry.Name,
+		PkgPath:      entry.PkgPath,
+		GoFiles:      entry.GoFiles,
+		IgnoredFiles: entry.IgnoredFiles,
+		Fset:         fset,
+		Types:        pkg,
+		Syntax:       syntax,
+	}, true
+}
+
+// CachePackage stores the type information of the source package of
+// srcDir, loaded with opts, in the cache in cacheDir.
+func CachePackage(cacheDir, srcDir string, opts LoadOptions, pkg *packages.Package) error {
+	key, err := cacheKey(srcDir, opts)
+	if err != nil {
+		return err
+	}
+
+	var export bytes.Buffer
+	if err := gcexportdata.Write(&export, pkg.Fset, pkg.Types); err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	err = gob.NewEncoder(&buf).Encode(cacheEntry{
+		Name:         pkg.Name,
+		PkgPath:      pkg.PkgPath,
+		GoFiles:      pkg.GoFiles,
+		Export:       export.Bytes(),
+		IgnoredFiles: pkg.IgnoredFiles,
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(cacheDir, 0750); err != nil {
+		return err
+	}
+
+	// Write to a temporary file first for concurrent runs not to read a
+	// partial entry.
+	tmp, err := os.CreateTemp(cacheDir, key+".*")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(buf.Bytes()); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), filepath.Join(cacheDir, key))
+}
+
+// cacheKey returns the key of the cache entry of the source package in
+// srcDir: a hash of the package directory, the environment it is loaded
+// in with opts and its module, along with the go.mod file of the -modfile
+// flag of GOFLAGS and the go.work file, if any.
+func cacheKey(srcDir string, opts LoadOptions) (string, error) {
+	dir, err := filepath.Abs(srcDir)
+	if err != nil {
+		return "", err
+	}
+
+	root, err := moduleRoot(dir)
+	if err != nil {
+		return "", err
+	}
+
+	h := sha256.New()
+	_, _ = fmt.Fprintln(h, cacheVersion, runtime.Version(), dir)
+	for _, name := range cacheEnv {
+		_, _ = fmt.Fprintln(h, name, opts.getenv(name))
+	}
+	for _, name := range opts.envNames() {
+		_, _ = fmt.Fprintln(h, name, opts.Env[name])
+	}
+
+	moduleHash, err := hashModule(root)
+	if err != nil {
+		return "", err
+	}
+	_, _ = fmt.Fprintln(h, moduleHash)
+	if modFile := opts.modFile(); modFile != "" {
+		hashFiles(h, modFile, strings.TrimSuffix(modFile, ".mod")+".sum")
+	}
+	if workFile := workFile(dir, opts); workFile != "" {
+		hashFiles(h, workFile, workFile+".sum")
+	}
+
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
+// moduleRoot returns the root directory of the module containing dir.
+func moduleRoot(dir string) (string, error) {
+	for {
+		if _, err := os.Stat(filepath.Join(dir, "go.mod")); err == nil {
+			return dir, nil
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", errors.New("not in a module")
+		}
+		dir = parent
+	}
+}
+
+// workFile returns the go.work file which the go command uses for dir: the
+// one of GOWORK, or else the first one found in dir and its parents. It
+// returns "" if there is none or GOWORK is off.
+func workFile(dir string, opts LoadOptions) string {
+	switch gowork := opts.getenv("GOWORK"); gowork {
+	case "off":
+		return ""
+	case "":
+	default:
+		return gowork
+	}
+
+	for {
+		name := filepath.Join(dir, "go.work")
+		if _, err := os.Stat(name); err == nil {
+			return name
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return ""
+		}
+		dir = parent
+	}
+}
+
+// hashFiles writes the path, size and modification time of each file to
+// h, like hashModule, or only the path of those which don't exist.
+func hashFiles(h io.Writer, paths ...string) {
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			_, _ = fmt.Fprintln(h, path)
+			continue
+		}
+		_, _ = fmt.Fprintln(h, path, info.Size(), info.ModTime().UnixNano())
+	}
+}
+
+// hashModule returns a hash of the Go files and the go.mod and go.sum
+// of the module in root, excluding nested modules. The files are hashed
+// by their path, size and modification time rather than their content,
+// which is enough to notice edits while being fast on large modules.
+// Modules replaced with local directories outside of root aren't
+// hashed.
+func hashModule(root string) (string, error) {
+	moduleHashes.Lock()
+	defer moduleHashes.Unlock()
+	if hash, ok := moduleHashes.m[root]; ok {
+		return hash, nil
+	}
+
+	h := sha256.New()
+	err := filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		name := d.Name()
+		if d.IsDir() {
+			if path == root {
+				return nil
+			}
+			if strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_") || name == "testdata" {
+				return filepath.SkipDir
+			}
+			if _, err := os.Stat(filepath.Join(path, "go.mod")); err == nil {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if !strings.HasSuffix(name, ".go") && name != "go.mod" && name != "go.sum" {
+			return nil
+		}
+
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		_, _ = fmt.Fprintln(h, path, info.Size(), info.ModTime().UnixNano())
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	hash := hex.EncodeToString(h.Sum(nil))
+	moduleHashes.m[root] = hash
+	return hash, nil
+}
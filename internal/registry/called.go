@@ -0,0 +1,57 @@
+package registry
+
+import (
+	"errors"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// CalledMethods loads the package in dir along with its tests and returns
+// the methods of interfaces which their code calls or takes the value of,
+// in the format 'path.Method' where path is the package declaring each
+// method, such as 'io.Close'. The methods promoted from an interface
+// embedded in a struct count as well. The errors of the package are
+// ignored, for its tests may use the mocks which are yet to be generated,
+// as long as it could be type-checked.
+func CalledMethods(dir string, opts LoadOptions) (map[string]bool, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes | packages.NeedTypesInfo,
+		Dir:     absDir,
+		Env:     opts.env(),
+		Context: opts.Context,
+		Overlay: opts.Overlay,
+		Tests:   true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	called := make(map[string]bool)
+	checked := false
+	for _, pkg := range pkgs {
+		if pkg.TypesInfo == nil {
+			continue
+		}
+		checked = true
+		for _, sel := range pkg.TypesInfo.Selections {
+			method, ok := sel.Obj().(*types.Func)
+			if !ok || method.Pkg() == nil {
+				continue
+			}
+			if recv := method.Type().(*types.Signature).Recv(); recv != nil && types.IsInterface(recv.Type()) {
+				called[method.Pkg().Path()+"."+method.Name()] = true
+			}
+		}
+	}
+	if !checked {
+		return nil, errors.New("no package to analyse in " + dir)
+	}
+	return called, nil
+}
@@ -0,0 +1,26 @@
+package registry
+
+import (
+	"go/types"
+	"strings"
+)
+
+// cgoPrefix is the prefix of the names cgo gives to the C types used by a
+// package, which its Go files refer to as C.name.
+const cgoPrefix = "_Ctype_"
+
+// CgoType returns the first C type the type refers to, in the format
+// 'C.name', if any. Mocks can't refer to C types, as these are only known
+// to the files declaring them in their cgo preamble.
+func CgoType(t types.Type) (string, bool) {
+	return findTypeName(t, cgoName)
+}
+
+// cgoName returns the name of the type in the format 'C.name', if it is a
+// C type.
+func cgoName(obj *types.TypeName) (string, bool) {
+	if !strings.HasPrefix(obj.Name(), cgoPrefix) {
+		return "", false
+	}
+	return "C." + strings.TrimPrefix(obj.Name(), cgoPrefix), true
+}
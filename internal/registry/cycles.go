@@ -0,0 +1,113 @@
+package registry
+
+import (
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// ImportCycle returns the chain of imports from one of the given imports
+// of the output file back to the output package, such as
+// [example.com/q example.com/r example.com/p] for q importing r importing
+// p, if any, in which case the output file can't import it. An import of
+// the output package itself is the chain of its path alone. The imports
+// of the standard library are only checked along with the outputs of
+// packages of the standard library, as they can't import the others. The
+// chains of the imports are loaded once and cached.
+func (r *Registry) ImportCycle(imports []*Package) ([]string, error) {
+	if r.miripPkgPath == "" {
+		return nil, nil
+	}
+
+	var paths, missing []string
+	for _, imprt := range imports {
+		path := imprt.Path()
+		if path == r.miripPkgPath {
+			return []string{path}, nil
+		}
+		if isStdPath(path) && !isStdPath(r.miripPkgPath) {
+			continue
+		}
+		paths = append(paths, path)
+		if _, ok := r.cycles[path]; !ok {
+			missing = append(missing, path)
+		}
+	}
+	if len(missing) != 0 {
+		if err := r.loadCycles(missing); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, path := range paths {
+		if chain := r.cycles[path]; chain != nil {
+			return chain, nil
+		}
+	}
+	return nil, nil
+}
+
+// loadCycles loads the packages of the given paths along with their
+// dependencies, and caches the chain of imports of each back to the
+// output package, nil for those not importing it.
+func (r *Registry) loadCycles(paths []string) error {
+	dir := r.srcDir
+	if len(r.srcPkg.GoFiles) != 0 {
+		dir = filepath.Dir(r.srcPkg.GoFiles[0])
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.NeedName | packages.NeedImports | packages.NeedDeps,
+		Dir:     dir,
+		Env:     r.loadOpts.env(),
+		Context: r.loadOpts.Context,
+		Overlay: r.loadOpts.Overlay,
+	}, paths...)
+	if err != nil {
+		return err
+	}
+
+	if r.cycles == nil {
+		r.cycles = make(map[string][]string)
+	}
+	for _, path := range paths {
+		r.cycles[path] = nil
+	}
+	for _, pkg := range pkgs {
+		r.cycles[pkg.PkgPath] = importChain(pkg, r.miripPkgPath, make(map[string]bool))
+	}
+	return nil
+}
+
+// importChain returns the chain of imports from the package to the
+// package of the given path, nil if it doesn't import it. The packages
+// already seen don't import it.
+func importChain(pkg *packages.Package, path string, seen map[string]bool) []string {
+	if pkg.PkgPath == path {
+		return []string{path}
+	}
+	if seen[pkg.PkgPath] {
+		return nil
+	}
+	seen[pkg.PkgPath] = true
+
+	imports := make([]string, 0, len(pkg.Imports))
+	for imported := range pkg.Imports {
+		imports = append(imports, imported)
+	}
+	sort.Strings(imports)
+	for _, imported := range imports {
+		if chain := importChain(pkg.Imports[imported], path, seen); chain != nil {
+			return append([]string{pkg.PkgPath}, chain...)
+		}
+	}
+	return nil
+}
+
+// isStdPath reports whether the import path is one of the standard
+// library, whose first element has no dot unlike those of modules.
+func isStdPath(path string) bool {
+	first, _, _ := strings.Cut(path, "/")
+	return !strings.Contains(first, ".")
+}
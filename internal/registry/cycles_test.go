@@ -0,0 +1,38 @@
+package registry
+
+import (
+	"go/types"
+	"reflect"
+	"testing"
+)
+
+// TestImportCycleSelf checks that the imports of the output package
+// itself are cycles, even of the standard library, without loading them.
+func TestImportCycleSelf(t *testing.T) {
+	for _, tc := range []struct {
+		name    string
+		output  string
+		imports []string
+		want    []string
+	}{
+		{name: "self", output: "example.com/p", imports: []string{"fmt", "example.com/p"}, want: []string{"example.com/p"}},
+		{name: "std self", output: "internal/p", imports: []string{"internal/p"}, want: []string{"internal/p"}},
+		{name: "std", output: "example.com/p", imports: []string{"fmt", "io"}},
+		{name: "no output package", imports: []string{"example.com/p"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := Registry{miripPkgPath: tc.output}
+			imports := make([]*Package, len(tc.imports))
+			for i, path := range tc.imports {
+				imports[i] = &Package{pkg: types.NewPackage(path, "p")}
+			}
+			chain, err := r.ImportCycle(imports)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(chain, tc.want) {
+				t.Errorf("ImportCycle() = %v, want %v", chain, tc.want)
+			}
+		})
+	}
+}
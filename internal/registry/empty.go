@@ -0,0 +1,75 @@
+package registry
+
+import (
+	"bufio"
+	"go/token"
+	"go/types"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// EmptyPackage returns a package of the given name without any file for
+// srcDir, which holds no Go files, so that the interfaces of other
+// packages can be mocked in a new package. Its path is the import path of
+// the directory in its module, if any. The registry of such a package
+// must be given its directory with SetSrcDir.
+func EmptyPackage(srcDir, name string) *packages.Package {
+	pkgPath := dirImportPath(srcDir)
+	return &packages.Package{
+		Name:    name,
+		PkgPath: pkgPath,
+		Fset:    token.NewFileSet(),
+		Types:   types.NewPackage(pkgPath, name),
+	}
+}
+
+// dirImportPath returns the import path of the directory in its module,
+// from the module path declared in go.mod, or an empty path if it isn't
+// in a module.
+func dirImportPath(dir string) string {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return ""
+	}
+	root, err := moduleRoot(abs)
+	if err != nil {
+		return ""
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return ""
+	}
+	modPath := modulePath(root)
+	if modPath == "" {
+		return ""
+	}
+	return path.Join(modPath, filepath.ToSlash(rel))
+}
+
+// modulePath returns the module path declared in the go.mod file of the
+// module root, or an empty path if it can't be read.
+func modulePath(root string) string {
+	f, err := os.Open(filepath.Join(root, "go.mod"))
+	if err != nil {
+		return ""
+	}
+	defer f.Close()
+
+	s := bufio.NewScanner(f)
+	for s.Scan() {
+		if line := strings.TrimSpace(s.Text()); strings.HasPrefix(line, "module ") {
+			return strings.Trim(strings.TrimSpace(strings.TrimPrefix(line, "module ")), `"`)
+		}
+	}
+	return ""
+}
+
+// IsEmpty returns whether the source package has no files, as returned by
+// EmptyPackage.
+func (r Registry) IsEmpty() bool {
+	return len(r.srcPkg.GoFiles) == 0
+}
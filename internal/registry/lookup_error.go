@@ -0,0 +1,163 @@
+package registry
+
+import (
+	"fmt"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"strings"
+)
+
+// NotFoundError is returned when the source package has no declaration
+// of the looked up name. It tells where the name was searched for, along
+// with the declarations of similar names. Dir is empty for the names
+// looked up in other packages than the source package, by their path.
+type NotFoundError struct {
+	// What is the kind of the declaration looked up, such as interface.
+	What    string
+	Name    string
+	PkgPath string
+	Dir     string
+	Files   []string
+
+	// Suggestions are the declarations of the same kind with the closest
+	// names, by edit distance.
+	Suggestions []string
+	Candidates  []Candidate
+
+	// Variants are the declarations of the name in the files of the
+	// package which the build configuration excludes.
+	Variants []Variant
+}
+
+// Candidate is a declaration whose name is similar to a name which was
+// not found.
+type Candidate struct {
+	Name string
+	What string
+	Pos  token.Position
+}
+
+func (e *NotFoundError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s not found: %s", e.What, e.Name)
+	if len(e.Suggestions) != 0 {
+		fmt.Fprintf(&b, " (did you mean %s?)", strings.Join(e.Suggestions, " or "))
+	}
+	if e.Dir != "" {
+		fmt.Fprintf(&b, "\n\tsearched package %s in %s (%s)", e.PkgPath, e.Dir, strings.Join(e.Files, ", "))
+	}
+	for _, c := range e.Candidates {
+		fmt.Fprintf(&b, "\n\tsimilarly named %s %s at %s", c.What, c.Name, c.Pos)
+	}
+	for _, v := range e.Variants {
+		fmt.Fprintf(&b, "\n\tdeclared at %s, which the build excludes: select it with -tags, -goos or -goarch", v)
+	}
+	return b.String()
+}
+
+// notFound returns the error for the name, of the given kind, which the
+// source package doesn't declare. The suggestions are the names of the
+// declarations of the kind, as reported by match, which are the closest
+// to the name. The candidates are the declarations whose names differ
+// only in case, or contain one another.
+func (r Registry) notFound(what, name string, match func(types.Object) bool) error {
+	e := &NotFoundError{
+		What:     what,
+		Name:     name,
+		PkgPath:  r.srcPkg.PkgPath,
+		Variants: r.Variants(name),
+	}
+	for _, file := range r.srcPkg.GoFiles {
+		e.Dir = filepath.Dir(file)
+		e.Files = append(e.Files, filepath.Base(file))
+	}
+
+	// Names further than a third of their length away are unlikely to be
+	// typos.
+	limit := len(name) / 3
+	if limit < 1 {
+		limit = 1
+	}
+	best := limit + 1
+	lower := strings.ToLower(name)
+	scope := r.SrcPkg().Scope()
+	for _, other := range scope.Names() {
+		if !match(scope.Lookup(other)) {
+			continue
+		}
+
+		switch d := editDistance(name, other); {
+		case d < best:
+			best = d
+			e.Suggestions = []string{other}
+		case d == best && d <= limit:
+			e.Suggestions = append(e.Suggestions, other)
+		}
+	}
+
+	for _, other := range scope.Names() {
+		otherLower := strings.ToLower(other)
+		if !strings.Contains(otherLower, lower) && !strings.Contains(lower, otherLower) {
+			continue
+		}
+
+		obj := scope.Lookup(other)
+		e.Candidates = append(e.Candidates, Candidate{
+			Name: other,
+			What: describe(obj),
+			Pos:  r.srcPkg.Fset.Position(obj.Pos()),
+		})
+	}
+	return e
+}
+
+// describe returns the kind of the declared object, such as interface.
+func describe(obj types.Object) string {
+	switch obj.(type) {
+	case *types.Func:
+		return "function"
+	case *types.Var:
+		return "variable"
+	case *types.Const:
+		return "constant"
+	case *types.TypeName:
+		if types.IsInterface(obj.Type()) {
+			return "interface"
+		}
+		return "type"
+	}
+	return "declaration"
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	prev := make([]int, len(t)+1)
+	curr := make([]int, len(t)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(s); i++ {
+		curr[0] = i
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			curr[j] = minInt(prev[j]+1, curr[j-1]+1, prev[j-1]+cost)
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(t)]
+}
+
+func minInt(n int, others ...int) int {
+	for _, o := range others {
+		if o < n {
+			n = o
+		}
+	}
+	return n
+}
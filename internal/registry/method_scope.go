@@ -10,49 +10,118 @@ import (
 //
 // It should be created using a registry instance.
 type MethodScope struct {
-	registry     *Registry
-	miripPkgPath string
+	registry       *Registry
+	miripPkgPath   string
+	conflictSuffix string
+	conflicts      string
+	reserved       []string
+	typeNames      map[string]string
+	positional     bool
+	positions      map[string]int
+
+	vars []*Var
+}
 
-	vars       []*Var
-	conflicted map[string]bool
+// AddVar allocates a variable instance and adds it to the method scope,
+// like AddVars.
+func (m *MethodScope) AddVar(vr *types.Var, suffix string) *Var {
+	return m.AddVars([]*types.Var{vr}, []string{suffix})[0]
 }
 
-// AddVar allocates a variable instance and adds it to the method scope.
+// AddVars allocates the variable instances, each with the suffix of the
+// same index, and adds them to the method scope.
 //
 // Variables names are generated if required and are ensured to be
 // without conflict with other variables and imported packages. It also
 // adds the relevant imports to the registry for each added variable.
-func (m *MethodScope) AddVar(vr *types.Var, suffix string) *Var {
-	imports := make(map[string]*Package)
-	m.populateImports(vr.Type(), imports)
-	m.resolveImportVarConflicts(imports)
-
-	name := varName(vr, suffix)
-	// Ensure that the var name does not conflict with a package import.
-	if _, ok := m.registry.searchImport(name); ok {
-		name += "MiripParam"
+//
+// The names are allocated in a single pass, once the imports of every
+// variable are added: the names given to several variables are numbered
+// in order, such as s1 and s2 with ConflictNumber, skipping the numbers of
+// the names already taken, and the variables added earlier are never
+// renamed, so that the names only depend on the variables given.
+func (m *MethodScope) AddVars(vrs []*types.Var, suffixes []string) []*Var {
+	vars := make([]*Var, len(vrs))
+	for i, vr := range vrs {
+		imports := make(map[string]*Package)
+		m.populateImports(vr.Type(), imports)
+		m.resolveImportVarConflicts(imports)
+		vars[i] = &Var{
+			vr:           vr,
+			imports:      imports,
+			miripPkgPath: m.miripPkgPath,
+		}
+	}
+
+	names := make([]string, len(vrs))
+	count := make(map[string]int)
+	for i, vr := range vrs {
+		m.positions[suffixes[i]]++
+		name := m.varName(vr, suffixes[i])
+		// Ensure that the var name does not conflict with a package import
+		// nor a reserved name, such as the receiver.
+		if _, ok := m.registry.searchImport(name); ok || m.isReserved(name) {
+			name += m.conflictSuffix
+		}
+		names[i] = name
+		count[name]++
+	}
+
+	taken := make(map[string]bool)
+	for _, v := range m.vars {
+		taken[v.Name] = true
+	}
+	// The names suffixed may be reserved in turn, such as a receiver
+	// named after the parameter it is renamed from.
+	for _, r := range m.reserved {
+		taken[r] = true
 	}
-	if _, ok := m.searchVar(name); ok || m.conflicted[name] {
-		name = m.resolveVarNameConflict(name)
+	for _, name := range names {
+		if count[name] == 1 && !taken[name] {
+			taken[name] = true
+			count[name] = 0
+		}
+	}
+	next := make(map[string]int)
+	for i, name := range names {
+		if count[name] != 0 {
+			name = m.disambiguated(name, next, taken)
+		}
+		vars[i].Name = name
 	}
 
-	v := Var{
-		vr:           vr,
-		imports:      imports,
-		miripPkgPath: m.miripPkgPath,
-		Name:         name,
+	m.vars = append(m.vars, vars...)
+	return vars
+}
+
+// disambiguated returns the name disambiguated by the lowest number from
+// the next of the name, following the conflicts strategy, which isn't
+// taken, and takes it.
+func (m MethodScope) disambiguated(name string, next map[string]int, taken map[string]bool) string {
+	for n := next[name] + 1; ; n++ {
+		unique := Disambiguate(m.conflicts, name, n)
+		if !taken[unique] {
+			next[name] = n
+			taken[unique] = true
+			return unique
+		}
 	}
-	m.vars = append(m.vars, &v)
-	return &v
 }
 
-func varName(vr *types.Var, suffix string) string {
+// varName returns the name of the variable, generated from its type or
+// its position if it has none, appending the conflict suffix to names which would shadow a
+// keyword, a basic type or an identifier of the mock.
+func (m MethodScope) varName(vr *types.Var, suffix string) string {
 	name := vr.Name()
 	if name != "" && name != "_" {
 		return name + suffix
 	}
 
-	name = varNameForType(vr.Type()) + suffix
+	if m.positional {
+		name = "arg" + strconv.Itoa(m.positions[suffix]) + suffix
+	} else {
+		name = m.varNameForType(vr.Type()) + suffix
+	}
 
 	switch name {
 	case "mock", "callInfo", "break", "default", "func", "interface", "select", "case", "defer", "go", "map", "struct",
@@ -63,7 +132,7 @@ func varName(vr *types.Var, suffix string) string {
 		"int", "int8", "int16", "int32", "int64",
 		"uint", "uint8", "uint16", "uint32", "uint64",
 		"float32", "float64", "complex64", "complex128":
-		name += "MiripParam"
+		name += m.conflictSuffix
 	}
 
 	return name
@@ -71,13 +140,35 @@ func varName(vr *types.Var, suffix string) string {
 
 // populateImports extracts all the package imports for a given type
 // recursively. The imported packages by a single type can be more than
-// one (ex: map[a.Type]b.Type).
+// one (ex: map[a.Type]b.Type, a.List[b.Type]).
 func (m MethodScope) populateImports(t types.Type, imports map[string]*Package) {
+	if a, ok := asAlias(t); ok {
+		if pkg := a.Obj().Pkg(); pkg != nil {
+			imports[stripVendorPath(pkg.Path())] = m.registry.AddImport(pkg)
+		}
+		args := typeArgs(a)
+		for i := 0; i < args.Len(); i++ {
+			m.populateImports(args.At(i), imports)
+		}
+		return
+	}
+
 	switch t := t.(type) {
 	case *types.Named:
 		if pkg := t.Obj().Pkg(); pkg != nil {
 			imports[stripVendorPath(pkg.Path())] = m.registry.AddImport(pkg)
 		}
+		// The type arguments of instances of generic types, such as
+		// List[a.User], are rendered along with the type.
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			m.populateImports(args.At(i), imports)
+		}
+
+	case *types.Basic:
+		if t.Kind() == types.UnsafePointer {
+			imports[types.Unsafe.Path()] = m.registry.AddImport(types.Unsafe)
+		}
 
 	case *types.Array:
 		m.populateImports(t.Elem(), imports)
@@ -115,6 +206,11 @@ func (m MethodScope) populateImports(t types.Type, imports map[string]*Package)
 		for i := 0; i < t.NumEmbeddeds(); i++ {
 			m.populateImports(t.EmbeddedType(i), imports)
 		}
+
+	case *types.Union: // type set of a constraint
+		for i := 0; i < t.Len(); i++ {
+			m.populateImports(t.Term(i).Type(), imports)
+		}
 	}
 }
 
@@ -125,11 +221,20 @@ func (m MethodScope) resolveImportVarConflicts(imports map[string]*Package) {
 	// existing vars.
 	for _, imprt := range imports {
 		if v, ok := m.searchVar(imprt.Qualifier()); ok {
-			v.Name += "MirippParam"
+			v.Name += m.conflictSuffix
 		}
 	}
 }
 
+func (m MethodScope) isReserved(name string) bool {
+	for _, r := range m.reserved {
+		if r == name {
+			return true
+		}
+	}
+	return false
+}
+
 func (m MethodScope) searchVar(name string) (*Var, bool) {
 	for _, v := range m.vars {
 		if v.Name == name {
@@ -139,20 +244,3 @@ func (m MethodScope) searchVar(name string) (*Var, bool) {
 
 	return nil, false
 }
-
-func (m *MethodScope) resolveVarNameConflict(suggested string) string {
-	for n := 1; ; n++ {
-		_, ok := m.searchVar(suggested + strconv.Itoa(n))
-		if ok {
-			continue
-		}
-
-		if n == 1 {
-			conflict, _ := m.searchVar(suggested)
-			conflict.Name += "1"
-			m.conflicted[suggested] = true
-			n++
-		}
-		return suggested + strconv.Itoa(n)
-	}
-}
@@ -0,0 +1,172 @@
+package registry
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"golang.org/x/mod/modfile"
+	"golang.org/x/mod/module"
+	"golang.org/x/tools/go/packages"
+)
+
+// CheckOutModule returns an error if dir, the directory the mocks are
+// written to, is in another module than the source package, such as a
+// sibling module of a monorepo, which doesn't import the source package
+// from its directory: the mocks wouldn't build there, or would assert
+// another version of the interfaces. The go command decides, from the
+// go.mod file of that module, its replace directives and the go.work
+// file, if any and unless GOWORK is off. The -modfile flag of GOFLAGS is
+// left out, as it stands for the go.mod file of the source module.
+func (r *Registry) CheckOutModule(dir string) error {
+	if r.IsEmpty() || r.isSrcDir(dir) {
+		return nil
+	}
+	outDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil
+	}
+	srcDir := filepath.Dir(r.srcPkg.GoFiles[0])
+	outRoot, err := moduleRoot(outDir)
+	if err != nil {
+		return nil
+	}
+	srcRoot, err := moduleRoot(srcDir)
+	if err != nil || srcRoot == outRoot {
+		return nil
+	}
+
+	outMod, srcMod := modulePath(outRoot), modulePath(srcRoot)
+	workspace := ", or add both modules to a go.work file"
+	if r.loadOpts.getenv("GOWORK") == "off" {
+		workspace = ""
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.NeedName | packages.NeedFiles,
+		Dir:     outRoot,
+		Env:     r.loadOpts.withoutModFile().env(),
+		Context: r.loadOpts.Context,
+	}, r.srcPkg.PkgPath)
+	if err != nil {
+		return fmt.Errorf("failed to load %s from module %s of the output directory %s: %w",
+			r.srcPkg.PkgPath, outMod, dir, err)
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Errors) != 0 || len(pkgs[0].GoFiles) == 0 {
+		reason := "package not found"
+		if len(pkgs) == 1 && len(pkgs[0].Errors) != 0 {
+			reason = pkgs[0].Errors[0].Msg
+		}
+		return fmt.Errorf("the output directory %s is in module %s, which doesn't provide %s of module %s (%s): "+
+			"require %s in %s, replacing it with %s%s",
+			dir, outMod, r.srcPkg.PkgPath, srcMod, reason, srcMod, filepath.Join(outRoot, "go.mod"), srcRoot, workspace)
+	}
+	if loaded := filepath.Dir(pkgs[0].GoFiles[0]); loaded != srcDir {
+		return fmt.Errorf("the output directory %s is in module %s, which loads %s from %s rather than %s: "+
+			"replace module %s with %s in %s%s",
+			dir, outMod, r.srcPkg.PkgPath, loaded, srcDir, srcMod, srcRoot, filepath.Join(outRoot, "go.mod"), workspace)
+	}
+	return nil
+}
+
+// OutModule returns the go.mod and go.sum files of a dedicated module of
+// mocks in dir, nested in the module holding it, for the mocks and their
+// dependencies, such as testify or go-cmp, to stay out of the dependency
+// graph of that module. The module is named after its directory in the
+// parent module, which it requires, replaced with its directory, along
+// with every requirement and replacement of the parent, at the same
+// versions; the checksums of the parent are copied too. It returns nil
+// files if dir already holds a go.mod file.
+func OutModule(dir string) (gomod, gosum []byte, err error) {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, nil, err
+	}
+	root, err := moduleRoot(abs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("the module directory %s isn't in a module", dir)
+	}
+	if root == abs {
+		return nil, nil, nil
+	}
+
+	parentMod := filepath.Join(root, "go.mod")
+	content, err := os.ReadFile(parentMod)
+	if err != nil {
+		return nil, nil, err
+	}
+	parent, err := modfile.Parse(parentMod, content, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent.Module == nil {
+		return nil, nil, fmt.Errorf("%s declares no module path", parentMod)
+	}
+	rel, err := filepath.Rel(root, abs)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	modPath := path.Join(parent.Module.Mod.Path, filepath.ToSlash(rel))
+	f, err := modfile.Parse("go.mod", []byte("module "+modfile.AutoQuote(modPath)+"\n"), nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	if parent.Go != nil {
+		if err := f.AddGoStmt(parent.Go.Version); err != nil {
+			return nil, nil, err
+		}
+	}
+	if parent.Toolchain != nil {
+		if err := f.AddToolchainStmt(parent.Toolchain.Name); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// The parent is replaced with its directory, so its version is only
+	// a placeholder.
+	reqs := []*modfile.Require{{Mod: module.Version{Path: parent.Module.Mod.Path, Version: "v0.0.0-00010101000000-000000000000"}}}
+	for _, r := range parent.Require {
+		reqs = append(reqs, &modfile.Require{Mod: r.Mod, Indirect: r.Indirect})
+	}
+	f.SetRequireSeparateIndirect(reqs)
+
+	if err := f.AddReplace(parent.Module.Mod.Path, "", replacedDir(abs, root), ""); err != nil {
+		return nil, nil, err
+	}
+	for _, r := range parent.Replace {
+		newPath := r.New.Path
+		if r.New.Version == "" && !filepath.IsAbs(newPath) {
+			newPath = replacedDir(abs, filepath.Join(root, filepath.FromSlash(newPath)))
+		}
+		if err := f.AddReplace(r.Old.Path, r.Old.Version, newPath, r.New.Version); err != nil {
+			return nil, nil, err
+		}
+	}
+	f.Cleanup()
+	if gomod, err = f.Format(); err != nil {
+		return nil, nil, err
+	}
+
+	gosum, err = os.ReadFile(filepath.Join(root, "go.sum"))
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, nil, err
+	}
+	return gomod, gosum, nil
+}
+
+// replacedDir returns the path of the directory of a replace directive of
+// a module in dir, relative to dir.
+func replacedDir(dir, target string) string {
+	rel, err := filepath.Rel(dir, target)
+	if err != nil {
+		return filepath.ToSlash(target)
+	}
+	rel = filepath.ToSlash(rel)
+	if !strings.HasPrefix(rel, "../") && rel != ".." {
+		rel = "./" + rel
+	}
+	return rel
+}
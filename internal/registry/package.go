@@ -1,8 +1,10 @@
 package registry
 
 import (
+	"go/token"
 	"go/types"
 	"strings"
+	"unicode"
 )
 
 var replacer = strings.NewReplacer(
@@ -21,6 +23,17 @@ type Package struct {
 	pkg *types.Package
 
 	Alias string
+
+	// fixed is set when the alias was given by the user, so that it is
+	// never renamed.
+	fixed bool
+
+	// yields is set for the packages of the generated code, such as its
+	// runtime, renamed rather than the imports they conflict with, and
+	// taken holds the names they must not be renamed to either, such as
+	// those of the declarations of the output package.
+	yields bool
+	taken  map[string]bool
 }
 
 // Qualifier returns the qualifier which must be used to refer to types
@@ -38,9 +51,9 @@ func (p *Package) Qualifier() string {
 }
 
 // uniqueName generates a unique name for a package by concatenating
-// path components. The generated name is guaranteed to unique with an
-// appropriate level because the full package import paths themselves
-// are unique.
+// path components. The generated name is unique with an appropriate level
+// unless the paths only differ by the characters left out, such as
+// go-yaml and yaml, and is always a valid identifier.
 func (p Package) uniqueName(lvl int) string {
 	pp := strings.Split(p.Path(), "/")
 	reverse(pp)
@@ -50,6 +63,27 @@ func (p Package) uniqueName(lvl int) string {
 		name = strings.ToLower(replacer.Replace(pp[i])) + name
 	}
 
+	return identifier(name)
+}
+
+// identifier returns the name without the characters which can't be in an
+// identifier, such as the dots of domains, prefixed with pkg when it is
+// empty or starts with a digit, and suffixed with pkg when it is a keyword
+// or would shadow a predeclared identifier, such as error.
+func identifier(name string) string {
+	name = strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return r
+		}
+		return -1
+	}, name)
+
+	switch {
+	case name == "" || unicode.IsDigit([]rune(name)[0]):
+		return "pkg" + name
+	case token.IsKeyword(name) || types.Universe.Lookup(name) != nil:
+		return name + "pkg"
+	}
 	return name
 }
 
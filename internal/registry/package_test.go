@@ -0,0 +1,22 @@
+package registry
+
+import (
+	"go/token"
+	"go/types"
+	"testing"
+)
+
+func FuzzIdentifier(f *testing.F) {
+	for _, seed := range []string{"", "v2", "go-foo.bar", "yaml.v3", "1password", "ünïcode", "error", "type", "a_b", "日本"} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, name string) {
+		id := identifier(name)
+		if !token.IsIdentifier(id) {
+			t.Fatalf("identifier(%q) = %q, not an identifier", name, id)
+		}
+		if types.Universe.Lookup(id) != nil {
+			t.Fatalf("identifier(%q) = %q, shadowing a predeclared identifier", name, id)
+		}
+	})
+}
@@ -0,0 +1,85 @@
+package registry
+
+import (
+	"fmt"
+	"go/types"
+	"path/filepath"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// LookupQualifiedInterface returns the underlying interface definition of
+// the interface of the given name declared in the package of the given
+// path, along with the package. Unless the source package is that package
+// or imports it, the package is loaded from the directory of the source
+// package, which may hold no Go files.
+func (r Registry) LookupQualifiedInterface(path, name string) (*types.Package, *types.Interface, error) {
+	pkg, err := r.lookupPackage(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	obj := pkg.Scope().Lookup(name)
+	if obj == nil {
+		return nil, nil, &NotFoundError{What: "interface", Name: path + "." + name, PkgPath: path}
+	}
+	if _, ok := obj.(*types.TypeName); !ok || !types.IsInterface(obj.Type()) {
+		return nil, nil, fmt.Errorf("%s.%s (%s) is not an interface", path, name, obj.Type())
+	}
+
+	return pkg, obj.Type().Underlying().(*types.Interface).Complete(), nil
+}
+
+// LookupQualifiedValue returns the package of the variable or constant of
+// the given name declared in the package of the given path, loaded like
+// the package of LookupQualifiedInterface.
+func (r Registry) LookupQualifiedValue(path, name string) (*types.Package, error) {
+	pkg, err := r.lookupPackage(path)
+	if err != nil {
+		return nil, err
+	}
+
+	switch pkg.Scope().Lookup(name).(type) {
+	case *types.Var, *types.Const:
+		return pkg, nil
+	case nil:
+		return nil, fmt.Errorf("context key not found: %s.%s", path, name)
+	}
+	return nil, fmt.Errorf("%s.%s is neither a variable nor a constant", path, name)
+}
+
+// lookupPackage returns the package of the given path, among the source
+// package and its imports or loaded from the directory of the source
+// package otherwise.
+func (r Registry) lookupPackage(path string) (*types.Package, error) {
+	if path == r.srcPkg.PkgPath {
+		return r.SrcPkg(), nil
+	}
+	for _, imprt := range r.SrcPkg().Imports() {
+		if imprt.Path() == path {
+			return imprt, nil
+		}
+	}
+
+	dir := r.srcDir
+	if len(r.srcPkg.GoFiles) != 0 {
+		dir = filepath.Dir(r.srcPkg.GoFiles[0])
+	}
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.NeedName | packages.NeedTypes,
+		Dir:     dir,
+		Env:     r.loadOpts.env(),
+		Context: r.loadOpts.Context,
+		Overlay: r.loadOpts.Overlay,
+	}, path)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 {
+		return nil, fmt.Errorf("package not found: %s", path)
+	}
+	if err := pkgError(pkgs[0]); err != nil {
+		return nil, err
+	}
+	return pkgs[0].Types, nil
+}
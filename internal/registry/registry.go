@@ -1,13 +1,17 @@
 package registry
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"go/token"
 	"go/types"
 	"golang.org/x/tools/go/packages"
+	"os"
 	"path"
 	"path/filepath"
 	"sort"
+	"strconv"
 	"strings"
 )
 
@@ -16,28 +20,347 @@ import (
 // imports and ensures there are no conflicts in the imported package
 // qualifiers.
 type Registry struct {
-	srcPkg       *packages.Package
-	miripPkgPath string
-	aliases      map[string]string
-	imports      map[string]*Package
+	srcPkg         *packages.Package
+	miripPkgPath   string
+	aliases        map[string]string
+	imports        map[string]*Package
+	qualifiers     string
+	importAliases  map[string]string
+	conflictSuffix string
+	conflicts      string
+	typeNames      map[string]string
+	paramNames     string
+	loadOpts       LoadOptions
+	srcDir         string
+	cycles         map[string][]string
 }
 
+// DefaultConflictSuffix is appended to the names of the variables of mocks
+// which would conflict with an import or shadow a keyword or a type.
+const DefaultConflictSuffix = "MiripParam"
+
+// Strategies of naming the imports whose qualifiers collide.
+const (
+	// QualifierPath renames both imports after the last components of
+	// their paths, as many as needed for them to differ, such as
+	// v2client and v3client.
+	QualifierPath = "path"
+
+	// QualifierNumber renames the later import after its name with the
+	// lowest number which makes it unique, such as client and client2.
+	QualifierNumber = "number"
+)
+
+// srcLoadMode is the mode in which source packages are loaded. Only the
+// source packages are parsed, for the aliases of their imports, while
+// the types of their dependencies come from export data, as mirip never
+// reads the syntax of dependencies nor the types info of any package.
+const srcLoadMode = packages.NeedName | packages.NeedFiles | packages.NeedImports |
+	packages.NeedSyntax | packages.NeedTypes
+
 // New loads the source package info and returns a new instance of
 // Registry.
 func New(srcDir, miripPkg string) (*Registry, error) {
-	srcPkg, err := pkgInfoFromPath(
-		srcDir, packages.NeedName|packages.NeedSyntax|packages.NeedTypes|packages.NeedTypesInfo|packages.NeedDeps,
-	)
+	srcPkg, err := pkgInfoFromPath(srcDir, srcLoadMode, LoadOptions{})
 	if err != nil {
-		return nil, fmt.Errorf("couldn't load source package: %s", err)
+		return nil, &LoadError{Dir: srcDir, Err: err}
 	}
 
+	return NewWithPackage(srcPkg, miripPkg), nil
+}
+
+// LoadError is returned when a source package can't be loaded.
+type LoadError struct {
+	Dir string
+	Err error
+}
+
+func (e *LoadError) Error() string {
+	return fmt.Sprintf("couldn't load source package %s: %s", e.Dir, e.Err)
+}
+
+func (e *LoadError) Unwrap() error {
+	return e.Err
+}
+
+// NewWithPackage returns a new instance of Registry for the given source
+// package, loaded by Load.
+func NewWithPackage(srcPkg *packages.Package, miripPkg string) *Registry {
 	return &Registry{
 		srcPkg:       srcPkg,
 		miripPkgPath: findPkgPath(miripPkg, srcPkg),
 		aliases:      parseImportsAliases(srcPkg),
 		imports:      make(map[string]*Package),
-	}, nil
+	}
+}
+
+// LoadOptions specifies how source packages are loaded.
+//
+// AllowErrors keeps the source packages which have errors, as long as
+// they could be type-checked. The interfaces looked up in such packages
+// must still be fully resolved.
+//
+// GOOS and GOARCH, if set, select the platform the packages are loaded
+// for instead of the one of the environment, so that the interfaces
+// declared in files constrained to another platform can be loaded.
+//
+// Context, if set, cancels the loading of the packages.
+//
+// Overlay replaces the content of the files keyed by their absolute path,
+// such as the unsaved buffers of an editor.
+//
+// Loader, if set, loads the source packages instead of go/packages.
+type LoadOptions struct {
+	Loader      Loader
+	AllowErrors bool
+	GOOS        string
+	GOARCH      string
+	Context     context.Context
+	Overlay     map[string][]byte
+	Env         map[string]string
+}
+
+// env returns the environment of the build system loading the packages,
+// nil for the environment of the process.
+func (o LoadOptions) env() []string {
+	if o.GOOS == "" && o.GOARCH == "" && len(o.Env) == 0 {
+		return nil
+	}
+
+	env := os.Environ()
+	for _, name := range o.envNames() {
+		env = append(env, name+"="+o.Env[name])
+	}
+	if o.GOOS != "" {
+		env = append(env, "GOOS="+o.GOOS)
+	}
+	if o.GOARCH != "" {
+		env = append(env, "GOARCH="+o.GOARCH)
+	}
+	return env
+}
+
+// getenv returns the value of the environment variable name which the
+// packages are loaded with.
+func (o LoadOptions) getenv(name string) string {
+	switch {
+	case name == "GOOS" && o.GOOS != "":
+		return o.GOOS
+	case name == "GOARCH" && o.GOARCH != "":
+		return o.GOARCH
+	}
+	if value, ok := o.Env[name]; ok {
+		return value
+	}
+	return os.Getenv(name)
+}
+
+// modFile returns the go.mod file given by the -modfile flag of GOFLAGS
+// which the packages are loaded with, "" if there is none.
+func (o LoadOptions) modFile() string {
+	var name string
+	for _, flag := range strings.Fields(o.getenv("GOFLAGS")) {
+		if value, ok := goFlag(flag, "modfile"); ok {
+			name = value
+		}
+	}
+	return name
+}
+
+// withoutModFile returns the options without the -modfile flag of
+// GOFLAGS, for loading packages from another module than the one the
+// go.mod file stands for.
+func (o LoadOptions) withoutModFile() LoadOptions {
+	if o.modFile() == "" {
+		return o
+	}
+
+	var flags []string
+	for _, flag := range strings.Fields(o.getenv("GOFLAGS")) {
+		if _, ok := goFlag(flag, "modfile"); !ok {
+			flags = append(flags, flag)
+		}
+	}
+	env := make(map[string]string, len(o.Env)+1)
+	for name, value := range o.Env {
+		env[name] = value
+	}
+	env["GOFLAGS"] = strings.Join(flags, " ")
+	o.Env = env
+	return o
+}
+
+// goFlag returns the value of the flag of GOFLAGS if it is the flag of
+// the given name, such as modfile for -modfile=go.test.mod.
+func goFlag(flag, name string) (string, bool) {
+	flagName, value, ok := strings.Cut(strings.TrimPrefix(flag, "-"), "=")
+	if !ok || (flagName != "-"+name && flagName != name) {
+		return "", false
+	}
+	return value, true
+}
+
+// Tags returns the build tags given by the -tags flag of GOFLAGS which
+// the packages are loaded with, "" if there are none.
+func (o LoadOptions) Tags() string {
+	var tags string
+	for _, flag := range strings.Fields(o.getenv("GOFLAGS")) {
+		if value, ok := goFlag(flag, "tags"); ok {
+			tags = value
+		}
+	}
+	return tags
+}
+
+// SelectsBuild reports whether the packages are loaded for a build
+// configuration given explicitly rather than the one of the environment:
+// another platform, or build tags.
+func (o LoadOptions) SelectsBuild() bool {
+	return o.GOOS != "" || o.GOARCH != "" || o.Tags() != ""
+}
+
+// envNames returns the sorted names of the variables of Env.
+func (o LoadOptions) envNames() []string {
+	names := make([]string, 0, len(o.Env))
+	for name := range o.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// check returns the error of the loaded package, unless it is allowed.
+func (o LoadOptions) check(pkg *packages.Package) error {
+	if o.AllowErrors && pkg.Types != nil {
+		return nil
+	}
+	return pkgError(pkg)
+}
+
+// Load loads the source packages of the given directories, in the same
+// order, with a single load so that their common dependencies are only
+// type-checked once. The packages are loaded one by one when they can't
+// be loaded together, such as when they belong to different modules.
+func Load(opts LoadOptions, srcDirs ...string) ([]*packages.Package, error) {
+	if opts.Loader != nil {
+		return loadWith(opts, srcDirs)
+	}
+	if len(srcDirs) > 1 {
+		if pkgs, err := loadAll(opts, srcDirs); err == nil {
+			return pkgs, nil
+		}
+	}
+
+	pkgs := make([]*packages.Package, len(srcDirs))
+	for i, dir := range srcDirs {
+		pkg, err := loadPkg(dir, srcLoadMode, opts)
+		if err == nil {
+			err = opts.check(pkg)
+		}
+		if err != nil {
+			return nil, &LoadError{Dir: dir, Err: err}
+		}
+		pkgs[i] = pkg
+	}
+	return pkgs, nil
+}
+
+// Loader loads the source packages of mocks in place of go/packages, for
+// front-ends such as the export data of a build system or the snapshots
+// of a language server to feed mirip without it reading the files of the
+// packages nor running the go command.
+type Loader interface {
+	// Load returns the package of each directory, in the same order,
+	// with at least its name, path, files, syntax and types, the fields
+	// of packages.NeedName, NeedFiles, NeedImports, NeedSyntax and
+	// NeedTypes. The errors of a package are kept in its Errors.
+	Load(ctx context.Context, dirs ...string) ([]*packages.Package, error)
+}
+
+// loadWith loads the source packages of the given directories with the
+// Loader of the options.
+func loadWith(opts LoadOptions, srcDirs []string) ([]*packages.Package, error) {
+	ctx := opts.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	pkgs, err := opts.Loader.Load(ctx, srcDirs...)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != len(srcDirs) {
+		return nil, fmt.Errorf("the loader returned %d packages for %d directories", len(pkgs), len(srcDirs))
+	}
+	for i, pkg := range pkgs {
+		err := errors.New("no package")
+		if pkg != nil {
+			err = opts.check(pkg)
+		}
+		if err != nil {
+			return nil, &LoadError{Dir: srcDirs[i], Err: err}
+		}
+	}
+	return pkgs, nil
+}
+
+// loadAll loads the source packages of the given directories together.
+func loadAll(opts LoadOptions, srcDirs []string) ([]*packages.Package, error) {
+	patterns := make([]string, len(srcDirs))
+	for i, dir := range srcDirs {
+		abs, err := filepath.Abs(dir)
+		if err != nil {
+			return nil, err
+		}
+		patterns[i] = abs
+	}
+
+	loaded, err := packages.Load(&packages.Config{
+		Mode:    srcLoadMode,
+		Env:     opts.env(),
+		Context: opts.Context,
+		Overlay: opts.Overlay,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	byDir := make(map[string]*packages.Package, len(loaded))
+	for _, pkg := range loaded {
+		if err := opts.check(pkg); err != nil {
+			return nil, err
+		}
+		if len(pkg.GoFiles) != 0 {
+			byDir[filepath.Dir(pkg.GoFiles[0])] = pkg
+		}
+	}
+
+	pkgs := make([]*packages.Package, len(patterns))
+	for i, dir := range patterns {
+		pkg, ok := byDir[dir]
+		if !ok {
+			return nil, fmt.Errorf("package not found in %s", dir)
+		}
+		pkgs[i] = pkg
+	}
+	return pkgs, nil
+}
+
+// DeclFile returns the path of the file of the source package declaring
+// the given name, if any.
+func (r Registry) DeclFile(name string) (string, bool) {
+	pos, ok := r.DeclPos(name)
+	return pos.Filename, ok
+}
+
+// DeclPos returns the position of the declaration of the given name in
+// the source package, if it is declared in one of its files.
+func (r Registry) DeclPos(name string) (token.Position, bool) {
+	obj := r.SrcPkg().Scope().Lookup(name)
+	if obj == nil {
+		return token.Position{}, false
+	}
+	pos := r.srcPkg.Fset.Position(obj.Pos())
+	return pos, pos.Filename != ""
 }
 
 // LookupInterface returns the underlying interface definition of the
@@ -45,14 +368,201 @@ func New(srcDir, miripPkg string) (*Registry, error) {
 func (r Registry) LookupInterface(name string) (*types.Interface, error) {
 	obj := r.SrcPkg().Scope().Lookup(name)
 	if obj == nil {
-		return nil, fmt.Errorf("interface not found: %s", name)
+		return nil, r.notFound("interface", name, func(obj types.Object) bool {
+			_, ok := obj.(*types.TypeName)
+			return ok && types.IsInterface(obj.Type())
+		})
 	}
 
 	if !types.IsInterface(obj.Type()) {
-		return nil, fmt.Errorf("%s (%s) is not an interface", name, obj.Type())
+		return nil, fmt.Errorf("%s (%s) declared at %s is not an interface", name, obj.Type(), r.srcPkg.Fset.Position(obj.Pos()))
+	}
+
+	iface := obj.Type().Underlying().(*types.Interface).Complete()
+	if len(r.srcPkg.Errors) != 0 && !resolved(iface) {
+		return nil, fmt.Errorf("interface %s can't be fully resolved: %s", name, pkgError(r.srcPkg))
+	}
+
+	return iface, nil
+}
+
+// resolved returns whether the type refers to no invalid type, which
+// type-checking errors leave in place of the types which couldn't be
+// resolved. Named types are assumed to be resolved, as only their name
+// is needed.
+func resolved(t types.Type) bool {
+	switch t := t.(type) {
+	case *types.Basic:
+		return t.Kind() != types.Invalid
+	case *types.Pointer:
+		return resolved(t.Elem())
+	case *types.Slice:
+		return resolved(t.Elem())
+	case *types.Array:
+		return resolved(t.Elem())
+	case *types.Chan:
+		return resolved(t.Elem())
+	case *types.Map:
+		return resolved(t.Key()) && resolved(t.Elem())
+	case *types.Named:
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if !resolved(args.At(i)) {
+				return false
+			}
+		}
+		return true
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if !resolved(t.At(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Signature:
+		return resolved(t.Params()) && resolved(t.Results())
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if !resolved(t.Field(i).Type()) {
+				return false
+			}
+		}
+		return true
+	case *types.Interface:
+		for i := 0; i < t.NumEmbeddeds(); i++ {
+			if !resolved(t.EmbeddedType(i)) {
+				return false
+			}
+		}
+		for i := 0; i < t.NumMethods(); i++ {
+			if !resolved(t.Method(i).Type()) {
+				return false
+			}
+		}
+		return true
+	}
+	return true
+}
+
+// Interfaces returns the names of the interfaces declared in the source
+// package, sorted.
+func (r Registry) Interfaces() []string {
+	var names []string
+	scope := r.SrcPkg().Scope()
+	for _, name := range scope.Names() {
+		if obj, ok := scope.Lookup(name).(*types.TypeName); ok && types.IsInterface(obj.Type()) {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// LookupConcreteType returns the named, non-interface type of the given
+// name.
+func (r Registry) LookupConcreteType(name string) (*types.Named, error) {
+	obj := r.SrcPkg().Scope().Lookup(name)
+	if obj == nil {
+		return nil, r.notFound("type", name, func(obj types.Object) bool {
+			_, ok := obj.(*types.TypeName)
+			return ok && !types.IsInterface(obj.Type())
+		})
+	}
+
+	named, ok := obj.Type().(*types.Named)
+	if _, isTypeName := obj.(*types.TypeName); !isTypeName || !ok || types.IsInterface(named) {
+		return nil, fmt.Errorf("%s (%s) declared at %s is not a concrete type", name, obj.Type(), r.srcPkg.Fset.Position(obj.Pos()))
+	}
+
+	return named, nil
+}
+
+// Constructors returns the signatures of the functions of the source
+// package whose first result is the given type or a pointer to it.
+func (r Registry) Constructors(named *types.Named) []*types.Signature {
+	var sigs []*types.Signature
+	scope := r.SrcPkg().Scope()
+	for _, name := range scope.Names() {
+		fn, ok := scope.Lookup(name).(*types.Func)
+		if !ok {
+			continue
+		}
+
+		sig := fn.Type().(*types.Signature)
+		if sig.Results().Len() == 0 {
+			continue
+		}
+
+		t := sig.Results().At(0).Type()
+		if ptr, ok := t.(*types.Pointer); ok {
+			t = ptr.Elem()
+		}
+		if types.Identical(t, named) {
+			sigs = append(sigs, sig)
+		}
+	}
+	return sigs
+}
+
+// LookupFunc returns the signature of the given function name.
+func (r Registry) LookupFunc(name string) (*types.Signature, error) {
+	obj := r.SrcPkg().Scope().Lookup(name)
+	if obj == nil {
+		return nil, r.notFound("function", name, func(obj types.Object) bool {
+			_, ok := obj.(*types.Func)
+			return ok
+		})
+	}
+
+	fn, ok := obj.(*types.Func)
+	if !ok {
+		return nil, fmt.Errorf("%s (%s) declared at %s is not a function", name, obj.Type(), r.srcPkg.Fset.Position(obj.Pos()))
+	}
+
+	return fn.Type().(*types.Signature), nil
+}
+
+// Declarations returns the positions of the package-level declarations
+// of the package in dir, keyed by name. Declarations of the files skip
+// are left out, as the files are about to be regenerated. No
+// declarations are returned when dir does not hold a package which can
+// be loaded.
+func (r Registry) Declarations(dir string, skip ...string) map[string]token.Position {
+	pkg := r.srcPkg
+	if !r.isSrcDir(dir) {
+		if _, err := os.Stat(dir); err != nil {
+			return nil
+		}
+		var err error
+		pkg, err = pkgInfoFromPath(dir, srcLoadMode, r.loadOpts)
+		if err != nil {
+			return nil
+		}
+	}
+
+	skipped := make(map[string]bool, len(skip))
+	for _, name := range skip {
+		abs, _ := filepath.Abs(name)
+		skipped[abs] = true
+	}
+
+	decls := make(map[string]token.Position)
+	scope := pkg.Types.Scope()
+	for _, name := range scope.Names() {
+		pos := pkg.Fset.Position(scope.Lookup(name).Pos())
+		if abs, _ := filepath.Abs(pos.Filename); !skipped[abs] {
+			decls[name] = pos
+		}
 	}
+	return decls
+}
 
-	return obj.Type().Underlying().(*types.Interface).Complete(), nil
+// isSrcDir returns whether dir is the directory of the source package.
+func (r Registry) isSrcDir(dir string) bool {
+	if len(r.srcPkg.GoFiles) == 0 {
+		return false
+	}
+	abs, err := filepath.Abs(dir)
+	return err == nil && abs == filepath.Dir(r.srcPkg.GoFiles[0])
 }
 
 // SrcPkg returns the types info for the source package.
@@ -65,6 +575,130 @@ func (r Registry) SrcPkgName() string {
 	return r.srcPkg.Name
 }
 
+// SetQualifiers sets the strategy naming the imports whose qualifiers
+// collide, QualifierPath by default, and the qualifiers of the imports of
+// the given paths. Those take precedence over the aliases of the source
+// package and are never renamed, so they must be unique.
+func (r *Registry) SetQualifiers(strategy string, importAliases map[string]string) {
+	r.qualifiers = strategy
+	r.importAliases = importAliases
+}
+
+// SetLoadOptions sets the options of loading the packages other than the
+// source package, such as the output package, which should be the same as
+// the source package was loaded with.
+func (r *Registry) SetLoadOptions(opts LoadOptions) {
+	r.loadOpts = opts
+}
+
+// SetSrcDir sets the directory of the source package, which the packages
+// of qualified interfaces are loaded from.
+func (r *Registry) SetSrcDir(dir string) {
+	r.srcDir = dir
+}
+
+// SetOutDir sets the directory the mocks are written to, if it is not the
+// source directory, for the types of its package not to be imported. The
+// mocks written to a directory holding no package yet import all the
+// types.
+func (r *Registry) SetOutDir(dir string) {
+	if r.isSrcDir(dir) {
+		return
+	}
+
+	r.miripPkgPath, r.cycles = "", nil
+	if _, err := os.Stat(dir); err != nil {
+		return
+	}
+	if pkg, err := loadPkg(dir, packages.NeedName, r.loadOpts); err == nil && len(pkg.GoFiles) != 0 {
+		r.miripPkgPath = pkg.PkgPath
+	}
+}
+
+// SetConflictSuffix sets the suffix appended to the names of variables
+// which would conflict, DefaultConflictSuffix by default.
+func (r *Registry) SetConflictSuffix(suffix string) {
+	r.conflictSuffix = suffix
+}
+
+// Strategies of naming the variables of mocks whose names collide, and
+// the generated identifiers.
+const (
+	// ConflictNumber appends the lowest numbers which make the names
+	// unique, such as s1 and s2.
+	ConflictNumber = "number"
+
+	// ConflictUnderscore appends as many underscores, such as s_ and s__.
+	ConflictUnderscore = "underscore"
+
+	// ConflictLetter appends capital letters, such as sA and sB, then
+	// sAA after sZ.
+	ConflictLetter = "letter"
+)
+
+// SetConflicts sets the strategy naming the variables whose names collide,
+// ConflictNumber by default.
+func (r *Registry) SetConflicts(strategy string) {
+	r.conflicts = strategy
+}
+
+// Disambiguate returns the nth name disambiguating the ones colliding on
+// the given name, following the strategy, ex: s2, s__ or sB for s.
+func Disambiguate(strategy, name string, n int) string {
+	switch strategy {
+	case ConflictUnderscore:
+		return name + strings.Repeat("_", n)
+	case ConflictLetter:
+		var letters []byte
+		for ; n > 0; n = (n - 1) / 26 {
+			letters = append([]byte{byte('A' + (n-1)%26)}, letters...)
+		}
+		return name + string(letters)
+	}
+	return name + strconv.Itoa(n)
+}
+
+// Styles of naming the unnamed variables of mocks.
+const (
+	// ParamNamesType names variables after their type, such as ctx or s.
+	ParamNamesType = "type"
+
+	// ParamNamesPosition names variables after their position in the
+	// parameters or results, such as arg1 or arg2Out.
+	ParamNamesPosition = "position"
+)
+
+// SetParamNames sets the style of naming the unnamed variables of mocks,
+// ParamNamesType by default.
+func (r *Registry) SetParamNames(style string) {
+	r.paramNames = style
+}
+
+// DefaultTypeNames are the names of the unnamed variables of well-known
+// types, keyed by type in the format 'path.Name' or '*path.Name'.
+var DefaultTypeNames = map[string]string{
+	"context.Context":             "ctx",
+	"*testing.T":                  "t",
+	"*testing.B":                  "b",
+	"testing.TB":                  "tb",
+	"time.Duration":               "d",
+	"net/http.ResponseWriter":     "w",
+	"*net/http.Request":           "r",
+	"github.com/google/uuid.UUID": "id",
+}
+
+// SetTypeNames sets the names of the unnamed variables of the given types,
+// which extend and override DefaultTypeNames.
+func (r *Registry) SetTypeNames(typeNames map[string]string) {
+	r.typeNames = make(map[string]string, len(DefaultTypeNames)+len(typeNames))
+	for t, name := range DefaultTypeNames {
+		r.typeNames[t] = name
+	}
+	for t, name := range typeNames {
+		r.typeNames[t] = name
+	}
+}
+
 // AddImport adds the given package to the set of imports. It generates a
 // suitable alias if there are any conflicts with previously imported
 // packages.
@@ -79,15 +713,71 @@ func (r *Registry) AddImport(pkg *types.Package) *Package {
 	}
 
 	imprt := Package{pkg: pkg, Alias: r.aliases[path]}
+	if alias, ok := r.importAliases[path]; ok {
+		imprt.Alias, imprt.fixed = alias, true
+	}
+
+	if conflict, ok := r.searchImport(imprt.Qualifier()); ok {
+		r.resolveImportConflict(&imprt, conflict)
+	}
+
+	r.imports[path] = &imprt
+	return &imprt
+}
+
+// AddYieldingImport adds the import of a package of the generated code,
+// such as its runtime, with the given alias unless the user gave it one.
+// Unlike the imports of AddImport, it is the one renamed when it conflicts
+// with another import, added before or after it, and it is renamed when
+// its qualifier is one of the taken names, such as the package-level
+// declarations of the output package.
+func (r *Registry) AddYieldingImport(pkg *types.Package, alias string, taken ...string) *Package {
+	path := stripVendorPath(pkg.Path())
+	if path == r.miripPkgPath {
+		return nil
+	}
+
+	if imprt, ok := r.imports[path]; ok {
+		return imprt
+	}
+
+	imprt := Package{pkg: pkg, Alias: alias, yields: true, taken: make(map[string]bool, len(taken))}
+	for _, name := range taken {
+		imprt.taken[name] = true
+	}
+	if alias, ok := r.importAliases[path]; ok {
+		imprt.Alias, imprt.fixed = alias, true
+	}
 
 	if conflict, ok := r.searchImport(imprt.Qualifier()); ok {
-		resolveImportConflict(&imprt, conflict, 0)
+		r.resolveImportConflict(&imprt, conflict)
+	} else if !imprt.fixed && imprt.taken[imprt.Qualifier()] {
+		r.rename(&imprt, "")
 	}
 
 	r.imports[path] = &imprt
 	return &imprt
 }
 
+// ResetImports removes all the imports added so far, for generating
+// another file.
+func (r *Registry) ResetImports() {
+	r.imports = make(map[string]*Package)
+}
+
+// IsOutputPkg reports whether the package is the package the mocks are
+// written to.
+func (r Registry) IsOutputPkg(pkg *types.Package) bool {
+	return pkg != nil && stripVendorPath(pkg.Path()) == r.miripPkgPath
+}
+
+// DropImport removes the package of the given path from the imports,
+// such as a package of the generated code which turned out not to be
+// used.
+func (r *Registry) DropImport(path string) {
+	delete(r.imports, path)
+}
+
 func (r Registry) searchImport(name string) (*Package, bool) {
 	for _, imprt := range r.imports {
 		if imprt.Qualifier() == name {
@@ -111,12 +801,68 @@ func (r Registry) Imports() []*Package {
 	return imports
 }
 
-// MethodScope returns a new MethodScope.
-func (r *Registry) MethodScope() *MethodScope {
+// ImportsOf returns the list of packages imported by the given vars
+// along with the extra packages, sorted by path. Unlike AddImport, the
+// extra packages are not added to the imports of the registry.
+func (r *Registry) ImportsOf(vars []*Var, extra ...*types.Package) []*Package {
+	used := make(map[string]*Package)
+	for _, v := range vars {
+		for path, imprt := range v.imports {
+			if imprt != nil {
+				used[path] = imprt
+			}
+		}
+	}
+
+	for _, pkg := range extra {
+		path := stripVendorPath(pkg.Path())
+		if imprt, ok := r.imports[path]; ok {
+			used[path] = imprt
+			continue
+		}
+
+		imprt := &Package{pkg: pkg}
+		for _, other := range used {
+			if other.Qualifier() == imprt.Qualifier() {
+				imprt.Alias = imprt.uniqueName(1)
+				break
+			}
+		}
+		used[path] = imprt
+	}
+
+	imports := make([]*Package, 0, len(used))
+	for _, imprt := range used {
+		imports = append(imports, imprt)
+	}
+	sort.Slice(imports, func(i, j int) bool {
+		return imports[i].Path() < imports[j].Path()
+	})
+	return imports
+}
+
+// MethodScope returns a new MethodScope, whose variables are renamed so as
+// not to conflict with the reserved names.
+func (r *Registry) MethodScope(reserved ...string) *MethodScope {
+	conflictSuffix := r.conflictSuffix
+	if conflictSuffix == "" {
+		conflictSuffix = DefaultConflictSuffix
+	}
+
+	typeNames := r.typeNames
+	if typeNames == nil {
+		typeNames = DefaultTypeNames
+	}
+
 	return &MethodScope{
-		registry:     r,
-		miripPkgPath: r.miripPkgPath,
-		conflicted:   map[string]bool{},
+		registry:       r,
+		miripPkgPath:   r.miripPkgPath,
+		conflictSuffix: conflictSuffix,
+		conflicts:      r.conflicts,
+		reserved:       reserved,
+		typeNames:      typeNames,
+		positional:     r.paramNames == ParamNamesPosition,
+		positions:      map[string]int{},
 	}
 }
 
@@ -132,22 +878,108 @@ func stripVendorPath(p string) string {
 	return strings.TrimLeft(path.Join(parts[1:]...), "/")
 }
 
-// resolveImportConflict generates and assigns a unique alias for
-// packages with conflicting qualifiers.
-func resolveImportConflict(a, b *Package, lvl int) {
+// resolveImportConflict renames the new import a, or the import b it
+// collides with, with the strategy of the registry. Imports with a fixed
+// alias are never renamed, and the packages of the generated code are
+// renamed rather than the others.
+func (r Registry) resolveImportConflict(a, b *Package) {
+	switch {
+	case a.fixed:
+		r.rename(b, a.Qualifier())
+	case b.fixed, a.yields:
+		r.rename(a, b.Qualifier())
+	case b.yields:
+		r.rename(b, a.Qualifier())
+	case r.qualifiers == QualifierNumber:
+		r.rename(a, b.Qualifier())
+	default:
+		r.resolvePathConflict(a, b, 0)
+	}
+}
+
+// rename renames the import with the strategy of the registry, so that
+// its qualifier is neither taken nor used by another import.
+func (r Registry) rename(p *Package, taken string) {
+	free := func(name string) bool {
+		_, used := r.searchImport(name)
+		return name != taken && !used && !p.taken[name]
+	}
+
+	if r.qualifiers != QualifierNumber {
+		// The last element of the paths of the packages of the generated
+		// code, such as runtime, is too generic to be their name alone.
+		lvl := 0
+		if p.yields {
+			lvl = 1
+		}
+		for ; lvl < len(strings.Split(p.Path(), "/")); lvl++ {
+			if name := p.uniqueName(lvl); free(name) {
+				p.Alias = name
+				return
+			}
+		}
+	}
+
+	base := p.Qualifier()
+	for n := 2; ; n++ {
+		if name := fmt.Sprintf("%s%d", base, n); free(name) {
+			p.Alias = name
+			return
+		}
+	}
+}
+
+// resolvePathConflict generates and assigns a unique alias for
+// packages with conflicting qualifiers from their paths, unused by the
+// other imports. The paths which make the same names at every level, or
+// only names used by other imports, are renamed by rename instead.
+func (r Registry) resolvePathConflict(a, b *Package, lvl int) {
+	free := func(name string) bool {
+		imprt, used := r.searchImport(name)
+		return !used || imprt == b
+	}
+
 	u1, u2 := a.uniqueName(lvl), b.uniqueName(lvl)
-	if u1 != u2 {
+	if u1 != u2 && free(u1) && free(u2) {
 		a.Alias, b.Alias = u1, u2
 		return
 	}
+	if lvl >= len(strings.Split(a.Path(), "/")) && lvl >= len(strings.Split(b.Path(), "/")) {
+		keep := u1 != u2 && free(u2)
+		b.Alias = u2
+		if !keep {
+			r.rename(b, u1)
+		}
+		a.Alias = u1
+		if _, used := r.searchImport(u1); used {
+			r.rename(a, b.Qualifier())
+		}
+		return
+	}
 
-	resolveImportConflict(a, b, lvl+1)
+	r.resolvePathConflict(a, b, lvl+1)
 }
 
-func pkgInfoFromPath(srcDir string, mode packages.LoadMode) (*packages.Package, error) {
+func pkgInfoFromPath(srcDir string, mode packages.LoadMode, opts LoadOptions) (*packages.Package, error) {
+	pkg, err := loadPkg(srcDir, mode, opts)
+	if err != nil {
+		return nil, err
+	}
+	if err := pkgError(pkg); err != nil {
+		return nil, err
+	}
+	return pkg, nil
+}
+
+// loadPkg loads the package in srcDir, which may have errors, with the
+// given options.
+func loadPkg(srcDir string, mode packages.LoadMode, opts LoadOptions) (*packages.Package, error) {
 	pkgs, err := packages.Load(&packages.Config{
-		Mode: mode,
-		Dir:  srcDir,
+		Mode:    mode,
+		Dir:     srcDir,
+		Env:     opts.env(),
+		Context: opts.Context,
+		Overlay: opts.Overlay,
 	})
 	if err != nil {
 		return nil, err
@@ -158,15 +990,21 @@ func pkgInfoFromPath(srcDir string, mode packages.LoadMode) (*packages.Package,
 	if len(pkgs) > 1 {
 		return nil, errors.New("found more than one package")
 	}
-	if errs := pkgs[0].Errors; len(errs) != 0 {
-		if len(errs) == 1 {
-			return nil, errs[0]
-		}
-		return nil, fmt.Errorf("%s (and %d more errors)", errs[0], len(errs)-1)
-	}
 	return pkgs[0], nil
 }
 
+// pkgError returns an error summing up the errors of the package, if any.
+func pkgError(pkg *packages.Package) error {
+	switch errs := pkg.Errors; len(errs) {
+	case 0:
+		return nil
+	case 1:
+		return errs[0]
+	default:
+		return fmt.Errorf("%s (and %d more errors)", errs[0], len(errs)-1)
+	}
+}
+
 func findPkgPath(pkgInputVal string, srcPkg *packages.Package) string {
 	if pkgInputVal == "" {
 		return srcPkg.PkgPath
@@ -174,15 +1012,57 @@ func findPkgPath(pkgInputVal string, srcPkg *packages.Package) string {
 	if pkgInDir(srcPkg.PkgPath, pkgInputVal) {
 		return srcPkg.PkgPath
 	}
-	subdirectoryPath := filepath.Join(srcPkg.PkgPath, pkgInputVal)
+	// Import paths are slash-separated, whatever the separator of the
+	// directories.
+	subdirectoryPath := path.Join(srcPkg.PkgPath, filepath.ToSlash(pkgInputVal))
 	if pkgInDir(subdirectoryPath, pkgInputVal) {
 		return subdirectoryPath
 	}
 	return ""
 }
 
+// PatternDirs returns the directories of the packages matched by the
+// package pattern, such as ./..., loaded with opts, sorted. Directories
+// holding only tests are left out.
+func PatternDirs(pattern string, opts LoadOptions) ([]string, error) {
+	pkgs, err := packages.Load(&packages.Config{
+		Mode:    packages.NeedName | packages.NeedFiles,
+		Env:     opts.env(),
+		Context: opts.Context,
+		Overlay: opts.Overlay,
+	}, pattern)
+	if err != nil {
+		return nil, err
+	}
+
+	var dirs []string
+	for _, pkg := range pkgs {
+		if len(pkg.GoFiles) != 0 {
+			dirs = append(dirs, filepath.Dir(pkg.GoFiles[0]))
+		}
+	}
+	if len(dirs) == 0 {
+		return nil, fmt.Errorf("no package matched %s", pattern)
+	}
+	sort.Strings(dirs)
+	return dirs, nil
+}
+
+// DirPkgName returns the name of the package in dir, loaded with opts, if
+// it holds one.
+func DirPkgName(dir string, opts LoadOptions) (string, bool) {
+	if _, err := os.Stat(dir); err != nil {
+		return "", false
+	}
+	pkg, err := pkgInfoFromPath(dir, packages.NeedName, opts)
+	if err != nil || pkg.Name == "" {
+		return "", false
+	}
+	return pkg.Name, true
+}
+
 func pkgInDir(pkgName, dir string) bool {
-	currentPkg, err := pkgInfoFromPath(dir, packages.NeedName)
+	currentPkg, err := pkgInfoFromPath(dir, packages.NeedName, LoadOptions{})
 	if err != nil {
 		return false
 	}
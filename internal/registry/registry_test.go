@@ -0,0 +1,40 @@
+package registry
+
+import (
+	"go/token"
+	"go/types"
+	"path"
+	"testing"
+)
+
+func FuzzImportAliases(f *testing.F) {
+	f.Add("example.com/a/client", "example.com/b/client", "example.com/client2", false)
+	f.Add("gopkg.in/yaml.v3", "github.com/go-yaml/yaml", "yaml", false)
+	f.Add("example.com/go-foo.bar", "example.com/gofoo/bar", "example.com/ünïcode/v2", true)
+	f.Add("a/client", "b/a/client", "aclient", false)
+	f.Fuzz(func(t *testing.T, path1, path2, path3 string, number bool) {
+		r := Registry{imports: make(map[string]*Package), qualifiers: QualifierPath}
+		if number {
+			r.qualifiers = QualifierNumber
+		}
+		for _, p := range []string{path1, path2, path3} {
+			if p == "" {
+				t.Skip()
+			}
+			// The go command names packages with identifiers.
+			r.AddImport(types.NewPackage(p, identifier(path.Base(p))))
+		}
+
+		seen := make(map[string]string)
+		for _, imprt := range r.imports {
+			q := imprt.Qualifier()
+			if !token.IsIdentifier(q) {
+				t.Fatalf("the qualifier %q of %s isn't an identifier", q, imprt.Path())
+			}
+			if other, ok := seen[q]; ok {
+				t.Fatalf("%s and %s are both qualified %q", other, imprt.Path(), q)
+			}
+			seen[q] = imprt.Path()
+		}
+	})
+}
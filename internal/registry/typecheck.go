@@ -0,0 +1,137 @@
+package registry
+
+import (
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// BuildError lists the errors of the type-checking of generated files in
+// the packages of their directories.
+type BuildError struct {
+	Errors []string
+}
+
+func (e *BuildError) Error() string {
+	return "generated code doesn't build:\n\t" + strings.Join(e.Errors, "\n\t")
+}
+
+// TypeCheck type-checks the packages of the directories of the given
+// files, along with their tests, with the files overlaid on the content
+// on disk, so that they are checked before or without being written. The
+// errors of the packages are only reported when some are in the files, as
+// the others aren't caused by them, but all are then, as the errors in
+// the files may come along with errors in the other files, such as of
+// declarations they conflict with.
+func TypeCheck(files map[string][]byte, opts LoadOptions) error {
+	overlay := make(map[string][]byte, len(opts.Overlay)+len(files))
+	for name, content := range opts.Overlay {
+		overlay[name] = content
+	}
+	checked := make(map[string]bool, len(files))
+	var dirs []string
+	for name, content := range files {
+		abs, err := filepath.Abs(name)
+		if err != nil {
+			return err
+		}
+		overlay[abs] = content
+		checked[abs] = true
+		if dir := filepath.Dir(abs); !contains(dirs, dir) {
+			dirs = append(dirs, dir)
+		}
+	}
+	if len(dirs) == 0 {
+		return nil
+	}
+	sort.Strings(dirs)
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedTypes,
+		// The packages are loaded in the module of the files, which may
+		// not be that of the working directory.
+		Dir:     dirs[0],
+		Env:     opts.env(),
+		Context: opts.Context,
+		Overlay: overlay,
+		Tests:   true,
+	}, dirs...)
+	if err != nil {
+		return err
+	}
+
+	var errs []string
+	seen := make(map[string]bool)
+	for _, pkg := range pkgs {
+		if !hasErrorIn(pkg, checked) {
+			continue
+		}
+		// The errors of go list repeat those of the compiler, at the
+		// temporary paths of the overlaid files.
+		pkgErrs := pkg.Errors
+		if compiled := compilerErrors(pkg); len(compiled) != 0 {
+			pkgErrs = compiled
+		}
+		for _, e := range pkgErrs {
+			if !seen[e.Error()] {
+				seen[e.Error()] = true
+				errs = append(errs, e.Error())
+			}
+		}
+	}
+	if len(errs) != 0 {
+		return &BuildError{Errors: errs}
+	}
+	return nil
+}
+
+// hasErrorIn returns whether the package has an error in one of the
+// files.
+func hasErrorIn(pkg *packages.Package, files map[string]bool) bool {
+	for _, e := range pkg.Errors {
+		if files[posFile(e.Pos)] {
+			return true
+		}
+	}
+	return false
+}
+
+// compilerErrors returns the errors of the package other than those of
+// go list.
+func compilerErrors(pkg *packages.Package) []packages.Error {
+	var errs []packages.Error
+	for _, e := range pkg.Errors {
+		if e.Kind != packages.ListError {
+			errs = append(errs, e)
+		}
+	}
+	return errs
+}
+
+// posFile returns the file of a position in the format 'file:line:col'
+// or 'file:line'.
+func posFile(pos string) string {
+	for i := 0; i < 2; i++ {
+		j := strings.LastIndex(pos, ":")
+		if j < 0 {
+			break
+		}
+		if _, err := strconv.Atoi(pos[j+1:]); err != nil {
+			break
+		}
+		pos = pos[:j]
+	}
+	return pos
+}
+
+func contains(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,30 @@
+package registry
+
+import (
+	"go/token"
+	"go/types"
+	"strings"
+)
+
+// TypeParams returns the type parameters of the generic type, in the
+// format '[K comparable, V any]', along with their use as type arguments,
+// ex: '[K, V]'. The packages of their constraints are imported. Both are
+// empty for the types which aren't generic.
+func (r *Registry) TypeParams(t types.Type) (params, args string) {
+	named, ok := t.(*types.Named)
+	if !ok || named.TypeParams().Len() == 0 {
+		return "", ""
+	}
+
+	scope := r.MethodScope()
+	tparams := named.TypeParams()
+	decls := make([]string, tparams.Len())
+	names := make([]string, tparams.Len())
+	for i := range decls {
+		tp := tparams.At(i)
+		constraint := scope.AddVar(types.NewVar(token.NoPos, nil, "", tp.Constraint()), "")
+		names[i] = tp.Obj().Name()
+		decls[i] = names[i] + " " + constraint.TypeString()
+	}
+	return "[" + strings.Join(decls, ", ") + "]", "[" + strings.Join(names, ", ") + "]"
+}
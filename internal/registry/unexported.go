@@ -0,0 +1,19 @@
+package registry
+
+import "go/types"
+
+// UnexportedType returns the first unexported type of another package
+// than the output package the type refers to, in the format 'pkg.name',
+// and the path of its package, if any. Mocks can't name such types, and
+// the type must be exported or the mock generated in its package.
+func (r Registry) UnexportedType(t types.Type) (name, pkgPath string, ok bool) {
+	var path string
+	name, ok = findTypeName(t, func(obj *types.TypeName) (string, bool) {
+		if obj.Exported() || obj.Pkg() == nil || stripVendorPath(obj.Pkg().Path()) == r.miripPkgPath {
+			return "", false
+		}
+		path = stripVendorPath(obj.Pkg().Path())
+		return obj.Pkg().Name() + "." + obj.Name(), true
+	})
+	return name, path, ok
+}
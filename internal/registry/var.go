@@ -22,12 +22,150 @@ func (v Var) IsSlice() bool {
 	return ok
 }
 
+// IsContext returns whether the type is context.Context.
+func (v Var) IsContext() bool {
+	named, ok := v.vr.Type().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "context" && named.Obj().Name() == "Context"
+}
+
+// IsTime returns whether the type is time.Time.
+func (v Var) IsTime() bool {
+	named, ok := v.vr.Type().(*types.Named)
+	return ok && named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "time" && named.Obj().Name() == "Time"
+}
+
+// IsAnonymous returns whether the type is an anonymous struct with fields
+// or an anonymous interface with methods.
+func (v Var) IsAnonymous() bool {
+	switch t := v.vr.Type().(type) {
+	case *types.Struct:
+		return t.NumFields() > 0
+	case *types.Interface:
+		return t.NumMethods() > 0
+	}
+	return false
+}
+
+// IsTypeParam returns whether the type is a type parameter, whose values
+// are only known at run time.
+func (v Var) IsTypeParam() bool {
+	_, ok := v.vr.Type().(*types.TypeParam)
+	return ok
+}
+
+// IsAny returns whether the type is an empty interface, such as any,
+// which values of any type are assigned to.
+func (v Var) IsAny() bool {
+	return isAny(v.vr.Type())
+}
+
+// IsAnySlice returns whether the type is a slice of an empty interface,
+// such as []any.
+func (v Var) IsAnySlice() bool {
+	s, ok := v.vr.Type().Underlying().(*types.Slice)
+	return ok && isAny(s.Elem())
+}
+
+func isAny(t types.Type) bool {
+	if _, ok := t.(*types.TypeParam); ok {
+		return false
+	}
+	iface, ok := t.Underlying().(*types.Interface)
+	return ok && iface.Empty()
+}
+
 // TypeString returns the variable type with the package qualifier in the
 // format 'pkg.Type'.
 func (v Var) TypeString() string {
 	return types.TypeString(v.vr.Type(), v.packageQualifier)
 }
 
+// SeqTypes returns the types of the values yielded by an iter.Seq or an
+// iter.Seq2, in the format 'pkg.Type', if the variable is one of them.
+func (v Var) SeqTypes() []string {
+	named, ok := v.vr.Type().(*types.Named)
+	if !ok || named.Obj().Pkg() == nil || named.Obj().Pkg().Path() != "iter" {
+		return nil
+	}
+	if name := named.Obj().Name(); name != "Seq" && name != "Seq2" {
+		return nil
+	}
+
+	args := named.TypeArgs()
+	elems := make([]string, args.Len())
+	for i := range elems {
+		elems[i] = types.TypeString(args.At(i), v.packageQualifier)
+	}
+	return elems
+}
+
+// ChanElem returns the element type of a channel which values can be
+// received from, in the format 'pkg.Type', if the variable is one. Named
+// channel types are left out.
+func (v Var) ChanElem() (string, bool) {
+	ch, ok := v.vr.Type().(*types.Chan)
+	if !ok || ch.Dir() == types.SendOnly {
+		return "", false
+	}
+	return types.TypeString(ch.Elem(), v.packageQualifier), true
+}
+
+// Encodable returns whether values of the variable type can be encoded
+// as JSON and decoded back, which rules out functions, channels, unsafe
+// pointers and interfaces. The types holding functions, channels or
+// unsafe pointers, such as []func(T) U or the instantiations of generic
+// types with function type arguments, are ruled out too unless they
+// encode themselves, while those holding interfaces encode their dynamic
+// values.
+func (v Var) Encodable() bool {
+	if types.IsInterface(v.vr.Type()) {
+		return false
+	}
+	return encodable(v.vr.Type(), make(map[types.Type]bool))
+}
+
+// encodable returns whether values of the type can be encoded as JSON.
+// The types already seen, of recursive types, are assumed to be.
+func encodable(t types.Type, seen map[types.Type]bool) bool {
+	if seen[t] {
+		return true
+	}
+	seen[t] = true
+	if named, ok := t.(*types.Named); ok && encodesItself(named) {
+		return true
+	}
+
+	switch t := t.Underlying().(type) {
+	case *types.Signature, *types.Chan:
+		return false
+	case *types.Basic:
+		return t.Kind() != types.UnsafePointer
+	case *types.Pointer:
+		return encodable(t.Elem(), seen)
+	case *types.Slice:
+		return encodable(t.Elem(), seen)
+	case *types.Array:
+		return encodable(t.Elem(), seen)
+	case *types.Map:
+		return encodable(t.Key(), seen) && encodable(t.Elem(), seen)
+	case *types.Struct:
+		// Unexported fields aren't encoded.
+		for i := 0; i < t.NumFields(); i++ {
+			if field := t.Field(i); (field.Exported() || field.Embedded()) && !encodable(field.Type(), seen) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// encodesItself returns whether the named type, or the pointer to it,
+// has both the MarshalJSON and UnmarshalJSON methods.
+func encodesItself(named *types.Named) bool {
+	mset := types.NewMethodSet(types.NewPointer(named))
+	return mset.Lookup(nil, "MarshalJSON") != nil && mset.Lookup(nil, "UnmarshalJSON") != nil
+}
+
 // packageQualifier is a types.Qualifier.
 func (v Var) packageQualifier(pkg *types.Package) string {
 	path := stripVendorPath(pkg.Path())
@@ -39,7 +177,9 @@ func (v Var) packageQualifier(pkg *types.Package) string {
 }
 
 // varNameForType generates a name for the variable using the type
-// information.
+// information. Well-known types get the name they are keyed by in the
+// type names of the scope, while the conflict suffix is appended to the
+// names which would shadow their type.
 //
 // Examples:
 // - string -> s
@@ -49,12 +189,25 @@ func (v Var) packageQualifier(pkg *types.Package) string {
 // - map[string]int -> stringToInt
 // - error -> err
 // - a.MyType -> myType
-func varNameForType(t types.Type) string {
+// - context.Context -> ctx
+func (m MethodScope) varNameForType(t types.Type) string {
+	if name, ok := m.typeNames[typeKey(t)]; ok {
+		return name
+	}
+
 	nestedType := func(t types.Type) string {
 		if t, ok := t.(*types.Basic); ok {
 			return deCapitalise(t.String())
 		}
-		return varNameForType(t)
+		return m.varNameForType(t)
+	}
+
+	if a, ok := asAlias(t); ok {
+		// Aliases of the universe, such as any, are named after their type.
+		if a.Obj().Pkg() == nil {
+			return m.varNameForType(a.Underlying())
+		}
+		return m.typeNameVar(a.Obj())
 	}
 
 	switch t := t.(type) {
@@ -63,12 +216,7 @@ func varNameForType(t types.Type) string {
 			return "err"
 		}
 
-		name := deCapitalise(t.Obj().Name())
-		if name == t.Obj().Name() {
-			name += "MiripParam"
-		}
-
-		return name
+		return m.typeNameVar(t.Obj())
 
 	case *types.Basic:
 		return basicTypeVarName(t)
@@ -83,7 +231,7 @@ func varNameForType(t types.Type) string {
 		return "val"
 
 	case *types.Pointer:
-		return varNameForType(t.Elem())
+		return m.varNameForType(t.Elem())
 
 	case *types.Signature:
 		return "fn"
@@ -101,6 +249,34 @@ func varNameForType(t types.Type) string {
 	return "v"
 }
 
+// typeNameVar returns the name of a variable of the named type or alias,
+// which must not shadow it.
+func (m MethodScope) typeNameVar(obj *types.TypeName) string {
+	name := deCapitalise(obj.Name())
+	if name == obj.Name() {
+		name += m.conflictSuffix
+	}
+	return name
+}
+
+// typeKey returns the key of the named type or alias, or pointer to one,
+// in type names, in the format 'path.Name' or '*path.Name'. It returns an
+// empty string for other types.
+func typeKey(t types.Type) string {
+	if p, ok := t.(*types.Pointer); ok {
+		if key := typeKey(p.Elem()); key != "" {
+			return "*" + key
+		}
+		return ""
+	}
+
+	named, ok := t.(interface{ Obj() *types.TypeName })
+	if !ok || named.Obj().Pkg() == nil {
+		return ""
+	}
+	return stripVendorPath(named.Obj().Pkg().Path()) + "." + named.Obj().Name()
+}
+
 func basicTypeVarName(b *types.Basic) string {
 	switch b.Info() {
 	case types.IsBoolean:
@@ -0,0 +1,99 @@
+package registry
+
+import (
+	"go/ast"
+	"go/build/constraint"
+	"go/parser"
+	"go/token"
+	"strings"
+)
+
+// Variant is a declaration of a name in a file of the source package
+// which the build configuration of the load excludes, such as a file of
+// other build tags or of another platform.
+type Variant struct {
+	Pos token.Position
+
+	// Constraint is the expression of the go:build line of the file,
+	// empty when only its name constrains it, such as conn_windows.go.
+	Constraint string
+}
+
+func (v Variant) String() string {
+	if v.Constraint == "" {
+		return v.Pos.String() + " (constrained by its file name)"
+	}
+	return v.Pos.String() + " (//go:build " + v.Constraint + ")"
+}
+
+// Variants returns the declarations of the type of the given name in the
+// files of the source package which its build configuration excludes, in
+// the order of the files, for the interfaces declared once per build tag
+// or platform to be told apart. Files which can't be parsed, or belong to
+// another package such as those of go:build ignore, are skipped.
+func (r Registry) Variants(name string) []Variant {
+	var variants []Variant
+	fset := token.NewFileSet()
+	for _, filename := range r.srcPkg.IgnoredFiles {
+		if !strings.HasSuffix(filename, ".go") || strings.HasSuffix(filename, "_test.go") {
+			continue
+		}
+		var src interface{}
+		if content, ok := r.loadOpts.Overlay[filename]; ok {
+			src = content
+		}
+		file, err := parser.ParseFile(fset, filename, src, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil || file.Name.Name != r.srcPkg.Name {
+			continue
+		}
+
+		for _, decl := range file.Decls {
+			gen, ok := decl.(*ast.GenDecl)
+			if !ok || gen.Tok != token.TYPE {
+				continue
+			}
+			for _, spec := range gen.Specs {
+				if ts := spec.(*ast.TypeSpec); ts.Name.Name == name {
+					variants = append(variants, Variant{Pos: fset.Position(ts.Name.Pos()), Constraint: buildConstraint(file)})
+				}
+			}
+		}
+	}
+	return variants
+}
+
+// DeclConstraint returns the expression of the go:build line of the file
+// of the source package declaring the given name, "" if it has none.
+func (r Registry) DeclConstraint(name string) string {
+	filename, ok := r.DeclFile(name)
+	if !ok {
+		return ""
+	}
+	var src interface{}
+	if content, ok := r.loadOpts.Overlay[filename]; ok {
+		src = content
+	}
+	file, err := parser.ParseFile(token.NewFileSet(), filename, src, parser.PackageClauseOnly|parser.ParseComments)
+	if err != nil {
+		return ""
+	}
+	return buildConstraint(file)
+}
+
+// buildConstraint returns the expression of the go:build line of the
+// file, if any.
+func buildConstraint(file *ast.File) string {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			break
+		}
+		for _, c := range group.List {
+			if constraint.IsGoBuild(c.Text) {
+				if expr, err := constraint.Parse(c.Text); err == nil {
+					return expr.String()
+				}
+			}
+		}
+	}
+	return ""
+}
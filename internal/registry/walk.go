@@ -0,0 +1,64 @@
+package registry
+
+import "go/types"
+
+// findTypeName returns the first result of match for the type names the
+// type refers to, such as in the parameters of a signature or the type
+// arguments of an instance, if any matched. The underlying types of named
+// types are not searched, as a reference to a named type only needs its
+// name.
+func findTypeName(t types.Type, match func(obj *types.TypeName) (string, bool)) (string, bool) {
+	if a, ok := asAlias(t); ok {
+		return match(a.Obj())
+	}
+
+	switch t := t.(type) {
+	case *types.Named:
+		if s, ok := match(t.Obj()); ok {
+			return s, true
+		}
+		args := t.TypeArgs()
+		for i := 0; i < args.Len(); i++ {
+			if s, ok := findTypeName(args.At(i), match); ok {
+				return s, true
+			}
+		}
+	case *types.Pointer:
+		return findTypeName(t.Elem(), match)
+	case *types.Slice:
+		return findTypeName(t.Elem(), match)
+	case *types.Array:
+		return findTypeName(t.Elem(), match)
+	case *types.Chan:
+		return findTypeName(t.Elem(), match)
+	case *types.Map:
+		if s, ok := findTypeName(t.Key(), match); ok {
+			return s, true
+		}
+		return findTypeName(t.Elem(), match)
+	case *types.Tuple:
+		for i := 0; i < t.Len(); i++ {
+			if s, ok := findTypeName(t.At(i).Type(), match); ok {
+				return s, true
+			}
+		}
+	case *types.Signature:
+		if s, ok := findTypeName(t.Params(), match); ok {
+			return s, true
+		}
+		return findTypeName(t.Results(), match)
+	case *types.Struct:
+		for i := 0; i < t.NumFields(); i++ {
+			if s, ok := findTypeName(t.Field(i).Type(), match); ok {
+				return s, true
+			}
+		}
+	case *types.Interface:
+		for i := 0; i < t.NumMethods(); i++ {
+			if s, ok := findTypeName(t.Method(i).Type(), match); ok {
+				return s, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,335 @@
+package suggest
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// Undirected is an interface mocked in a file generated by mirip which no
+// go:generate directive of the module regenerates, so the mock rots once
+// the interface changes.
+type Undirected struct {
+	// Interface is the interface mocked.
+	Interface *types.TypeName
+
+	// Pos is the position of the declaration of the interface.
+	Pos token.Position
+
+	// MockFile is the absolute path of the file of the mock, and MockPkg
+	// the name of its package.
+	MockFile string
+	MockPkg  string
+
+	// args are the arguments of mirip recorded in the stamp of the mock,
+	// empty if it has none.
+	args string
+}
+
+// Fix inserts a go:generate directive into the file declaring mocked
+// interfaces.
+type Fix struct {
+	// File is the absolute path of the file declaring the interfaces.
+	File string
+
+	// Directive is the go:generate comment regenerating their mock.
+	Directive string
+}
+
+// command is a go:generate directive running mirip.
+type command struct {
+	dir  string
+	args []string
+}
+
+// mocked is an interface mocked in a file generated by mirip.
+type mocked struct {
+	iface string
+	file  string
+	pkg   string
+	args  string
+}
+
+// FindUndirected loads the packages of the module in dir along with their
+// tests and returns the interfaces declared there which are mocked by
+// files generated by mirip, without a go:generate directive either
+// writing the mock file or naming the interface and its package. The
+// mocked interfaces are read from the stamp of the mocks, or from their
+// assertions when they have none.
+func FindUndirected(dir string) ([]Undirected, error) {
+	pkgs, err := loadModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		commands []command
+		mocks    []mocked
+		decls    = make(map[string]*types.TypeName)
+		pos      = make(map[*types.TypeName]token.Position)
+		seen     = make(map[string]bool)
+	)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Pos()).Filename
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			commands = append(commands, directives(file, filepath.Dir(name))...)
+			if !isMiripGenerated(file) {
+				for _, obj := range declaredInterfaces(pkg, file) {
+					decls[obj.Pkg().Path()+"."+obj.Name()] = obj
+					pos[obj] = pkg.Fset.Position(obj.Pos())
+				}
+				continue
+			}
+			args, sources := stamp(file)
+			if len(sources) == 0 {
+				sources = assertedInterfaces(pkg, file)
+			}
+			for _, src := range sources {
+				mocks = append(mocks, mocked{iface: src, file: name, pkg: pkg.Name, args: args})
+			}
+		}
+	}
+
+	var undirected []Undirected
+	reported := make(map[string]bool)
+	for _, m := range mocks {
+		obj, ok := decls[m.iface]
+		if !ok || reported[m.iface+" "+m.file] {
+			continue
+		}
+		if directed(commands, m.file, obj.Name(), filepath.Dir(pos[obj].Filename)) {
+			continue
+		}
+		reported[m.iface+" "+m.file] = true
+		undirected = append(undirected, Undirected{
+			Interface: obj,
+			Pos:       pos[obj],
+			MockFile:  m.file,
+			MockPkg:   m.pkg,
+			args:      m.args,
+		})
+	}
+
+	sort.Slice(undirected, func(i, j int) bool {
+		a, b := undirected[i], undirected[j]
+		if a.Pos.Filename != b.Pos.Filename {
+			return a.Pos.Filename < b.Pos.Filename
+		}
+		if a.Pos.Line != b.Pos.Line {
+			return a.Pos.Line < b.Pos.Line
+		}
+		return a.MockFile < b.MockFile
+	})
+	return undirected, nil
+}
+
+// Fixes returns the go:generate directives regenerating the mocks of the
+// undirected interfaces, one per mock file and package of interfaces,
+// inserted into the file declaring the first of the interfaces. The
+// directive repeats the arguments recorded in the stamp of the mock when
+// they write the mock file from there, and otherwise mocks the interfaces
+// with the default flags.
+func Fixes(undirected []Undirected) []Fix {
+	var fixes []Fix
+	var directives []*Directive
+	index := make(map[string]int)
+	for _, u := range undirected {
+		srcDir := filepath.Dir(u.Pos.Filename)
+		key := u.MockFile + " " + srcDir
+		i, ok := index[key]
+		if !ok {
+			outFile, err := filepath.Rel(srcDir, u.MockFile)
+			if err != nil {
+				continue
+			}
+			i = len(fixes)
+			index[key] = i
+			fixes = append(fixes, Fix{File: u.Pos.Filename})
+			directives = append(directives, &Directive{
+				PkgName: u.MockPkg,
+				SrcDir:  ".",
+				OutFile: filepath.ToSlash(outFile),
+			})
+			if u.args != "" && writes(strings.Fields(u.args), srcDir, u.MockFile) {
+				fixes[i].Directive = "//go:generate mirip " + u.args
+			}
+		}
+		directives[i].Interfaces = append(directives[i].Interfaces, u.Interface.Name())
+	}
+
+	for i, d := range directives {
+		if fixes[i].Directive != "" {
+			continue
+		}
+		sort.Strings(d.Interfaces)
+		fixes[i].Directive = d.String()
+	}
+	return fixes
+}
+
+// Apply returns the content of the file with the directive inserted after
+// its package clause.
+func (f Fix) Apply(content []byte) ([]byte, error) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, f.File, content, parser.PackageClauseOnly)
+	if err != nil {
+		return nil, err
+	}
+	offset := fset.Position(file.Name.End()).Offset
+
+	fixed := make([]byte, 0, len(content)+len(f.Directive)+2)
+	fixed = append(fixed, content[:offset]...)
+	fixed = append(fixed, "\n\n"+f.Directive...)
+	return append(fixed, content[offset:]...), nil
+}
+
+// directives returns the go:generate directives of the file running
+// mirip, either installed or with go run.
+func directives(file *ast.File, dir string) []command {
+	var commands []command
+	for _, group := range file.Comments {
+		for _, comment := range group.List {
+			if !strings.HasPrefix(comment.Text, "//go:generate ") {
+				continue
+			}
+			fields := strings.Fields(strings.TrimPrefix(comment.Text, "//go:generate "))
+			if len(fields) > 2 && fields[0] == "go" && fields[1] == "run" {
+				fields = fields[2:]
+			}
+			if len(fields) == 0 || !isMirip(fields[0]) {
+				continue
+			}
+			commands = append(commands, command{dir: dir, args: fields[1:]})
+		}
+	}
+	return commands
+}
+
+// isMirip reports whether the program of a directive is mirip, such as
+// mirip.exe or github.com/gmhafiz/mirip/cmd/mirip@latest.
+func isMirip(program string) bool {
+	program, _, _ = strings.Cut(program, "@")
+	program = strings.TrimSuffix(filepath.Base(filepath.FromSlash(program)), ".exe")
+	return program == "mirip"
+}
+
+// directed reports whether one of the commands writes the mock file, or
+// names the interface along with the directory of its package.
+func directed(commands []command, mockFile, iface, srcDir string) bool {
+	for _, c := range commands {
+		if writes(c.args, c.dir, mockFile) {
+			return true
+		}
+
+		var named, inDir bool
+		for _, arg := range c.args {
+			for _, piece := range strings.FieldsFunc(arg, func(r rune) bool { return r == '=' || r == ',' }) {
+				name, _, _ := mirip.ParseNamePair(piece)
+				name, _, _ = strings.Cut(name, "[")
+				named = named || name == iface
+				inDir = inDir || !strings.HasPrefix(piece, "-") && filepath.Join(c.dir, piece) == srcDir
+			}
+		}
+		if named && inDir {
+			return true
+		}
+	}
+	return false
+}
+
+// writes reports whether the arguments of mirip, run from dir, have -out
+// writing the file.
+func writes(args []string, dir, file string) bool {
+	for i, arg := range args {
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if !strings.HasPrefix(arg, "-") || name != "out" {
+			continue
+		}
+		if !hasValue {
+			if i+1 == len(args) {
+				return false
+			}
+			value = args[i+1]
+		}
+		if filepath.Join(dir, filepath.FromSlash(strings.Trim(value, "'"))) == file {
+			return true
+		}
+	}
+	return false
+}
+
+// isMiripGenerated reports whether the file has the header of the files
+// generated by mirip.
+func isMiripGenerated(file *ast.File) bool {
+	if !isGenerated(file) || len(file.Comments) == 0 {
+		return false
+	}
+	return file.Comments[0].List[0].Text == "// Code generated by mirip; DO NOT EDIT."
+}
+
+// stamp returns the arguments and the mocked interfaces recorded in the
+// stamp of a file generated by mirip, in the format 'path.Interface'.
+func stamp(file *ast.File) (string, []string) {
+	var args string
+	var sources []string
+	for _, comment := range file.Comments[0].List {
+		switch text := comment.Text; {
+		case strings.HasPrefix(text, "// mirip:source "):
+			sources = append(sources, strings.TrimPrefix(text, "// mirip:source "))
+		case strings.HasPrefix(text, "// mirip:args "):
+			args = strings.TrimPrefix(text, "// mirip:args ")
+		}
+	}
+	return args, sources
+}
+
+// assertedInterfaces returns the interfaces of the assertions of the file,
+// blank variables such as 'var _ Interface = &InterfaceMock{}', in the
+// format 'path.Interface'.
+func assertedInterfaces(pkg *packages.Package, file *ast.File) []string {
+	var sources []string
+	ast.Inspect(file, func(n ast.Node) bool {
+		spec, ok := n.(*ast.ValueSpec)
+		if !ok || spec.Type == nil || len(spec.Names) != 1 || spec.Names[0].Name != "_" {
+			return true
+		}
+		t := pkg.TypesInfo.TypeOf(spec.Type)
+		if named, ok := t.(*types.Named); ok && types.IsInterface(named) && named.Obj().Pkg() != nil {
+			sources = append(sources, named.Obj().Pkg().Path()+"."+named.Obj().Name())
+		}
+		return true
+	})
+	return sources
+}
+
+// declaredInterfaces returns the interfaces declared at the top level of
+// the file.
+func declaredInterfaces(pkg *packages.Package, file *ast.File) []*types.TypeName {
+	var objs []*types.TypeName
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			obj, ok := pkg.TypesInfo.Defs[spec.(*ast.TypeSpec).Name].(*types.TypeName)
+			if ok && !obj.IsAlias() && types.IsInterface(obj.Type()) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return objs
+}
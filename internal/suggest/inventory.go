@@ -0,0 +1,274 @@
+package suggest
+
+import (
+	"bytes"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Mocked is an interface mocked by files generated by mirip in a module.
+type Mocked struct {
+	// Interface is the interface mocked, in the format 'path.Name'.
+	Interface string
+
+	// Pos is the position of the declaration of the interface, zero if
+	// it isn't declared in the module.
+	Pos token.Position
+
+	// Methods are the signatures of the methods of the interface, empty
+	// if it can't be resolved from the packages of the module.
+	Methods []string
+
+	// Mocks are the mocks of the interface.
+	Mocks []Mock
+}
+
+// Mock is a type generated by mirip for an interface.
+type Mock struct {
+	// Name is the name of the type, and File the absolute path of the file
+	// declaring it.
+	Name string
+	File string
+
+	// UsedBy are the absolute paths of the test files of the module
+	// naming the type.
+	UsedBy []string
+}
+
+// Inventory loads the packages of the module in dir along with their
+// tests and returns the interfaces mocked by files generated by mirip,
+// sorted by interface. The mocked interfaces are read from the stamp of
+// the mocks, or from their assertions when they have none. The mocks of
+// an interface are the types of these files implementing it, preferably
+// named after it, and the tests using them those naming them, found by a
+// plain search of the test files.
+func Inventory(dir string) ([]Mocked, error) {
+	pkgs, err := loadModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tests    []string
+		decls    = make(map[string]*types.TypeName)
+		byIface  = make(map[string]*Mocked)
+		mockKeys = make(map[string]bool)
+		seen     = make(map[string]bool)
+	)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Pos()).Filename
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			if strings.HasSuffix(name, "_test.go") {
+				tests = append(tests, name)
+			}
+			if !isMiripGenerated(file) {
+				for _, obj := range declaredInterfaces(pkg, file) {
+					decls[obj.Pkg().Path()+"."+obj.Name()] = obj
+				}
+				continue
+			}
+			_, sources := stamp(file)
+			if len(sources) == 0 {
+				sources = assertedInterfaces(pkg, file)
+			}
+			for _, src := range sources {
+				m, ok := byIface[src]
+				if !ok {
+					m = &Mocked{Interface: src}
+					byIface[src] = m
+				}
+				named := lookupNamed(pkg.Types, src)
+				for _, mockName := range mocksOf(pkg, file, named) {
+					if key := name + " " + mockName; !mockKeys[key] {
+						mockKeys[key] = true
+						m.Mocks = append(m.Mocks, Mock{Name: mockName, File: name})
+					}
+				}
+				if m.Methods == nil && named != nil {
+					m.Methods = methodSignatures(named)
+				}
+			}
+		}
+	}
+
+	mocked := make([]Mocked, 0, len(byIface))
+	for src, m := range byIface {
+		if obj, ok := decls[src]; ok {
+			m.Pos = pkgs[0].Fset.Position(obj.Pos())
+			if m.Methods == nil {
+				m.Methods = methodSignatures(obj.Type().(*types.Named))
+			}
+		}
+		for i := range m.Mocks {
+			usedBy, err := usingFiles(tests, m.Mocks[i])
+			if err != nil {
+				return nil, err
+			}
+			m.Mocks[i].UsedBy = usedBy
+		}
+		mocked = append(mocked, *m)
+	}
+	sort.Slice(mocked, func(i, j int) bool {
+		return mocked[i].Interface < mocked[j].Interface
+	})
+	return mocked, nil
+}
+
+// loadModule loads the packages of the module in dir along with their
+// tests, failing on the first package with errors.
+func loadModule(dir string) ([]*packages.Package, error) {
+	return loadPackages(dir, "./...")
+}
+
+// loadPackages loads the packages of the patterns, relative to dir, along
+// with their tests, failing on the first package with errors.
+func loadPackages(dir string, patterns ...string) ([]*packages.Package, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedModule,
+		Dir:   absDir,
+		Tests: true,
+	}, patterns...)
+	if err != nil {
+		return nil, err
+	}
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 {
+			return nil, pkg.Errors[0]
+		}
+	}
+	return pkgs, nil
+}
+
+// lookupNamed returns the named type of the given qualified name, in the
+// format 'path.Name', declared in the package or one it imports, or nil.
+func lookupNamed(pkg *types.Package, qualified string) *types.Named {
+	i := strings.LastIndex(qualified, ".")
+	if i < 0 {
+		return nil
+	}
+	path, name := qualified[:i], qualified[i+1:]
+
+	scopes := []*types.Package{pkg}
+	scopes = append(scopes, pkg.Imports()...)
+	for _, p := range scopes {
+		if p.Path() != path {
+			continue
+		}
+		if obj, ok := p.Scope().Lookup(name).(*types.TypeName); ok {
+			named, _ := obj.Type().(*types.Named)
+			return named
+		}
+	}
+	return nil
+}
+
+// mocksOf returns the names of the types declared in the file which
+// implement the interface, keeping those named after it if any is. The
+// pointers to the types of generic interfaces only need to have its
+// methods, as their type arguments aren't known.
+func mocksOf(pkg *packages.Package, file *ast.File, named *types.Named) []string {
+	if named == nil || !types.IsInterface(named) {
+		return nil
+	}
+	iface := named.Underlying().(*types.Interface)
+	if iface.NumMethods() == 0 {
+		return nil
+	}
+
+	implements := func(t types.Type) bool {
+		if named.TypeParams().Len() == 0 {
+			return types.Implements(types.NewPointer(t), iface)
+		}
+		mset := types.NewMethodSet(types.NewPointer(t))
+		for i := 0; i < iface.NumMethods(); i++ {
+			if mset.Lookup(named.Obj().Pkg(), iface.Method(i).Name()) == nil {
+				return false
+			}
+		}
+		return true
+	}
+
+	var all, after []string
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			obj, ok := pkg.TypesInfo.Defs[spec.(*ast.TypeSpec).Name].(*types.TypeName)
+			if !ok || obj.IsAlias() || types.IsInterface(obj.Type()) || !implements(obj.Type()) {
+				continue
+			}
+			all = append(all, obj.Name())
+			if strings.Contains(obj.Name(), named.Obj().Name()) {
+				after = append(after, obj.Name())
+			}
+		}
+	}
+	if len(after) != 0 {
+		return after
+	}
+	return all
+}
+
+// methodSignatures returns the signatures of the methods of the named
+// interface, such as 'Get(ctx context.Context, key string) (string,
+// error)', with the types qualified by package name.
+func methodSignatures(named *types.Named) []string {
+	iface, ok := named.Underlying().(*types.Interface)
+	if !ok {
+		return nil
+	}
+	qualifier := func(p *types.Package) string {
+		if p == named.Obj().Pkg() {
+			return ""
+		}
+		return p.Name()
+	}
+
+	methods := make([]string, iface.NumMethods())
+	for i := range methods {
+		m := iface.Method(i)
+		methods[i] = m.Name() + strings.TrimPrefix(types.TypeString(m.Type(), qualifier), "func")
+	}
+	return methods
+}
+
+// usingFiles returns the test files naming the mock, other than its own.
+func usingFiles(tests []string, mock Mock) ([]string, error) {
+	word := regexp.MustCompile(`\b` + regexp.QuoteMeta(mock.Name) + `\b`)
+	var files []string
+	for _, name := range tests {
+		if name == mock.File {
+			continue
+		}
+		content, err := os.ReadFile(name)
+		if err != nil {
+			return nil, err
+		}
+		if bytes.Contains(content, []byte(mock.Name)) && word.Match(content) {
+			files = append(files, name)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
@@ -0,0 +1,106 @@
+package suggest
+
+import (
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Declared is an interface declared by a package, along with its size and
+// the mocks generated by mirip for it, for the metrics of the interfaces
+// of a codebase.
+type Declared struct {
+	// Interface is the interface, in the format 'path.Name', Package the
+	// path of the package declaring it and Pos the position of its
+	// declaration.
+	Interface string
+	Package   string
+	Pos       token.Position
+
+	// Methods is the number of methods of the interface, including those
+	// of the interfaces it embeds.
+	Methods int
+
+	// Mocks are the names of the types of the files generated by mirip
+	// mocking the interface.
+	Mocks []string
+}
+
+// Interfaces loads the packages of the patterns, relative to dir, along
+// with their tests and returns the interfaces declared by their files
+// other than tests and those generated, sorted by package and name. The
+// constraints, whose type set isn't a method set, are left out as they
+// can't be mocked. The mocks are those of the files generated by mirip
+// among the loaded packages only, found like Inventory, so that the
+// patterns should include the packages of the mocks, such as './...'.
+func Interfaces(dir string, patterns ...string) ([]Declared, error) {
+	pkgs, err := loadPackages(dir, patterns...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		declared []Declared
+		byIface  = make(map[string]int)
+		mocks    = make(map[string][]string)
+		mockKeys = make(map[string]bool)
+		seen     = make(map[string]bool)
+	)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Pos()).Filename
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			if isMiripGenerated(file) {
+				_, sources := stamp(file)
+				if len(sources) == 0 {
+					sources = assertedInterfaces(pkg, file)
+				}
+				for _, src := range sources {
+					for _, mockName := range mocksOf(pkg, file, lookupNamed(pkg.Types, src)) {
+						if key := name + " " + mockName; !mockKeys[key] {
+							mockKeys[key] = true
+							mocks[src] = append(mocks[src], mockName)
+						}
+					}
+				}
+				continue
+			}
+			if strings.HasSuffix(name, "_test.go") || isGenerated(file) {
+				continue
+			}
+			for _, obj := range declaredInterfaces(pkg, file) {
+				iface := obj.Type().Underlying().(*types.Interface)
+				key := obj.Pkg().Path() + "." + obj.Name()
+				if _, ok := byIface[key]; ok || !iface.IsMethodSet() {
+					continue
+				}
+				byIface[key] = len(declared)
+				declared = append(declared, Declared{
+					Interface: key,
+					Package:   obj.Pkg().Path(),
+					Pos:       pkg.Fset.Position(obj.Pos()),
+					Methods:   iface.NumMethods(),
+				})
+			}
+		}
+	}
+
+	for src, names := range mocks {
+		if i, ok := byIface[src]; ok {
+			sort.Strings(names)
+			declared[i].Mocks = names
+		}
+	}
+	sort.Slice(declared, func(i, j int) bool {
+		if declared[i].Package != declared[j].Package {
+			return declared[i].Package < declared[j].Package
+		}
+		return declared[i].Interface < declared[j].Interface
+	})
+	return declared, nil
+}
@@ -0,0 +1,441 @@
+package suggest
+
+import (
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"golang.org/x/tools/go/packages"
+)
+
+// Suggestion is an interface which the tests of a package should mock
+// with mirip.
+type Suggestion struct {
+	// Interface is the interface to mock.
+	Interface *types.Named
+
+	// Reasons are the places in tests where the interface is hand-stubbed
+	// or left unset, in the format 'file:line: reason'.
+	Reasons []string
+
+	// PkgName is the name of the test package the mock belongs to.
+	PkgName string
+
+	// SrcDir is the directory of the package declaring the interface,
+	// relative to the analysed directory. It is empty when the package is
+	// outside of the module, in which case it can't be mocked from a
+	// go:generate directive.
+	SrcDir string
+}
+
+// Directive is a go:generate directive generating mocks of suggested
+// interfaces declared in the same package.
+type Directive struct {
+	PkgName    string
+	SrcDir     string
+	OutFile    string
+	Interfaces []string
+}
+
+// String returns the go:generate comment of the directive.
+func (d Directive) String() string {
+	return fmt.Sprintf("//go:generate mirip -pkg %s -out %s %s %s",
+		d.PkgName, d.OutFile, d.SrcDir, strings.Join(d.Interfaces, " "))
+}
+
+// Analyze loads the package in dir along with its tests and suggests the
+// interfaces its tests should mock. These are the interfaces the package
+// depends on, through the parameters of its functions and the fields of
+// its structs, which are either implemented by types declared in tests or
+// left unset by tests, with a nil argument or an omitted struct field.
+// Interfaces already mocked by generated test files are not suggested.
+func Analyze(dir string) ([]Suggestion, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	pkgs, err := packages.Load(&packages.Config{
+		Mode: packages.NeedName | packages.NeedFiles | packages.NeedSyntax | packages.NeedTypes |
+			packages.NeedTypesInfo | packages.NeedImports | packages.NeedDeps | packages.NeedModule,
+		Dir:   absDir,
+		Tests: true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var suggestions []Suggestion
+	var tested bool
+	for _, pkg := range pkgs {
+		if !hasTestFiles(pkg) {
+			continue
+		}
+		if len(pkg.Errors) != 0 {
+			return nil, pkg.Errors[0]
+		}
+		tested = true
+
+		a := analysis{pkg: pkg, dir: absDir, reasons: make(map[*types.TypeName][]string)}
+		suggestions = append(suggestions, a.run()...)
+	}
+	if !tested {
+		return nil, errors.New("package has no test files")
+	}
+
+	return suggestions, nil
+}
+
+// Directives groups the suggestions into the go:generate directives
+// mocking them. Suggestions without a source dir are skipped.
+func Directives(suggestions []Suggestion) []Directive {
+	var directives []Directive
+	index := make(map[string]int)
+	for _, s := range suggestions {
+		if s.SrcDir == "" {
+			continue
+		}
+
+		key := s.PkgName + " " + s.SrcDir
+		i, ok := index[key]
+		if !ok {
+			i = len(directives)
+			index[key] = i
+			directives = append(directives, Directive{
+				PkgName: s.PkgName,
+				SrcDir:  s.SrcDir,
+				OutFile: s.Interface.Obj().Pkg().Name() + "_mock_test.go",
+			})
+		}
+		directives[i].Interfaces = append(directives[i].Interfaces, s.Interface.Obj().Name())
+	}
+
+	for i := range directives {
+		sort.Strings(directives[i].Interfaces)
+	}
+	return directives
+}
+
+// analysis is the analysis of a single test package.
+type analysis struct {
+	pkg *packages.Package
+	dir string
+
+	deps    []*types.Named
+	mocked  map[*types.TypeName]bool
+	reasons map[*types.TypeName][]string
+}
+
+func (a *analysis) run() []Suggestion {
+	a.deps = a.dependencies()
+	a.mocked = a.generatedMocks()
+
+	for _, file := range a.pkg.Syntax {
+		if !a.isTestFile(file) || isGenerated(file) {
+			continue
+		}
+		a.findStubs(file)
+		a.findUnset(file)
+	}
+
+	var suggestions []Suggestion
+	for _, dep := range a.deps {
+		reasons, ok := a.reasons[dep.Obj()]
+		if !ok || a.mocked[dep.Obj()] {
+			continue
+		}
+		suggestions = append(suggestions, Suggestion{
+			Interface: dep,
+			Reasons:   reasons,
+			PkgName:   a.pkg.Name,
+			SrcDir:    a.srcDir(dep.Obj().Pkg()),
+		})
+	}
+	return suggestions
+}
+
+// dependencies returns the interfaces among the parameters of functions
+// and the fields of structs declared in the non-test files of the
+// package under test, and of the package itself for external tests.
+func (a *analysis) dependencies() []*types.Named {
+	var deps []*types.Named
+	seen := make(map[*types.TypeName]bool)
+	add := func(t types.Type) {
+		named, ok := mockable(t)
+		if ok && !seen[named.Obj()] {
+			seen[named.Obj()] = true
+			deps = append(deps, named)
+		}
+	}
+
+	for _, pkg := range a.testedPackages() {
+		scope := pkg.Scope()
+		for _, name := range scope.Names() {
+			obj := scope.Lookup(name)
+			if a.isTestPos(obj.Pos()) {
+				continue
+			}
+
+			switch obj := obj.(type) {
+			case *types.Func:
+				sig := obj.Type().(*types.Signature)
+				for i := 0; i < sig.Params().Len(); i++ {
+					add(sig.Params().At(i).Type())
+				}
+
+			case *types.TypeName:
+				if st, ok := obj.Type().Underlying().(*types.Struct); ok {
+					for i := 0; i < st.NumFields(); i++ {
+						add(st.Field(i).Type())
+					}
+				}
+			}
+		}
+	}
+
+	sort.Slice(deps, func(i, j int) bool {
+		return deps[i].String() < deps[j].String()
+	})
+	return deps
+}
+
+// testedPackages returns the packages whose declarations are tested,
+// which for an external test package include the imported package under
+// test.
+func (a *analysis) testedPackages() []*types.Package {
+	pkgs := []*types.Package{a.pkg.Types}
+	if strings.HasSuffix(a.pkg.Name, "_test") {
+		tested := strings.TrimSuffix(a.pkg.PkgPath, "_test")
+		if imported, ok := a.pkg.Imports[tested]; ok {
+			pkgs = append(pkgs, imported.Types)
+		}
+	}
+	return pkgs
+}
+
+// generatedMocks returns the interfaces already implemented by types of
+// generated test files.
+func (a *analysis) generatedMocks() map[*types.TypeName]bool {
+	mocked := make(map[*types.TypeName]bool)
+	for _, file := range a.pkg.Syntax {
+		if !a.isTestFile(file) || !isGenerated(file) {
+			continue
+		}
+		for _, obj := range a.declaredTypes(file) {
+			for _, dep := range a.deps {
+				if implements(obj.Type(), dep) {
+					mocked[dep.Obj()] = true
+				}
+			}
+		}
+	}
+	return mocked
+}
+
+// findStubs records the dependencies implemented by types declared in
+// the test file.
+func (a *analysis) findStubs(file *ast.File) {
+	for _, obj := range a.declaredTypes(file) {
+		for _, dep := range a.deps {
+			if implements(obj.Type(), dep) {
+				a.addReason(dep, obj.Pos(), "%s hand-stubs %s", obj.Name(), dep)
+			}
+		}
+	}
+}
+
+// findUnset records the dependencies passed as nil to function calls and
+// omitted from keyed struct literals in the test file.
+func (a *analysis) findUnset(file *ast.File) {
+	ast.Inspect(file, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.CallExpr:
+			sig, ok := a.pkg.TypesInfo.TypeOf(n.Fun).(*types.Signature)
+			if !ok {
+				return true
+			}
+			for i, arg := range n.Args {
+				if i >= sig.Params().Len() || !a.isNil(arg) {
+					continue
+				}
+				if dep, ok := a.dependency(sig.Params().At(i).Type()); ok {
+					a.addReason(dep, arg.Pos(), "nil passed for %s to %s", dep, types.ExprString(n.Fun))
+				}
+			}
+
+		case *ast.CompositeLit:
+			st, ok := a.pkg.TypesInfo.TypeOf(n).Underlying().(*types.Struct)
+			if !ok || len(n.Elts) == 0 {
+				return true
+			}
+			set := make(map[string]bool)
+			for _, elt := range n.Elts {
+				kv, ok := elt.(*ast.KeyValueExpr)
+				if !ok {
+					return true
+				}
+				if key, ok := kv.Key.(*ast.Ident); ok && !a.isNil(kv.Value) {
+					set[key.Name] = true
+				}
+			}
+			for i := 0; i < st.NumFields(); i++ {
+				field := st.Field(i)
+				if set[field.Name()] {
+					continue
+				}
+				if dep, ok := a.dependency(field.Type()); ok {
+					a.addReason(dep, n.Pos(), "%s left unset in %s literal", field.Name(), types.ExprString(n.Type))
+				}
+			}
+		}
+		return true
+	})
+}
+
+func (a *analysis) dependency(t types.Type) (*types.Named, bool) {
+	named, ok := mockable(t)
+	if !ok {
+		return nil, false
+	}
+	for _, dep := range a.deps {
+		if dep.Obj() == named.Obj() {
+			return dep, true
+		}
+	}
+	return nil, false
+}
+
+func (a *analysis) addReason(dep *types.Named, pos token.Pos, format string, args ...interface{}) {
+	position := a.pkg.Fset.Position(pos)
+	if rel, err := filepath.Rel(a.dir, position.Filename); err == nil {
+		position.Filename = rel
+	}
+	reason := fmt.Sprintf("%s:%d: ", position.Filename, position.Line) + fmt.Sprintf(format, args...)
+	a.reasons[dep.Obj()] = append(a.reasons[dep.Obj()], reason)
+}
+
+// declaredTypes returns the concrete types declared at the top level of
+// the file.
+func (a *analysis) declaredTypes(file *ast.File) []*types.TypeName {
+	var objs []*types.TypeName
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.TYPE {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			obj, ok := a.pkg.TypesInfo.Defs[spec.(*ast.TypeSpec).Name].(*types.TypeName)
+			if ok && !types.IsInterface(obj.Type()) {
+				objs = append(objs, obj)
+			}
+		}
+	}
+	return objs
+}
+
+func (a *analysis) isNil(expr ast.Expr) bool {
+	tv, ok := a.pkg.TypesInfo.Types[expr]
+	return ok && tv.IsNil()
+}
+
+func (a *analysis) isTestFile(file *ast.File) bool {
+	return a.isTestPos(file.Pos())
+}
+
+func (a *analysis) isTestPos(pos token.Pos) bool {
+	return strings.HasSuffix(a.pkg.Fset.Position(pos).Filename, "_test.go")
+}
+
+// srcDir returns the directory of the given package relative to the
+// analysed directory, if the package is within the module.
+func (a *analysis) srcDir(pkg *types.Package) string {
+	if a.pkg.Module == nil {
+		return ""
+	}
+
+	imported := a.pkg
+	if pkg.Path() != a.pkg.PkgPath {
+		var ok bool
+		if imported, ok = a.findImport(a.pkg, pkg.Path(), map[string]bool{}); !ok {
+			return ""
+		}
+	}
+	if imported.Module == nil || imported.Module.Path != a.pkg.Module.Path || len(imported.GoFiles) == 0 {
+		return ""
+	}
+
+	rel, err := filepath.Rel(a.dir, filepath.Dir(imported.GoFiles[0]))
+	if err != nil {
+		return ""
+	}
+	if !strings.HasPrefix(rel, ".") {
+		rel = "." + string(filepath.Separator) + rel
+	}
+	return filepath.ToSlash(rel)
+}
+
+func (a *analysis) findImport(pkg *packages.Package, path string, visited map[string]bool) (*packages.Package, bool) {
+	if pkg.PkgPath == path {
+		return pkg, true
+	}
+	if visited[pkg.ID] {
+		return nil, false
+	}
+	visited[pkg.ID] = true
+
+	for _, imported := range pkg.Imports {
+		if found, ok := a.findImport(imported, path, visited); ok {
+			return found, true
+		}
+	}
+	return nil, false
+}
+
+func hasTestFiles(pkg *packages.Package) bool {
+	for _, name := range pkg.GoFiles {
+		if strings.HasSuffix(name, "_test.go") {
+			return true
+		}
+	}
+	return false
+}
+
+// isGenerated reports whether the file has the comment marking generated
+// Go source, before its package clause.
+func isGenerated(file *ast.File) bool {
+	for _, group := range file.Comments {
+		if group.Pos() > file.Package {
+			return false
+		}
+		for _, comment := range group.List {
+			if strings.HasPrefix(comment.Text, "// Code generated ") && strings.HasSuffix(comment.Text, " DO NOT EDIT.") {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// implements reports whether the type or a pointer to it implements the
+// interface.
+func implements(t types.Type, iface *types.Named) bool {
+	it := iface.Underlying().(*types.Interface)
+	return types.Implements(t, it) || types.Implements(types.NewPointer(t), it)
+}
+
+// mockable returns the named interface type of t if mirip can mock it.
+func mockable(t types.Type) (*types.Named, bool) {
+	named, ok := t.(*types.Named)
+	if !ok || !types.IsInterface(named) || named.Obj().Pkg() == nil || named.TypeArgs().Len() > 0 {
+		return nil, false
+	}
+	if named.Obj().Pkg().Path() == "context" {
+		return nil, false
+	}
+	return named, true
+}
@@ -0,0 +1,161 @@
+package suggest
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"sort"
+	"strings"
+)
+
+// Unused is a mock generated by mirip which the module never configures
+// nor asserts, likely dead and safe to delete along with its file once
+// the tests naming it don't need it.
+type Unused struct {
+	Mock
+
+	// Interface is the interface mocked, in the format 'path.Name'.
+	Interface string
+}
+
+// FindUnused loads the packages of the module in dir along with their
+// tests and returns the mocks of the files generated by mirip, found like
+// Inventory, which the other files of the module never configure nor
+// assert, sorted by file and name. A mock is configured by assigning one
+// of its Func fields, in a statement or a composite literal, and asserted
+// by calling one of its methods which isn't one of the interface, such as
+// its Calls accessors. Using the other declarations of its file named
+// after it, such as its builder or the types of its calls, counts as
+// either. UsedBy lists the test files still naming the mock, those to
+// update before deleting it.
+func FindUnused(dir string) ([]Unused, error) {
+	pkgs, err := loadModule(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		tests     []string
+		mocks     []Unused
+		generated = make(map[string]bool)
+		used      = make(map[int]bool)
+		// markers maps the positions of the declarations configuring or
+		// asserting a mock to its index in mocks. Positions are used
+		// rather than objects as the packages and their test variants
+		// type check the same files to different objects.
+		markers = make(map[string]int)
+		seen    = make(map[string]bool)
+	)
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Syntax {
+			name := pkg.Fset.Position(file.Pos()).Filename
+			if seen[name] {
+				continue
+			}
+			seen[name] = true
+
+			if strings.HasSuffix(name, "_test.go") {
+				tests = append(tests, name)
+			}
+			if !isMiripGenerated(file) {
+				continue
+			}
+			generated[name] = true
+			_, sources := stamp(file)
+			if len(sources) == 0 {
+				sources = assertedInterfaces(pkg, file)
+			}
+			for _, src := range sources {
+				named := lookupNamed(pkg.Types, src)
+				for _, mockName := range mocksOf(pkg, file, named) {
+					obj, ok := pkg.Types.Scope().Lookup(mockName).(*types.TypeName)
+					if !ok {
+						continue
+					}
+					for _, pos := range mockMarkers(file, obj, named) {
+						markers[pkg.Fset.Position(pos).String()] = len(mocks)
+					}
+					mocks = append(mocks, Unused{Mock: Mock{Name: mockName, File: name}, Interface: src})
+				}
+			}
+		}
+	}
+
+	for _, pkg := range pkgs {
+		for ident, obj := range pkg.TypesInfo.Uses {
+			if obj == nil || generated[pkg.Fset.Position(ident.Pos()).Filename] {
+				continue
+			}
+			if i, ok := markers[pkg.Fset.Position(obj.Pos()).String()]; ok {
+				used[i] = true
+			}
+		}
+	}
+
+	var unused []Unused
+	for i, m := range mocks {
+		if used[i] {
+			continue
+		}
+		usedBy, err := usingFiles(tests, m.Mock)
+		if err != nil {
+			return nil, err
+		}
+		m.UsedBy = usedBy
+		unused = append(unused, m)
+	}
+	sort.Slice(unused, func(i, j int) bool {
+		if unused[i].File != unused[j].File {
+			return unused[i].File < unused[j].File
+		}
+		return unused[i].Name < unused[j].Name
+	})
+	return unused, nil
+}
+
+// mockMarkers returns the positions of the declarations configuring or
+// asserting the mock of the named interface: its Func fields, its methods
+// which aren't methods of the interface, and the types and functions of
+// its file named after it.
+func mockMarkers(file *ast.File, mock *types.TypeName, named *types.Named) []token.Pos {
+	var markers []token.Pos
+	if st, ok := mock.Type().Underlying().(*types.Struct); ok {
+		for i := 0; i < st.NumFields(); i++ {
+			f := st.Field(i)
+			if _, ok := f.Type().Underlying().(*types.Signature); ok && strings.HasSuffix(f.Name(), "Func") {
+				markers = append(markers, f.Pos())
+			}
+		}
+	}
+
+	iface := named.Underlying().(*types.Interface)
+	mset := types.NewMethodSet(types.NewPointer(mock.Type()))
+	for i := 0; i < mset.Len(); i++ {
+		m := mset.At(i).Obj()
+		if obj, _, _ := types.LookupFieldOrMethod(iface, false, m.Pkg(), m.Name()); obj == nil {
+			markers = append(markers, m.Pos())
+		}
+	}
+
+	for _, decl := range file.Decls {
+		var idents []*ast.Ident
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			if decl.Recv == nil {
+				idents = append(idents, decl.Name)
+			}
+		case *ast.GenDecl:
+			if decl.Tok == token.TYPE {
+				for _, spec := range decl.Specs {
+					idents = append(idents, spec.(*ast.TypeSpec).Name)
+				}
+			}
+		}
+		for _, ident := range idents {
+			if ident.Name != mock.Name() && strings.HasPrefix(ident.Name, mock.Name()) {
+				markers = append(markers, ident.Pos())
+			}
+		}
+	}
+	return markers
+}
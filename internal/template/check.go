@@ -0,0 +1,302 @@
+package template
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// CheckError lists the references of a template to fields and methods
+// which the data of the templates doesn't have.
+type CheckError struct {
+	Errors []string
+}
+
+func (e *CheckError) Error() string {
+	return strings.Join(e.Errors, "\n")
+}
+
+// builtinTypes are the result types of the builtin functions of
+// text/template which don't depend on their arguments.
+var builtinTypes = map[string]reflect.Type{
+	"eq":       reflect.TypeOf(true),
+	"ne":       reflect.TypeOf(true),
+	"lt":       reflect.TypeOf(true),
+	"le":       reflect.TypeOf(true),
+	"gt":       reflect.TypeOf(true),
+	"ge":       reflect.TypeOf(true),
+	"not":      reflect.TypeOf(true),
+	"len":      reflect.TypeOf(0),
+	"print":    reflect.TypeOf(""),
+	"printf":   reflect.TypeOf(""),
+	"println":  reflect.TypeOf(""),
+	"html":     reflect.TypeOf(""),
+	"js":       reflect.TypeOf(""),
+	"urlquery": reflect.TypeOf(""),
+}
+
+// Check parses the text of a template replacing the one of a kind and
+// checks the fields and methods it refers to against Data, which it is
+// executed with, along with the types of their values. The references
+// through values of interface types, such as the results of index, can't
+// be checked.
+func Check(name, text string) error {
+	base, err := New()
+	if err != nil {
+		return err
+	}
+	builtin := make(map[string]*parse.Tree)
+	for _, t := range base.tmpl.Templates() {
+		builtin[t.Name()] = t.Tree
+	}
+	tmpl, err := base.tmpl.New(name).Parse(text)
+	if err != nil {
+		return err
+	}
+
+	// The templates defined by the text, which may replace builtin ones.
+	defined := make(map[string]bool)
+	for _, t := range tmpl.Templates() {
+		defined[t.Name()] = t.Tree != builtin[t.Name()]
+	}
+
+	c := checker{tmpl: tmpl, defined: defined, checked: make(map[string]bool)}
+	root := reflect.TypeOf(Data{})
+	c.walk(tmpl.Tree, tmpl.Tree.Root, root, map[string]reflect.Type{"$": root})
+	if len(c.errs) != 0 {
+		return &CheckError{Errors: c.errs}
+	}
+	return nil
+}
+
+// checker walks the parse trees of a template, tracking the types of dot
+// and of the variables. A nil type is one which can't be known.
+type checker struct {
+	tmpl    *template.Template
+	defined map[string]bool
+	checked map[string]bool
+	errs    []string
+}
+
+func (c *checker) errorf(tree *parse.Tree, node parse.Node, format string, args ...interface{}) {
+	location, _ := tree.ErrorContext(node)
+	c.errs = append(c.errs, location+": "+fmt.Sprintf(format, args...))
+}
+
+func (c *checker) walk(tree *parse.Tree, node parse.Node, dot reflect.Type, vars map[string]reflect.Type) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			c.walk(tree, child, dot, vars)
+		}
+
+	case *parse.ActionNode:
+		t := c.pipe(tree, n.Pipe, dot, vars)
+		for _, v := range n.Pipe.Decl {
+			vars[v.Ident[0]] = t
+		}
+
+	case *parse.IfNode:
+		scope := copyVars(vars)
+		t := c.pipe(tree, n.Pipe, dot, scope)
+		for _, v := range n.Pipe.Decl {
+			scope[v.Ident[0]] = t
+		}
+		c.walk(tree, n.List, dot, scope)
+		c.walk(tree, n.ElseList, dot, copyVars(vars))
+
+	case *parse.WithNode:
+		scope := copyVars(vars)
+		t := c.pipe(tree, n.Pipe, dot, scope)
+		for _, v := range n.Pipe.Decl {
+			scope[v.Ident[0]] = t
+		}
+		c.walk(tree, n.List, t, scope)
+		c.walk(tree, n.ElseList, dot, copyVars(vars))
+
+	case *parse.RangeNode:
+		scope := copyVars(vars)
+		t := c.pipe(tree, n.Pipe, dot, scope)
+		key, elem := rangeTypes(t)
+		switch len(n.Pipe.Decl) {
+		case 1:
+			scope[n.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			scope[n.Pipe.Decl[0].Ident[0]] = key
+			scope[n.Pipe.Decl[1].Ident[0]] = elem
+		}
+		c.walk(tree, n.List, elem, scope)
+		c.walk(tree, n.ElseList, dot, copyVars(vars))
+
+	case *parse.TemplateNode:
+		var t reflect.Type
+		if n.Pipe != nil {
+			t = c.pipe(tree, n.Pipe, dot, vars)
+		}
+		// The builtin templates are executed with the data they expect,
+		// while those of the text are checked once per type of dot.
+		key := n.Name + " " + fmt.Sprint(t)
+		def := c.tmpl.Lookup(n.Name)
+		if !c.defined[n.Name] || def == nil || c.checked[key] {
+			return
+		}
+		c.checked[key] = true
+		c.walk(def.Tree, def.Tree.Root, t, map[string]reflect.Type{"$": t})
+	}
+}
+
+// pipe returns the type of the value of the pipeline.
+func (c *checker) pipe(tree *parse.Tree, p *parse.PipeNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	if p == nil {
+		return nil
+	}
+	var t reflect.Type
+	for _, cmd := range p.Cmds {
+		t = c.command(tree, cmd, dot, vars)
+	}
+	return t
+}
+
+// command returns the type of the value of the command, checking the
+// arguments of function calls.
+func (c *checker) command(tree *parse.Tree, cmd *parse.CommandNode, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	for _, arg := range cmd.Args[1:] {
+		c.arg(tree, arg, dot, vars)
+	}
+
+	ident, ok := cmd.Args[0].(*parse.IdentifierNode)
+	if !ok {
+		return c.arg(tree, cmd.Args[0], dot, vars)
+	}
+	if fn, ok := templateFuncs[ident.Ident]; ok {
+		if out := reflect.TypeOf(fn); out.NumOut() > 0 {
+			return out.Out(0)
+		}
+		return nil
+	}
+	switch ident.Ident {
+	case "index":
+		if len(cmd.Args) > 1 {
+			_, elem := rangeTypes(c.arg(tree, cmd.Args[1], dot, vars))
+			return elem
+		}
+	case "slice", "and", "or":
+		if len(cmd.Args) > 1 {
+			return c.arg(tree, cmd.Args[1], dot, vars)
+		}
+	}
+	return builtinTypes[ident.Ident]
+}
+
+// arg returns the type of the value of an argument of a command.
+func (c *checker) arg(tree *parse.Tree, node parse.Node, dot reflect.Type, vars map[string]reflect.Type) reflect.Type {
+	switch n := node.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return c.fields(tree, n, dot, n.Ident)
+	case *parse.VariableNode:
+		return c.fields(tree, n, vars[n.Ident[0]], n.Ident[1:])
+	case *parse.ChainNode:
+		return c.fields(tree, n, c.arg(tree, n.Node, dot, vars), n.Field)
+	case *parse.PipeNode:
+		return c.pipe(tree, n, dot, vars)
+	case *parse.StringNode:
+		return reflect.TypeOf("")
+	case *parse.BoolNode:
+		return reflect.TypeOf(true)
+	}
+	return nil
+}
+
+// fields returns the type of the value of the chain of fields and methods
+// of t, reporting those it doesn't have.
+func (c *checker) fields(tree *parse.Tree, node parse.Node, t reflect.Type, names []string) reflect.Type {
+	for _, name := range names {
+		if t == nil {
+			return nil
+		}
+		next, ok := member(t, name)
+		if !ok {
+			c.errorf(tree, node, "%s has no field or method %s", t, name)
+			return nil
+		}
+		t = next
+	}
+	return t
+}
+
+// member returns the type of the value of the field or method of t of
+// the given name, nil if it can't be known.
+func member(t reflect.Type, name string) (reflect.Type, bool) {
+	if t.Kind() == reflect.Interface {
+		// The dynamic type of the value may have the member.
+		if m, ok := t.MethodByName(name); ok {
+			return result(m.Type), true
+		}
+		return nil, true
+	}
+	ptr := t
+	if t.Kind() != reflect.Ptr {
+		ptr = reflect.PtrTo(t)
+	}
+	if m, ok := ptr.MethodByName(name); ok {
+		return result(m.Type), true
+	}
+
+	switch t.Kind() {
+	case reflect.Ptr:
+		return member(t.Elem(), name)
+	case reflect.Struct:
+		f, ok := t.FieldByName(name)
+		if !ok || f.PkgPath != "" {
+			return nil, false
+		}
+		return f.Type, true
+	case reflect.Map:
+		return t.Elem(), true
+	}
+	return nil, false
+}
+
+// result returns the type of the first result of a method, if any.
+func result(method reflect.Type) reflect.Type {
+	if method.NumOut() == 0 {
+		return nil
+	}
+	return method.Out(0)
+}
+
+// rangeTypes returns the types of the keys and elements ranged over in a
+// value of type t.
+func rangeTypes(t reflect.Type) (key, elem reflect.Type) {
+	if t == nil {
+		return nil, nil
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		return rangeTypes(t.Elem())
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), t.Elem()
+	case reflect.Map:
+		return t.Key(), t.Elem()
+	case reflect.Chan:
+		return nil, t.Elem()
+	case reflect.Int:
+		return t, t
+	}
+	return nil, nil
+}
+
+func copyVars(vars map[string]reflect.Type) map[string]reflect.Type {
+	scope := make(map[string]reflect.Type, len(vars))
+	for name, t := range vars {
+		scope[name] = t
+	}
+	return scope
+}
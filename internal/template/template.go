@@ -15,18 +15,110 @@ type Template struct {
 }
 
 // Execute generates and writes the Mirip implementation for the given
-// data.
+// data. The template used is picked by the kind of the data.
 func (t Template) Execute(w io.Writer, data Data) error {
-	return t.tmpl.Execute(w, data)
+	return t.tmpl.ExecuteTemplate(w, data.Kind, data)
+}
+
+// ExecuteTest generates and writes a test skeleton for the given data.
+func (t Template) ExecuteTest(w io.Writer, data TestData) error {
+	return t.tmpl.ExecuteTemplate(w, "testgen", data)
+}
+
+// ExecuteExample generates and writes Example functions for the mocks of
+// the given data.
+func (t Template) ExecuteExample(w io.Writer, data Data) error {
+	return t.tmpl.ExecuteTemplate(w, "example", data)
+}
+
+// ExecuteConformance generates and writes the conformance tests of the
+// interfaces of the given data.
+func (t Template) ExecuteConformance(w io.Writer, data Data) error {
+	return t.tmpl.ExecuteTemplate(w, "conformance", data)
+}
+
+// ExecuteBenchmark generates and writes the benchmarks of the interfaces
+// of the given data.
+func (t Template) ExecuteBenchmark(w io.Writer, data Data) error {
+	return t.tmpl.ExecuteTemplate(w, "bench", data)
+}
+
+// ExecuteEnsure generates and writes the assertions that the generated
+// types of the given data implement their interface.
+func (t Template) ExecuteEnsure(w io.Writer, data Data) error {
+	return t.tmpl.ExecuteTemplate(w, "ensures", data)
+}
+
+// ExecuteInterface generates and writes the interface declarations for
+// the given data.
+func (t Template) ExecuteInterface(w io.Writer, data InterfaceData) error {
+	return t.tmpl.ExecuteTemplate(w, "iface", data)
+}
+
+// ExecuteDoc generates and writes the doc.go file of a mocks package for
+// the given data.
+func (t Template) ExecuteDoc(w io.Writer, data DocData) error {
+	return t.tmpl.ExecuteTemplate(w, "doc", data)
+}
+
+// Override returns the Template with the text of a template replacing the
+// one of the given kind. The templates of the kinds can be used by it.
+func (t Template) Override(kind, text string) (Template, error) {
+	tmpl, err := t.tmpl.Clone()
+	if err != nil {
+		return Template{}, err
+	}
+	if _, err := tmpl.New(kind).Parse(text); err != nil {
+		return Template{}, err
+	}
+	return Template{tmpl: tmpl}, nil
 }
 
 // New returns a new instance of Template.
 func New() (Template, error) {
-	tmpl, err := template.New("mirip").Funcs(templateFuncs).Parse(miripTemplate)
+	tmpl, err := template.New("mirip").Funcs(templateFuncs).Parse(headerTemplate)
 	if err != nil {
 		return Template{}, err
 	}
 
+	if _, err := tmpl.Parse(providerTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("testgen").Parse(testgenTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("example").Parse(exampleTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("conformance").Parse(conformanceTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("bench").Parse(benchTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("iface").Parse(ifaceTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("doc").Parse(docTemplate); err != nil {
+		return Template{}, err
+	}
+
+	if _, err := tmpl.New("ensures").Parse(ensureTemplate); err != nil {
+		return Template{}, err
+	}
+
+	for kind, text := range kindTemplates {
+		if _, err := tmpl.New(kind).Parse(text); err != nil {
+			return Template{}, err
+		}
+	}
+
 	return Template{tmpl: tmpl}, nil
 }
 
@@ -54,22 +146,99 @@ var templateFuncs = template.FuncMap{
 
 		return "sync"
 	},
-	"Exported": func(s string) string {
-		if s == "" {
-			return ""
-		}
-		for _, initialism := range golintInitialisms {
-			if strings.ToUpper(s) == initialism {
-				return initialism
+	"PkgQualifier": func(imports []*registry.Package, path string) string {
+		for _, imprt := range imports {
+			if imprt.Path() == path {
+				return imprt.Qualifier()
 			}
 		}
-		return strings.ToUpper(s[0:1]) + s[1:]
+
+		return path[strings.LastIndex(path, "/")+1:]
 	},
+	"Exported": Exported,
+}
+
+// Exported returns the identifier with its first letter in upper case, or
+// all of it if it is an initialism.
+func Exported(s string) string {
+	if s == "" {
+		return ""
+	}
+	for _, initialism := range golintInitialisms {
+		if strings.ToUpper(s) == initialism {
+			return initialism
+		}
+	}
+	return strings.ToUpper(s[0:1]) + s[1:]
+}
+
+// kindTemplates are the templates for each kind of generated code,
+// keyed by kind.
+var kindTemplates = map[string]string{
+	"mock":     mockTemplate,
+	"chaos":    chaosTemplate,
+	"recorder": recorderTemplate,
+	"stub":     stubTemplate,
+
+	"fake-builtin": fakeBuiltinTemplate,
+}
+
+// headerTemplate is the template for the file header shared by all
+// kinds.
+// language=GoTemplate
+var headerTemplate = `{{define "header" -}}
+// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+{{- if .Stamp}}
+//
+{{- range .Stamp}}
+// {{.}}
+{{- end}}
+{{- end}}
+{{- if .Constraint}}
+
+//go:build {{.Constraint}}
+{{- end}}
+
+package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+{{end}}
+
+{{- define "ensure" -}}
+{{- $mock := .}}
+{{- if .TypeSet}}
+// {{.MockName}} isn't asserted to implement {{.SrcPkgQualifier}}{{.InterfaceName}}, whose type set only
+// constrains type parameters.
+func _[T {{.SrcPkgQualifier}}{{.InterfaceName}}]() {}
+{{- else}}
+// Ensure that {{.MockName}} implements {{.SrcPkgQualifier}}{{.InterfaceName}}.
+{{- if .TypeParams}}
+func _{{.TypeParams}}() {
+	var _ {{.SrcPkgQualifier}}{{.InterfaceName}}{{.TypeArgs}} = {{.EnsureOf .MockName}}
 }
+{{- else if .InterfaceAliases}}
+var (
+	_ {{.SrcPkgQualifier}}{{.InterfaceName}} = {{.EnsureOf .MockName}}
+{{- range .InterfaceAliases}}
+	_ {{$mock.SrcPkgQualifier}}{{.}} = {{$mock.EnsureOf $mock.MockName}}
+{{- end}}
+)
+{{- else}}
+var _ {{.SrcPkgQualifier}}{{.InterfaceName}} = {{.EnsureOf .MockName}}
+{{- end}}
+{{- end}}
+{{- end}}`
 
-// miripTemplate is the template for mocked code.
+// ensureTemplate is the template for the file of the assertions that the
+// generated types implement their interface, written instead of those in
+// the generated files.
 // language=GoTemplate
-var miripTemplate = `// Code generated by mirip; DO NOT EDIT.
+var ensureTemplate = `// Code generated by mirip; DO NOT EDIT.
 // github.com/gmhafiz/mirip
 
 package {{.PkgName}}
@@ -79,21 +248,1984 @@ import (
 	{{. | ImportStatement}}
 {{- end}}
 )
+{{- range .Mocks}}
+
+{{template "ensure" .}}
+{{- end}}
+`
+
+// providerTemplate is the template for the optional dependency
+// injection provider and registry of the generated types, shared by all
+// kinds.
+// language=GoTemplate
+var providerTemplate = `{{define "provider" -}}
+{{if eq .Provider "func"}}
+// ProvideMocks constructs every type generated in this file and returns
+// them bound to the interfaces they implement.
+func ProvideMocks() ({{range $i, $mock := .Mocks}}{{if $i}}, {{end}}{{.SrcPkgQualifier}}{{.InterfaceName}}{{end}}) {
+	return {{range $i, $mock := .Mocks}}{{if $i}}, {{end}}&{{.MockName}}{}{{end}}
+}
+{{else if .Provider}}
+{{- range .Mocks}}
+// New{{.MockName}} returns a new instance of {{.MockName}}.
+func New{{.MockName}}() *{{.MockName}} {
+	return &{{.MockName}}{}
+}
+
+{{end}}
+{{- if eq .Provider "wire"}}
+{{- $wire := PkgQualifier .Imports "github.com/google/wire"}}
+// ProvideMocks is a wire provider set constructing every type generated
+// in this file and binding it to the interface it implements.
+var ProvideMocks = {{$wire}}.NewSet(
+{{- range .Mocks}}
+	New{{.MockName}},
+	{{$wire}}.Bind(new({{.SrcPkgQualifier}}{{.InterfaceName}}), new(*{{.MockName}})),
+{{- end}}
+)
+{{- else if eq .Provider "fx"}}
+// ProvideMocks is an fx option providing every type generated in this
+// file, both as itself and as the interface it implements.
+var ProvideMocks = {{PkgQualifier .Imports "go.uber.org/fx"}}.Provide(
+{{- range .Mocks}}
+	New{{.MockName}},
+	func(m *{{.MockName}}) {{.SrcPkgQualifier}}{{.InterfaceName}} { return m },
+{{- end}}
+)
+{{- end}}
+{{end}}
+{{- if .Registry}}
+// Mocks holds a constructor of each type generated in this file by the
+// name of the interface it implements, for instantiating them by name.
+var Mocks = map[string]func() interface{}{
+{{- range .Mocks}}
+	"{{.InterfaceName}}": func() interface{} { return &{{.MockName}}{} },
+{{- end}}
+}
+{{end}}
+{{- if .Shared}}
+{{- $sync := PkgQualifier .Imports "sync"}}
+{{- $testing := PkgQualifier .Imports "testing"}}
+{{- range .Mocks}}
+// shared{{.MockName}}s holds the {{.MockName}} of each running test.
+var shared{{.MockName}}s = struct {
+	{{$sync}}.Mutex
+	mocks map[{{$testing}}.TB]*{{.MockName}}
+}{mocks: make(map[{{$testing}}.TB]*{{.MockName}})}
+
+// Shared{{.MockName}} returns the {{.MockName}} of the test, created on its
+// first call in the test and dropped once the test ends, for the
+// package-level fixtures and helpers to use the mock the test configures
+// without sharing it between tests. Subtests have their own mock. It is
+// safe for concurrent use, such as by parallel tests.
+func Shared{{.MockName}}(t {{$testing}}.TB) *{{.MockName}} {
+	shared{{.MockName}}s.Lock()
+	defer shared{{.MockName}}s.Unlock()
+	if mock, ok := shared{{.MockName}}s.mocks[t]; ok {
+		return mock
+	}
+	mock := &{{.MockName}}{}
+	shared{{.MockName}}s.mocks[t] = mock
+	t.Cleanup(func() {
+		shared{{.MockName}}s.Lock()
+		defer shared{{.MockName}}s.Unlock()
+		delete(shared{{.MockName}}s.mocks, t)
+	})
+	return mock
+}
+{{end}}
+{{- end}}
+{{- if .VerifyAll}}
+{{- $fmt := PkgQualifier .Imports "fmt"}}
+{{- $testing := PkgQualifier .Imports "testing"}}
+// VerifyAll runs the verifications returned by the Build methods of the
+// builders of this file in the given order, all of them even when some
+// fail, and reports their failures at once, each along with the number
+// of its verification, for a single deferred call to verify the mocks of
+// a test.
+func VerifyAll(t {{$testing}}.TB, verifications ...func(t {{$testing}}.TB)) {
+	t.Helper()
+	var failures []string
+	failed := 0
+	for i, verify := range verifications {
+		v := &verifyAllTB{TB: t}
+		v.run(verify)
+		for _, failure := range v.failures {
+			failures = append(failures, {{$fmt}}.Sprintf("verification %d: %s", i+1, failure))
+		}
+		if v.failed {
+			failed++
+		}
+	}
+	if failed != 0 {
+		t.Errorf("%d of %d mock verifications failed:\n\t%s", failed, len(verifications), {{PkgQualifier .Imports "strings"}}.Join(failures, "\n\t"))
+	}
+}
+
+// verifyAllTB records the failures of a verification run by VerifyAll
+// rather than failing the test.
+type verifyAllTB struct {
+	{{$testing}}.TB
+	failures []string
+	failed   bool
+}
+
+// verifyAllStop stops a verification which failed with FailNow.
+type verifyAllStop struct{}
+
+func (v *verifyAllTB) run(verify func(t {{$testing}}.TB)) {
+	defer func() {
+		if r := recover(); r != nil {
+			if _, ok := r.(verifyAllStop); !ok {
+				panic(r)
+			}
+		}
+	}()
+	verify(v)
+}
+
+func (v *verifyAllTB) Error(args ...interface{}) {
+	v.failures = append(v.failures, {{$fmt}}.Sprint(args...))
+	v.failed = true
+}
+
+func (v *verifyAllTB) Errorf(format string, args ...interface{}) {
+	v.failures = append(v.failures, {{$fmt}}.Sprintf(format, args...))
+	v.failed = true
+}
+
+func (v *verifyAllTB) Fail() {
+	v.failed = true
+}
+
+func (v *verifyAllTB) Failed() bool {
+	return v.failed
+}
+
+func (v *verifyAllTB) Fatal(args ...interface{}) {
+	v.Error(args...)
+	v.FailNow()
+}
 
+func (v *verifyAllTB) Fatalf(format string, args ...interface{}) {
+	v.Errorf(format, args...)
+	v.FailNow()
+}
+
+func (v *verifyAllTB) FailNow() {
+	v.failed = true
+	panic(verifyAllStop{})
+}
+{{end}}
+{{- end}}`
+
+// mockTemplate is the template for mocked code.
+// language=GoTemplate
+var mockTemplate = `{{define "callStruct" -}}
+{{if .CallType}}{{.CallType}}{{else}}{{template "callFields" .}}{{end}}
+{{- end}}
+
+{{- define "callFields" -}}
+{{if .Params}}struct {
+{{- range .Params}}
+	{{.Name | Exported}} {{.CallTypeString}}
+{{- end}}
+}{{else}}struct{}{{end}}
+{{- end}}
+
+{{- template "header" .}}
+{{- $sync := PkgQualifier .Imports "sync"}}
+{{- $m := .Receiver}}
 {{range $i, $mock := .Mocks -}}
+{{- $h := .Helpers}}
+{{- $calls := index $h $.CallsField}}
+{{- $lock := index $h $.LockField}}
 
-// {{.MockName}} is a mock implementation of {{$.SrcPkgQualifier}}{{.InterfaceName}}.
-type {{.MockName}} struct {
+{{- if not $.SkipEnsure}}
+{{template "ensure" .}}
+
+{{end -}}
+// {{.MockName}} is a mock implementation of {{.SrcPkgQualifier}}{{.InterfaceName}}.
+{{- if .Delegated}}
+// The methods it doesn't mock are delegated to the embedded
+// {{.SrcPkgQualifier}}{{.InterfaceName}}, to be set before calling them.
+{{- end}}
+type {{.MockName}}{{.TypeParams}} struct {
+{{- if .Delegated}}
+	{{.SrcPkgQualifier}}{{.InterfaceName}}{{.TypeArgs}}
+{{end}}
 {{- range .Methods}}
-	{{.Name}}Func func({{.ArgList}}) {{.ReturnArgTypeList}}{{end}}
+	{{.FuncName}} func({{.ArgList}}) {{.ReturnArgTypeList}}{{end}}
+
+{{- if eq $.Stub "random"}}
+
+	// {{$h.Rand}} is the source of the random values returned by methods without
+	// a Func. It is seeded with the current time when nil.
+	{{$h.Rand}} *{{PkgQualifier $.Imports "math/rand"}}.Rand
+{{- end}}
+{{- if eq $.Stub "fuzz"}}
+
+	// {{$h.Fuzz}} is the input of a fuzz target which the results of the methods
+	// without a Func are decoded from, consumed by the calls in order. The
+	// results are zero once it is exhausted.
+	{{$h.Fuzz}} []byte
+{{- end}}
+{{- if eq $.Stub "fixture"}}
+
+	// {{$h.Fixture}} holds the results of the calls to methods without a Func, by
+	// method and in the order of the calls. It can be decoded from JSON or YAML.
+	{{$h.Fixture}} {{.MockName}}Fixture
+
+	// {{$h.fixtureCalls}} counts the calls to each method answered by {{$h.Fixture}}.
+	{{$h.fixtureCalls}} struct {
+	{{- range .Methods}}
+	{{- if .Returns}}
+		{{.Name}} {{PkgQualifier $.Imports "sync/atomic"}}.Int64
+	{{- end}}
+	{{- end}}
+	}
+{{- end}}
+{{- if and $.Clock .ReturnsTime}}
+
+	// {{$h.Clock}}, if set, returns the time.Time results of the methods without
+	// a Func, such as a fixed time for "now"{{if eq $.Stub "fixture"}}, unless the fixture gives them{{end}}.
+	{{$h.Clock}} func() {{PkgQualifier $.Imports "time"}}.Time
+{{- end}}
+{{- if eq $.Assert "cmp"}}
+
+	// {{$h.CmpOptions}} are the options of cmp.Equal comparing the arguments of
+	// calls in the assertions, such as cmpopts.IgnoreUnexported.
+	{{$h.CmpOptions}} []{{PkgQualifier $.Imports "github.com/google/go-cmp/cmp"}}.Option
+{{- end}}
+{{- if $.RecordSwitch}}
+
+	// {{$h.MaxCalls}}, if positive, is the number of the last calls of each method
+	// kept by the mock, dropping the earlier ones.
+	{{$h.MaxCalls}} int
+
+	{{$h.recordingDisabled}} bool
+{{- end}}
+{{- if $.Report}}
+
+	{{$h.reportCalls}} []{{PkgQualifier $.Imports "github.com/gmhafiz/mirip/runtime"}}.Call
+	{{$h.reportLock}}  {{$sync}}.Mutex
+{{- end}}
+
+	{{$calls}} struct {
+	{{- range .Methods}}
+	{{- if $.Light}}
+		{{.Name}} {{PkgQualifier $.Imports "sync/atomic"}}.Int64
+	{{- else}}
+		{{.Name}} []{{template "callStruct" .}}
+	{{- end}}
+	{{- end}}
+	}
+{{- if $.LockPerMethod}}
+	{{$lock}} struct {
+	{{- range .Methods}}
+		{{.Name}} {{$sync}}.RWMutex
+	{{- end}}
+	{{- if eq $.Stub "random"}}
+		{{$h.Rand}} {{$sync}}.Mutex
+	{{- end}}
+	{{- if eq $.Stub "fuzz"}}
+		{{$h.Fuzz}} {{$sync}}.Mutex
+	{{- end}}
+	}
+{{- else}}
+	{{$lock}} {{$sync}}.RWMutex
+{{- end}}
 }
 
-{{$out := .}}
+{{- range $method := .Methods}}
+{{- range .Params}}
+{{- if .Helper}}
+
+// {{.Helper}} is the type of the {{.Name}} parameter of {{$method.Name}}.
+type {{.Helper}} = {{.TypeString}}
+{{- end}}
+{{- end}}
+{{- end}}
 {{- range .Methods}}
-func (m * {{$out.MockName}} ) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
-	return m.{{.Name}}Func({{.ArgCallList}})
-}
-{{end}}
+{{- if .CallTypeName}}
 
+// {{.CallTypeName}} is the type of the calls to {{$mock.MockName}}.{{.Name}}
+// recorded by the mock.
+type {{.CallTypeName}}{{$mock.TypeParams}} {{if .Params}}struct {
+{{- range $i, $p := .Params}}
+{{- if $i}}
 {{end}}
+	// {{.Name | Exported}} is the {{.Name}} argument of the call.
+	{{.Name | Exported}} {{.CallTypeString}}
+{{- end}}
+}{{else}}struct{}{{end}}
+{{- end}}
+{{- end}}
+{{- if eq $.Stub "fixture"}}
+
+// {{.MockName}}Fixture holds the results of the calls to the methods of
+// {{.MockName}} without a Func, at the index of the call.
+type {{.MockName}}Fixture struct {
+{{- range .Methods}}
+{{- if .Returns}}
+	{{.Name}} []{{$mock.MockName}}{{.Name}}Results ` + "`" + `json:"{{.Name}}" yaml:"{{.Name}}"` + "`" + `
+{{- end}}
+{{- end}}
+}
+
+{{- range $method := .Methods}}
+{{- if .Returns}}
+
+// {{$mock.MockName}}{{.Name}}Results are the results of a call to {{.Name}} in the
+// fixture of {{$mock.MockName}}. Errors are given by their message, while the
+// results which can't be decoded, such as interfaces, are zero.
+type {{$mock.MockName}}{{.Name}}Results struct {
+{{- range .Returns}}
+{{- if eq .TypeString "error"}}
+	{{.FixtureName | Exported}} string ` + "`" + `json:"{{.FixtureName}}" yaml:"{{.FixtureName}}"` + "`" + `
+{{- else if .Var.Encodable}}
+	{{.FixtureName | Exported}} {{.TypeString}} ` + "`" + `json:"{{.FixtureName}}" yaml:"{{.FixtureName}}"` + "`" + `
+{{- end}}
+{{- end}}
+}
+{{- end}}
+{{- end}}
+{{- end}}
+
+{{- range .Methods}}
+{{- $lock := $lock}}
+{{- if $.LockPerMethod}}{{$lock = printf "%s.%s" $lock .Name}}{{end}}
+
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
+{{- if $.Light}}
+{{- if $.Coverage}}
+	if {{$m}}.{{$calls}}.{{.Name}}.Add(1) == 1 {
+		{{$m}}.{{$h.writeCoverage}}("call", "{{.Name}}")
+	}
+{{- else}}
+	{{$m}}.{{$calls}}.{{.Name}}.Add(1)
+{{- end}}
+{{- else if $.RecordSwitch}}
+	{{$m}}.{{$lock}}.Lock()
+	if !{{$m}}.{{$h.recordingDisabled}} {
+		{{$m}}.{{$calls}}.{{.Name}} = append({{$m}}.{{$calls}}.{{.Name}}[{{$m}}.{{$h.firstCall}}(len({{$m}}.{{$calls}}.{{.Name}})):], {{template "callStruct" .}}{
+		{{- range .Params}}
+			{{.Name | Exported}}: {{.Name}},
+		{{- end}}
+		})
+	}
+	{{$m}}.{{$lock}}.Unlock()
+{{- else}}
+	{{$m}}.{{$lock}}.Lock()
+	{{$m}}.{{$calls}}.{{.Name}} = append({{$m}}.{{$calls}}.{{.Name}}, {{template "callStruct" .}}{
+	{{- range .Params}}
+		{{.Name | Exported}}: {{.Name}},
+	{{- end}}
+	})
+{{- if $.Coverage}}
+	if len({{$m}}.{{$calls}}.{{.Name}}) == 1 {
+		{{$m}}.{{$h.writeCoverage}}("call", "{{.Name}}")
+	}
+{{- end}}
+	{{$m}}.{{$lock}}.Unlock()
+{{- end}}
+{{- if $.Report}}
+	{{$m}}.{{$h.reportCall}}("{{.Name}}"{{range .Params}}, {{PkgQualifier $.Imports "github.com/gmhafiz/mirip/runtime"}}.Arg{Name: "{{.Name}}", Value: {{.Name}}}{{end}})
+{{- end}}
+{{- if .DoneContext}}
+	if {{.DoneContext}}.Err() != nil {
+	{{- if .LeadingReturns}}
+		var (
+		{{- range .LeadingReturns}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+	{{- end}}
+		return {{range .LeadingReturns}}{{.Name}}, {{end}}{{.DoneContext}}.Err()
+	}
+{{- end}}
+{{- if .Fluent}}
+	if {{$m}}.{{.FuncName}} == nil {
+		var (
+		{{- range .Returns}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+		{{- range .Returns}}
+		{{- if .Self}}
+		{{.Name}} = {{$m}}
+		{{- end}}
+		{{- end}}
+		return {{.ReturnArgNameList}}
+	}
+{{- else if $.Stub}}
+	if {{$m}}.{{.FuncName}} == nil {
+	{{- if .Returns}}
+		var (
+		{{- range .Returns}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+		{{- if eq $.Stub "random"}}
+		{{$m}}.{{$h.randomize}}({{range $i, $r := .Returns}}{{if $i}}, {{end}}&{{.Name}}{{end}})
+		{{- else if eq $.Stub "fuzz"}}
+		{{$m}}.{{$h.fuzz}}({{range $i, $r := .Returns}}{{if $i}}, {{end}}&{{.Name}}{{end}})
+		{{- else if eq $.Stub "fixture"}}
+		{{- $decoded := false}}
+		{{- range .Returns}}
+		{{- if or (eq .TypeString "error") .Var.Encodable}}
+		{{- $decoded = true}}
+		{{- end}}
+		{{- end}}
+		{{if $decoded}}results :={{else}}_ ={{end}} {{$m}}.{{$h.Fixture}}.{{.Name}}[{{$m}}.{{$h.fixtureCall}}("{{.Name}}", int({{$m}}.{{$h.fixtureCalls}}.{{.Name}}.Add(1)), len({{$m}}.{{$h.Fixture}}.{{.Name}}))]
+		{{- range .Returns}}
+		{{- if eq .TypeString "error"}}
+		{{.Name}} = {{$m}}.{{$h.fixtureError}}(results.{{.FixtureName | Exported}})
+		{{- else if .Var.Encodable}}
+		{{.Name}} = results.{{.FixtureName | Exported}}
+		{{- end}}
+		{{- end}}
+		{{- end}}
+		{{- if $.NotImplemented}}
+		{{- $method := .}}
+		{{- range .Returns}}
+		{{- if eq .TypeString "error"}}
+		{{.Name}} = {{PkgQualifier $.Imports "github.com/gmhafiz/mirip/runtime"}}.NotImplemented("{{$mock.MockName}}.{{$method.Name}}")
+		{{- end}}
+		{{- end}}
+		{{- end}}
+		{{- if and $.Clock .ReturnsTime}}
+		if {{$m}}.{{$h.Clock}} != nil {
+		{{- range .Returns}}
+		{{- if .Var.IsTime}}
+		{{- if eq $.Stub "fixture"}}
+			if {{.Name}}.IsZero() {
+				{{.Name}} = {{$m}}.{{$h.Clock}}()
+			}
+		{{- else}}
+			{{.Name}} = {{$m}}.{{$h.Clock}}()
+		{{- end}}
+		{{- end}}
+		{{- end}}
+		}
+		{{- end}}
+		return {{.ReturnArgNameList}}
+	{{- else}}
+		return
+	{{- end}}
+	}
+{{- end}}
+	{{if .Returns}}return {{end}}{{$m}}.{{.FuncName}}({{.ArgCallList}})
+}
+{{- if $.Light}}
+
+// {{.CountName}} returns the number of calls made to {{.Name}}.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.CountName}}() int {
+	return int({{$m}}.{{$calls}}.{{.Name}}.Load())
+}
+{{- else}}
+
+// {{.CallsName}} returns the calls made to {{.Name}}.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.CallsName}}() []{{template "callStruct" .}} {
+	{{$m}}.{{$lock}}.RLock()
+	defer {{$m}}.{{$lock}}.RUnlock()
+	return {{$m}}.{{$calls}}.{{.Name}}
+}
+
+// {{.SnapshotName}} returns a copy of the calls made to {{.Name}},
+// which the calls recorded later don't change, for reading them while
+// {{.Name}} may still be called concurrently.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.SnapshotName}}() []{{template "callStruct" .}} {
+	{{$m}}.{{$lock}}.RLock()
+	defer {{$m}}.{{$lock}}.RUnlock()
+	calls := make([]{{template "callStruct" .}}, len({{$m}}.{{$calls}}.{{.Name}}))
+	copy(calls, {{$m}}.{{$calls}}.{{.Name}})
+{{- range .Params}}
+{{- if .Variadic}}
+	for i := range calls {
+		calls[i].{{.Name | Exported}} = append(calls[i].{{.Name | Exported}}[:0:0], calls[i].{{.Name | Exported}}...)
+	}
+{{- end}}
+{{- end}}
+	return calls
+}
+{{- end}}
+{{- if .AssertName}}
+
+// {{.AssertName}} fails the test unless {{.Name}} was called with
+// the arguments, reporting the diff of each call otherwise.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.AssertName}}(t {{PkgQualifier $.Imports "testing"}}.TB, {{.ArgList}}) {
+	t.Helper()
+	{{$m}}.{{$h.assertCalledWith}}(t, "{{.Name}}", {{template "callStruct" .}}{
+	{{- range .Params}}
+		{{.Name | Exported}}: {{.Name}},
+	{{- end}}
+	}, {{$m}}.{{.CallsName}}())
+}
+{{- end}}
+{{- if or .ContextDeadlineName .ContextValuesName}}
+{{- $ctx := (index .Params 0).Name | Exported}}
+{{- if .ContextDeadlineName}}
+
+// {{.ContextDeadlineName}} fails the test unless {{.Name}} was
+// called, with a context having a deadline at every call.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.ContextDeadlineName}}(t {{PkgQualifier $.Imports "testing"}}.TB) {
+	t.Helper()
+	calls := {{$m}}.{{.CallsName}}()
+	if len(calls) == 0 {
+		t.Errorf("{{$mock.MockName}}.{{.Name}} was not called")
+	}
+	for i, call := range calls {
+		if _, ok := call.{{$ctx}}.Deadline(); !ok {
+			t.Errorf("{{$mock.MockName}}.{{.Name}} was called without a context deadline at call %d", i)
+		}
+	}
+}
+
+// {{.ContextValueName}} fails the test unless the context of
+// one of the calls to {{.Name}} holds want for the key.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.ContextValueName}}(t {{PkgQualifier $.Imports "testing"}}.TB, key, want interface{}) {
+	t.Helper()
+	calls := {{$m}}.{{.CallsName}}()
+	for _, call := range calls {
+		if {{PkgQualifier $.Imports "reflect"}}.DeepEqual(call.{{$ctx}}.Value(key), want) {
+			return
+		}
+	}
+	t.Errorf("{{$mock.MockName}}.{{.Name}} was not called with the context value %v for the key %v, in %d calls", want, key, len(calls))
+}
+{{- end}}
+{{- if .ContextValuesName}}
+
+// {{.ContextValuesName}} returns the values of the context keys in the
+// context of each call to {{.Name}}, keyed by key.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.ContextValuesName}}() []map[interface{}]interface{} {
+	calls := {{$m}}.{{.CallsName}}()
+	values := make([]map[interface{}]interface{}, len(calls))
+	for i, call := range calls {
+		values[i] = map[interface{}]interface{}{
+		{{- range $.ContextKeys}}
+			{{.}}: call.{{$ctx}}.Value({{.}}),
+		{{- end}}
+		}
+	}
+	return values
+}
+{{- end}}
+{{- end}}
+{{- if .SeqName}}
+{{- $elems := .SeqReturn}}
+{{- if eq (len $elems) 1}}
+
+// {{.SeqName}} stubs {{.Name}} to return an iterator yielding the values.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.SeqName}}(values []{{index $elems 0}}) {
+	seq := func(yield func({{index $elems 0}}) bool) {
+		for _, v := range values {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+{{- else}}
+
+// {{.SeqName}} stubs {{.Name}} to return an iterator yielding the keys
+// along with the values at the same index.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.SeqName}}(keys []{{index $elems 0}}, values []{{index $elems 1}}) {
+	seq := func(yield func({{index $elems 0}}, {{index $elems 1}}) bool) {
+		for i, k := range keys {
+			if !yield(k, values[i]) {
+				return
+			}
+		}
+	}
+{{- end}}
+	{{$m}}.{{.FuncName}} = func({{.ArgTypeList}}) {{.ReturnArgTypeList}} {
+		return seq{{if .ReturnsError}}, nil{{end}}
+	}
+}
+{{- end}}
+{{- if .ChanName}}
+{{- $elem := .ChanReturn}}
+
+// {{.ChanName}} stubs {{.Name}} to return a channel buffering the values,
+// which is closed once they are received.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.ChanName}}(values []{{$elem}}) {
+	{{$m}}.{{.FuncName}} = func({{.ArgTypeList}}) {{.ReturnArgTypeList}} {
+		ch := make(chan {{$elem}}, len(values))
+		for _, v := range values {
+			ch <- v
+		}
+		close(ch)
+		return ch{{if .ReturnsError}}, nil{{end}}
+	}
+}
+{{- end}}
+{{- $method := .}}
+{{- range .Params}}
+{{- if .SeqName}}
+{{- $elems := .Var.SeqTypes}}
+{{- if eq (len $elems) 1}}
+
+// {{.SeqName}} returns the values yielded by the {{.Name}} parameter
+// of the given call to {{$method.Name}}.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.SeqName}}(call int) []{{index $elems 0}} {
+	var values []{{index $elems 0}}
+	{{$m}}.{{$method.CallsName}}()[call].{{.Name | Exported}}(func(v {{index $elems 0}}) bool {
+		values = append(values, v)
+		return true
+	})
+	return values
+}
+{{- else}}
+
+// {{.SeqName}} returns the keys and the values yielded by the
+// {{.Name}} parameter of the given call to {{$method.Name}}.
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.SeqName}}(call int) ([]{{index $elems 0}}, []{{index $elems 1}}) {
+	var (
+		keys   []{{index $elems 0}}
+		values []{{index $elems 1}}
+	)
+	{{$m}}.{{$method.CallsName}}()[call].{{.Name | Exported}}(func(k {{index $elems 0}}, v {{index $elems 1}}) bool {
+		keys = append(keys, k)
+		values = append(values, v)
+		return true
+	})
+	return keys, values
+}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if $.Builder}}
+{{- $testing := PkgQualifier $.Imports "testing"}}
+
+// {{.MockName}}Builder builds a {{.MockName}} along with the verification of
+// the number of calls to its methods, such as in the cases of tables of
+// tests. Its zero value is ready to use.
+type {{.MockName}}Builder{{.TypeParams}} struct {
+	funcs struct {
+	{{- range .Methods}}
+		{{.Name}} func({{.ArgList}}) {{.ReturnArgTypeList}}
+	{{- end}}
+	}
+	calls    map[string]int
+	failFast {{$testing}}.TB
+}
+{{- range $method := .Methods}}
+
+// With{{.Name}} sets the {{.FuncName}} of the mock.
+func (b *{{$mock.MockName}}Builder{{$mock.TypeArgs}}) With{{.Name}}(f func({{.ArgList}}) {{.ReturnArgTypeList}}) *{{$mock.MockName}}Builder{{$mock.TypeArgs}} {
+	b.funcs.{{.Name}} = f
+	return b
+}
+{{- if .Returns}}
+
+// Return{{.Name}} sets the {{.FuncName}} of the mock to return the given
+// results, whatever the arguments.
+func (b *{{$mock.MockName}}Builder{{$mock.TypeArgs}}) Return{{.Name}}{{.ReturnArgList}} *{{$mock.MockName}}Builder{{$mock.TypeArgs}} {
+	b.funcs.{{.Name}} = func({{.ArgTypeList}}) {{.ReturnArgTypeList}} {
+		return {{.ReturnArgNameList}}
+	}
+	return b
+}
+{{- with $mock.AnyResults .}}
+
+// {{$mock.MockName}}Return{{$method.Name}}As sets the {{$method.FuncName}} of the mock of the
+// builder to return the given results, of any type T for those of type
+// any, and []T for those of type []any, such as the values it decodes.
+func {{$mock.MockName}}Return{{$method.Name}}As{{.TypeParams}}(b *{{$mock.MockName}}Builder{{$mock.TypeArgs}}, {{.ArgList}}) *{{$mock.MockName}}Builder{{$mock.TypeArgs}} {
+	{{- range .Slices}}
+	{{.Name}}Any := make({{.TypeString}}, len({{.Name}}))
+	for i, v := range {{.Name}} {
+		{{.Name}}Any[i] = v
+	}
+	{{- end}}
+	return b.Return{{$method.Name}}({{.CallList}})
+}
+{{- end}}
+{{- else}}
+
+// Return{{.Name}} sets the {{.FuncName}} of the mock to do nothing, for the
+// calls to {{.Name}} not to panic.
+func (b *{{$mock.MockName}}Builder{{$mock.TypeArgs}}) Return{{.Name}}() *{{$mock.MockName}}Builder{{$mock.TypeArgs}} {
+	b.funcs.{{.Name}} = func({{.ArgTypeList}}) {}
+	return b
+}
+{{- end}}
+
+// Expect{{.Name}}Called makes the verification fail unless {{.Name}} was
+// called n times.
+func (b *{{$mock.MockName}}Builder{{$mock.TypeArgs}}) Expect{{.Name}}Called(n int) *{{$mock.MockName}}Builder{{$mock.TypeArgs}} {
+	if b.calls == nil {
+		b.calls = make(map[string]int)
+	}
+	b.calls["{{.Name}}"] = n
+	return b
+}
+{{- end}}
+
+// FailFast makes the calls to the methods of the mock with a Func which
+// exceed the number of calls expected by the builder fail the test t with
+// Fatalf during the call, rather than at the verification. The mock must
+// then only be called by the goroutine of the test.
+func (b *{{.MockName}}Builder{{.TypeArgs}}) FailFast(t {{$testing}}.TB) *{{.MockName}}Builder{{.TypeArgs}} {
+	b.failFast = t
+	return b
+}
+
+// Build returns a new {{.MockName}} with the Funcs of the builder, along with
+// the verification of the calls to the mock expected by the builder.
+func (b *{{.MockName}}Builder{{.TypeArgs}}) Build() (*{{.MockName}}{{.TypeArgs}}, func(t {{$testing}}.TB)) {
+	mock := &{{.MockName}}{{.TypeArgs}}{
+	{{- range .Methods}}
+		{{.FuncName}}: b.funcs.{{.Name}},
+	{{- end}}
+	}
+	calls := make(map[string]int, len(b.calls))
+	for method, n := range b.calls {
+		calls[method] = n
+	}
+	if t := b.failFast; t != nil {
+	{{- range .Methods}}
+		if want, ok := calls["{{.Name}}"]; ok && mock.{{.FuncName}} != nil {
+			f := mock.{{.FuncName}}
+			mock.{{.FuncName}} = func({{.ArgList}}) {{.ReturnArgTypeList}} {
+				if got := {{if $.Light}}mock.{{.CountName}}(){{else}}len(mock.{{.CallsName}}()){{end}}; got > want {
+					t.Fatalf("{{$mock.MockName}}.{{.Name}} calls = %d, want %d", got, want)
+				}
+				{{if .Returns}}return {{end}}f({{.ArgCallList}})
+			}
+		}
+	{{- end}}
+	}
+	return mock, func(t {{$testing}}.TB) {
+		t.Helper()
+	{{- range .Methods}}
+		if want, ok := calls["{{.Name}}"]; ok {
+			if got := {{if $.Light}}mock.{{.CountName}}(){{else}}len(mock.{{.CallsName}}()){{end}}; got != want {
+				t.Errorf("{{$mock.MockName}}.{{.Name}} calls = %d, want %d", got, want)
+			}
+		}
+	{{- end}}
+	}
+}
+{{- end}}
+{{- if $.Golden}}
+{{- $testing := PkgQualifier $.Imports "testing"}}
+{{- $os := PkgQualifier $.Imports "os"}}
+
+// {{$h.SaveCallsGolden}} writes the calls made to the mock to the golden file at
+// path, as JSON keyed by method, for {{$h.AssertCallsGolden}} to compare the
+// calls of later runs with.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.SaveCallsGolden}}(t {{$testing}}.TB, path string) {
+	t.Helper()
+	if err := {{$os}}.MkdirAll({{PkgQualifier $.Imports "path/filepath"}}.Dir(path), 0750); err != nil {
+		t.Fatalf("saving the calls of {{.MockName}}: %v", err)
+	}
+	if err := {{$os}}.WriteFile(path, {{$m}}.{{$h.callsGolden}}(t), 0644); err != nil {
+		t.Fatalf("saving the calls of {{.MockName}}: %v", err)
+	}
+}
+
+// {{$h.AssertCallsGolden}} fails the test unless the calls made to the mock are
+// those of the golden file at path, which it writes instead when the
+// -update flag the tests define is set, or the MIRIP_UPDATE environment
+// variable.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.AssertCallsGolden}}(t {{$testing}}.TB, path string) {
+	t.Helper()
+	if f := {{PkgQualifier $.Imports "flag"}}.Lookup("update"); f != nil && f.Value.String() == "true" || {{$os}}.Getenv("MIRIP_UPDATE") != "" {
+		{{$m}}.{{$h.SaveCallsGolden}}(t, path)
+		return
+	}
+
+	want, err := {{$os}}.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading the golden calls of {{.MockName}}: %v, update them with -update", err)
+	}
+	if got := {{$m}}.{{$h.callsGolden}}(t); !{{PkgQualifier $.Imports "bytes"}}.Equal(got, want) {
+		t.Errorf("calls of {{.MockName}} differ from %s, update them with -update\ngot:\n%s\nwant:\n%s", path, got, want)
+	}
+}
+
+// {{$h.callsGolden}} returns the calls made to the mock as indented JSON, keyed
+// by method, along with their arguments which can be encoded.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.callsGolden}}(t {{$testing}}.TB) []byte {
+	t.Helper()
+	calls := map[string][]map[string]interface{}{
+	{{- range .Methods}}
+		"{{.Name}}": {},
+	{{- end}}
+	}
+	{{- range .Methods}}
+	{{- $params := .EncodableParams}}
+	for {{if $params}}_, call := {{end}}range {{$m}}.{{.CallsName}}() {
+		calls["{{.Name}}"] = append(calls["{{.Name}}"], map[string]interface{}{
+		{{- range $params}}
+			"{{.Name}}": call.{{.Name | Exported}},
+		{{- end}}
+		})
+	}
+	{{- end}}
+
+	data, err := {{PkgQualifier $.Imports "encoding/json"}}.MarshalIndent(calls, "", "\t")
+	if err != nil {
+		t.Fatalf("encoding the calls of {{.MockName}}: %v", err)
+	}
+	return append(data, '\n')
+}
+{{- end}}
+{{- if $.Coverage}}
+
+func init() {
+	(*{{.MockName}})(nil).{{$h.writeCoverage}}("mock"{{range .Methods}}, "{{.Name}}"{{end}})
+}
+
+// {{$h.writeCoverage}} appends a line for the event of each of the given methods
+// to the coverage file named by the MIRIP_COVERAGE environment variable,
+// if set. The file is reported on by mirip coverage.
+func (*{{.MockName}}) {{$h.writeCoverage}}(event string, methods ...string) {
+	{{- $os := PkgQualifier $.Imports "os"}}
+	name := {{$os}}.Getenv("MIRIP_COVERAGE")
+	if name == "" {
+		return
+	}
+
+	f, err := {{$os}}.OpenFile(name, {{$os}}.O_APPEND|{{$os}}.O_CREATE|{{$os}}.O_WRONLY, 0644)
+	if err != nil {
+		return
+	}
+	defer f.Close()
+
+	var b {{PkgQualifier $.Imports "strings"}}.Builder
+	for _, method := range methods {
+		b.WriteString(event + " {{$.PkgName}}.{{.MockName}}." + method + "\n")
+	}
+	_, _ = f.WriteString(b.String())
+}
+{{- end}}
+{{- if eq $.Assert "cmp"}}
+
+// {{$h.assertCalledWith}} fails the test unless one of the calls to the method
+// equals want, reporting the diff of each call otherwise.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.assertCalledWith}}(t {{PkgQualifier $.Imports "testing"}}.TB, method string, want interface{}, calls interface{}) {
+	{{- $cmp := PkgQualifier $.Imports "github.com/google/go-cmp/cmp"}}
+	t.Helper()
+	v := {{PkgQualifier $.Imports "reflect"}}.ValueOf(calls)
+	if v.Len() == 0 {
+		t.Errorf("{{.MockName}}.%s was not called", method)
+		return
+	}
+
+	var diffs string
+	for i := 0; i < v.Len(); i++ {
+		call := v.Index(i).Interface()
+		if {{$cmp}}.Equal(want, call, {{$m}}.{{$h.CmpOptions}}...) {
+			return
+		}
+		diffs += {{PkgQualifier $.Imports "fmt"}}.Sprintf("\ncall %d (-want +got):\n%s", i, {{$cmp}}.Diff(want, call, {{$m}}.{{$h.CmpOptions}}...))
+	}
+	t.Errorf("{{.MockName}}.%s was not called with the arguments:%s", method, diffs)
+}
+{{- end}}
+{{- if eq $.Stub "random"}}
+
+// {{$h.randomize}} sets the values pointed to by ptrs to random values of their
+// type. Values of types which can't be generated, such as interfaces, are
+// left unchanged.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.randomize}}(ptrs ...interface{}) {
+	{{- $rand := PkgQualifier $.Imports "math/rand"}}
+	{{- $reflect := PkgQualifier $.Imports "reflect"}}
+	{{$m}}.{{$lock}}{{if $.LockPerMethod}}.{{$h.Rand}}{{end}}.Lock()
+	defer {{$m}}.{{$lock}}{{if $.LockPerMethod}}.{{$h.Rand}}{{end}}.Unlock()
+	if {{$m}}.{{$h.Rand}} == nil {
+		{{$m}}.{{$h.Rand}} = {{$rand}}.New({{$rand}}.NewSource({{PkgQualifier $.Imports "time"}}.Now().UnixNano()))
+	}
+	for _, ptr := range ptrs {
+		v := {{$reflect}}.ValueOf(ptr).Elem()
+		if value, ok := {{PkgQualifier $.Imports "testing/quick"}}.Value(v.Type(), {{$m}}.{{$h.Rand}}); ok {
+			v.Set(value)
+		}
+	}
+}
+{{- end}}
+{{- if eq $.Stub "fuzz"}}
+{{- $reflect := PkgQualifier $.Imports "reflect"}}
+
+// {{.MockName}}FromFuzz returns a {{.MockName}} whose methods without a Func
+// return results decoded from the input of a fuzz target, for the fuzzer to
+// drive the code under test through the mock, ex:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		svc := NewService({{.MockName}}FromFuzz(data))
+//		...
+//	})
+func {{.MockName}}FromFuzz{{.TypeParams}}(data []byte) *{{.MockName}}{{.TypeArgs}} {
+	return &{{.MockName}}{{.TypeArgs}}{ {{- $h.Fuzz}}: data}
+}
+
+// {{$h.fuzz}} sets the values pointed to by ptrs to values decoded from
+// {{$h.Fuzz}}, consuming it. Values of types which can't be decoded, such as
+// functions and interfaces other than error, are left unchanged.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.fuzz}}(ptrs ...interface{}) {
+	{{$m}}.{{$lock}}{{if $.LockPerMethod}}.{{$h.Fuzz}}{{end}}.Lock()
+	defer {{$m}}.{{$lock}}{{if $.LockPerMethod}}.{{$h.Fuzz}}{{end}}.Unlock()
+	for _, ptr := range ptrs {
+		{{$m}}.{{$h.fuzzValue}}({{$reflect}}.ValueOf(ptr).Elem(), 0)
+	}
+}
+
+// {{$h.fuzzValue}} sets v to a value decoded from {{$h.Fuzz}}: a byte selects
+// whether errors and pointers are nil, and the length of strings, slices and
+// maps, while numbers take as many bytes as their size. Nested values
+// deeper than a few levels, such as those of recursive types, are zero.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.fuzzValue}}(v {{$reflect}}.Value, depth int) {
+	if len({{$m}}.{{$h.Fuzz}}) == 0 || depth > 4 {
+		return
+	}
+	if v.Type() == {{$reflect}}.TypeOf((*error)(nil)).Elem() {
+		if {{$m}}.{{$h.fuzzBytes}}(1)[0]%2 == 1 {
+			v.Set({{$reflect}}.ValueOf({{PkgQualifier $.Imports "errors"}}.New("fuzzed error")))
+		}
+		return
+	}
+	uint64Of := func(size uintptr) uint64 {
+		var u uint64
+		for i, b := range {{$m}}.{{$h.fuzzBytes}}(int(size)) {
+			u |= uint64(b) << (8 * i)
+		}
+		return u
+	}
+	switch v.Kind() {
+	case {{$reflect}}.Bool:
+		v.SetBool({{$m}}.{{$h.fuzzBytes}}(1)[0]%2 == 1)
+	case {{$reflect}}.Int, {{$reflect}}.Int8, {{$reflect}}.Int16, {{$reflect}}.Int32, {{$reflect}}.Int64:
+		v.SetInt(int64(uint64Of(v.Type().Size())))
+	case {{$reflect}}.Uint, {{$reflect}}.Uint8, {{$reflect}}.Uint16, {{$reflect}}.Uint32, {{$reflect}}.Uint64, {{$reflect}}.Uintptr:
+		v.SetUint(uint64Of(v.Type().Size()))
+	case {{$reflect}}.Float32:
+		v.SetFloat(float64({{PkgQualifier $.Imports "math"}}.Float32frombits(uint32(uint64Of(4)))))
+	case {{$reflect}}.Float64:
+		v.SetFloat({{PkgQualifier $.Imports "math"}}.Float64frombits(uint64Of(8)))
+	case {{$reflect}}.String:
+		v.SetString(string({{$m}}.{{$h.fuzzBytes}}(int({{$m}}.{{$h.fuzzBytes}}(1)[0]))))
+	case {{$reflect}}.Slice:
+		n := int({{$m}}.{{$h.fuzzBytes}}(1)[0] % 8)
+		v.Set({{$reflect}}.MakeSlice(v.Type(), n, n))
+		for i := 0; i < n; i++ {
+			{{$m}}.{{$h.fuzzValue}}(v.Index(i), depth+1)
+		}
+	case {{$reflect}}.Array:
+		for i := 0; i < v.Len(); i++ {
+			{{$m}}.{{$h.fuzzValue}}(v.Index(i), depth+1)
+		}
+	case {{$reflect}}.Map:
+		n := int({{$m}}.{{$h.fuzzBytes}}(1)[0] % 8)
+		v.Set({{$reflect}}.MakeMapWithSize(v.Type(), n))
+		for i := 0; i < n; i++ {
+			key, elem := {{$reflect}}.New(v.Type().Key()).Elem(), {{$reflect}}.New(v.Type().Elem()).Elem()
+			{{$m}}.{{$h.fuzzValue}}(key, depth+1)
+			{{$m}}.{{$h.fuzzValue}}(elem, depth+1)
+			v.SetMapIndex(key, elem)
+		}
+	case {{$reflect}}.Ptr:
+		if {{$m}}.{{$h.fuzzBytes}}(1)[0]%2 == 1 {
+			elem := {{$reflect}}.New(v.Type().Elem())
+			{{$m}}.{{$h.fuzzValue}}(elem.Elem(), depth+1)
+			v.Set(elem)
+		}
+	case {{$reflect}}.Struct:
+		for i := 0; i < v.NumField(); i++ {
+			if v.Field(i).CanSet() {
+				{{$m}}.{{$h.fuzzValue}}(v.Field(i), depth+1)
+			}
+		}
+	}
+}
+
+// {{$h.fuzzBytes}} consumes up to n bytes of {{$h.Fuzz}}, padded with zeros
+// once it is exhausted.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.fuzzBytes}}(n int) []byte {
+	b := make([]byte, n)
+	k := copy(b, {{$m}}.{{$h.Fuzz}})
+	{{$m}}.{{$h.Fuzz}} = {{$m}}.{{$h.Fuzz}}[k:]
+	return b
+}
+{{- end}}
+{{- if eq $.Stub "fixture"}}
+
+// {{$h.fixtureCall}} returns the index of the last of the given number of calls
+// to method, panicking if the fixture holds fewer results of the method.
+func ({{$m}} *{{.MockName}}) {{$h.fixtureCall}}(method string, calls, results int) int {
+	if calls > results {
+		panic({{PkgQualifier $.Imports "fmt"}}.Sprintf("{{.MockName}}.%sFunc: method is nil but the fixture holds the results of %d calls out of %d", method, results, calls))
+	}
+	return calls - 1
+}
+
+// {{$h.fixtureError}} returns the error of the fixture with the given message,
+// or nil if it is empty.
+func (*{{.MockName}}) {{$h.fixtureError}}(msg string) error {
+	if msg == "" {
+		return nil
+	}
+	return {{PkgQualifier $.Imports "errors"}}.New(msg)
+}
+{{- end}}
+{{- if $.RecordSwitch}}
+
+// {{$h.DisableRecording}} stops the mock from recording calls, such as for long
+// stress tests, until {{$h.EnableRecording}}. The calls recorded so far are kept.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.DisableRecording}}() {
+	{{$m}}.{{$h.setRecordingDisabled}}(true)
+}
+
+// {{$h.EnableRecording}} resumes the recording of calls after {{$h.DisableRecording}}.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.EnableRecording}}() {
+	{{$m}}.{{$h.setRecordingDisabled}}(false)
+}
+
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.setRecordingDisabled}}(disabled bool) {
+{{- if $.LockPerMethod}}
+{{- range .Methods}}
+	{{$m}}.{{$lock}}.{{.Name}}.Lock()
+{{- end}}
+	{{$m}}.{{$h.recordingDisabled}} = disabled
+{{- range .Methods}}
+	{{$m}}.{{$lock}}.{{.Name}}.Unlock()
+{{- end}}
+{{- else}}
+	{{$m}}.{{$lock}}.Lock()
+	defer {{$m}}.{{$lock}}.Unlock()
+	{{$m}}.{{$h.recordingDisabled}} = disabled
+{{- end}}
+}
+
+// {{$h.firstCall}} returns the index of the first of the given number of calls
+// to keep when recording another, for keeping at most {{$h.MaxCalls}}.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.firstCall}}(calls int) int {
+	if {{$m}}.{{$h.MaxCalls}} <= 0 || calls < {{$m}}.{{$h.MaxCalls}} {
+		return 0
+	}
+	return calls - {{$m}}.{{$h.MaxCalls}} + 1
+}
+{{- end}}
+{{- if $.Report}}
+{{- $mirip := PkgQualifier $.Imports "github.com/gmhafiz/mirip/runtime"}}
+
+// {{$h.reportCall}} records the call of the method for the report of the calls.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.reportCall}}(method string, args ...{{$mirip}}.Arg) {
+	call := {{$mirip}}.Call{Seq: {{$mirip}}.NextSeq(), Mock: "{{.MockName}}", Method: method, Args: args}
+	{{$m}}.{{$h.reportLock}}.Lock()
+	{{$m}}.{{$h.reportCalls}} = append({{$m}}.{{$h.reportCalls}}, call)
+	{{$m}}.{{$h.reportLock}}.Unlock()
+}
+
+// {{$h.MiripCalls}} returns the calls made to the mock along with their order
+// among the calls to every mock, reported by mirip.Report.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.MiripCalls}}() []{{$mirip}}.Call {
+	{{$m}}.{{$h.reportLock}}.Lock()
+	defer {{$m}}.{{$h.reportLock}}.Unlock()
+	return append([]{{$mirip}}.Call(nil), {{$m}}.{{$h.reportCalls}}...)
+}
+{{- end}}
+{{- if $.WithResets}}
+
+// {{$h.Reset}} clears the calls recorded by the mock{{if eq $.Stub "fixture"}}, rewinding its fixture{{end}}.
+func ({{$m}} *{{.MockName}}{{.TypeArgs}}) {{$h.Reset}}() {
+{{- if $.LockPerMethod}}
+{{- range .Methods}}
+	{{$m}}.{{$lock}}.{{.Name}}.Lock()
+	{{$m}}.{{$calls}}.{{.Name}} = nil
+	{{$m}}.{{$lock}}.{{.Name}}.Unlock()
+{{- end}}
+{{- else}}
+	{{$m}}.{{$lock}}.Lock()
+	defer {{$m}}.{{$lock}}.Unlock()
+{{- range .Methods}}
+	{{$m}}.{{$calls}}.{{.Name}} = nil
+{{- end}}
+{{- end}}
+{{- if eq $.Stub "fixture"}}
+{{- range .Methods}}
+{{- if .Returns}}
+	{{$m}}.{{$h.fixtureCalls}}.{{.Name}}.Store(0)
+{{- end}}
+{{- end}}
+{{- end}}
+{{- if $.Report}}
+	{{$m}}.{{$h.reportLock}}.Lock()
+	{{$m}}.{{$h.reportCalls}} = nil
+	{{$m}}.{{$h.reportLock}}.Unlock()
+{{- end}}
+}
+{{- end}}
+{{- if eq $.Flavor "ginkgo"}}
+{{- $gomega := PkgQualifier $.Imports "github.com/onsi/gomega"}}
+{{- $types := PkgQualifier $.Imports "github.com/onsi/gomega/types"}}
+{{- range .Methods}}
+
+// Have{{$mock.MockName}}{{.Name}}Calls returns a matcher for a *{{$mock.MockName}}
+// succeeding when {{.Name}} was called and its calls satisfy all the given
+// matchers, ex:
+//
+//	Expect(mock).To(Have{{$mock.MockName}}{{.Name}}Calls(HaveLen(2)))
+func Have{{$mock.MockName}}{{.Name}}Calls(matchers ...{{$types}}.GomegaMatcher) {{$types}}.GomegaMatcher {
+	return {{$gomega}}.WithTransform(func(m *{{$mock.MockName}}{{$mock.TypeArgs}}) []{{template "callStruct" .}} {
+		return m.{{.CallsName}}()
+	}, {{$gomega}}.And(append([]{{$types}}.GomegaMatcher{ {{- $gomega}}.Not({{$gomega}}.BeEmpty())}, matchers...)...))
+}
+{{- end}}
+{{- end}}
+
+{{end}}
+{{- if eq .Flavor "testify"}}
+// MockSet aggregates the mocks generated in this file. It embeds
+// suite.Suite so that a test suite embedding MockSet gets new mocks before
+// each test, and fails tests leaving stubbed methods of the mocks uncalled.
+type MockSet struct {
+	{{PkgQualifier .Imports "github.com/stretchr/testify/suite"}}.Suite
+{{range .Mocks}}
+	{{.MockName}} *{{.MockName}}
+{{- end}}
+}
+
+// SetupTest replaces the mocks of the set with new instances.
+func (s *MockSet) SetupTest() {
+{{- range .Mocks}}
+	s.{{.MockName}} = &{{.MockName}}{}
+{{- end}}
+}
+
+// TearDownTest fails the test for every method of the mocks of the set
+// which was stubbed but never called.
+func (s *MockSet) TearDownTest() {
+{{- range $mock := .Mocks}}
+{{- range .Methods}}
+	if s.{{$mock.MockName}}.{{.FuncName}} != nil && len(s.{{$mock.MockName}}.{{.CallsName}}()) == 0 {
+		s.T().Errorf("{{$mock.MockName}}.{{.Name}} was stubbed but never called")
+	}
+{{- end}}
+{{- end}}
+}
+{{end}}
+{{- template "provider" .}}
+`
+
+// chaosTemplate is the template for fault-injecting wrappers.
+// language=GoTemplate
+var chaosTemplate = `{{template "header" .}}
+{{- $sync := PkgQualifier .Imports "sync"}}
+{{- $m := .Receiver}}
+{{- $time := PkgQualifier .Imports "time"}}
+{{- $rand := PkgQualifier .Imports "math/rand"}}
+{{range $i, $mock := .Mocks -}}
+{{- $h := .Helpers}}
+{{- $calls := index $h $.CallsField}}
+{{- $lock := index $h $.LockField}}
+
+{{- if not $.SkipEnsure}}
+{{template "ensure" .}}
+
+{{end -}}
+// {{.MockName}} is a fault-injecting wrapper around {{.SrcPkgQualifier}}{{.InterfaceName}}.
+// Calls are delegated to {{$h.Impl}} unless the policy of the method decides to
+// inject a fault.
+type {{.MockName}} struct {
+	// {{$h.Impl}} is the implementation calls are delegated to.
+	{{$h.Impl}} {{.SrcPkgQualifier}}{{.InterfaceName}}
+
+	// {{$h.Policy}} applies to methods without an entry in {{$h.MethodPolicy}}.
+	{{$h.Policy}} {{.MockName}}Policy
+
+	// {{$h.MethodPolicy}} overrides {{$h.Policy}} for the methods it is keyed by.
+	{{$h.MethodPolicy}} map[string]{{.MockName}}Policy
+
+	// {{$h.Rand}} is the source used for Probability. It is seeded with the
+	// current time when nil.
+	{{$h.Rand}} *{{$rand}}.Rand
+
+	{{$lock}}  {{$sync}}.Mutex
+	{{$calls}} map[string]int
+}
+
+// {{.MockName}}Policy describes when and which faults are injected into
+// calls of {{.MockName}}.
+type {{.MockName}}Policy struct {
+	// Probability is the chance, between 0 and 1, of a call being faulted.
+	Probability float64
+
+	// Nth faults every nth call of a method when greater than 0.
+	Nth int
+
+	// Err is returned from a faulted call instead of delegating to {{$h.Impl}}.
+	// It is only returned by methods whose last result is an error.
+	Err error
+
+	// Delay is waited before a faulted call returns or is delegated.
+	Delay {{$time}}.Duration
+}
+
+{{- range .Methods}}
+
+func ({{$m}} *{{$mock.MockName}}) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
+{{- if .ReturnsError}}
+	if err := {{$m}}.{{$h.inject}}("{{.Name}}"); err != nil {
+		{{- range .LeadingReturns}}
+		var {{.Name}} {{.TypeString}}
+		{{- end}}
+		return {{range .LeadingReturns}}{{.Name}}, {{end}}err
+	}
+{{- else}}
+	_ = {{$m}}.{{$h.inject}}("{{.Name}}")
+{{- end}}
+	{{if .Returns}}return {{end}}{{$m}}.{{$h.Impl}}.{{.Name}}({{.ArgCallList}})
+}
+{{- end}}
+
+// {{$h.inject}} counts a call to the given method and, if the call is to be
+// faulted, waits for the policy delay and returns the policy error.
+func ({{$m}} *{{.MockName}}) {{$h.inject}}(method string) error {
+	{{$m}}.{{$lock}}.Lock()
+	policy, ok := {{$m}}.{{$h.MethodPolicy}}[method]
+	if !ok {
+		policy = {{$m}}.{{$h.Policy}}
+	}
+	if {{$m}}.{{$calls}} == nil {
+		{{$m}}.{{$calls}} = make(map[string]int)
+	}
+	{{$m}}.{{$calls}}[method]++
+	if {{$m}}.{{$h.Rand}} == nil {
+		{{$m}}.{{$h.Rand}} = {{$rand}}.New({{$rand}}.NewSource({{$time}}.Now().UnixNano()))
+	}
+	fault := policy.Nth > 0 && {{$m}}.{{$calls}}[method]%policy.Nth == 0 ||
+		policy.Probability > 0 && {{$m}}.{{$h.Rand}}.Float64() < policy.Probability
+	{{$m}}.{{$lock}}.Unlock()
+
+	if !fault {
+		return nil
+	}
+	{{$time}}.Sleep(policy.Delay)
+	return policy.Err
+}
+
+{{end}}
+{{- template "provider" .}}
+`
+
+// recorderTemplate is the template for the recorders of the calls to
+// implementations and the replayers of the recorded calls.
+// language=GoTemplate
+var recorderTemplate = `{{template "header" .}}
+{{- $sync := PkgQualifier .Imports "sync"}}
+{{- $json := PkgQualifier .Imports "encoding/json"}}
+{{- $bytes := PkgQualifier .Imports "bytes"}}
+{{- $errors := PkgQualifier .Imports "errors"}}
+{{- $fmt := PkgQualifier .Imports "fmt"}}
+{{- $io := PkgQualifier .Imports "io"}}
+{{- $m := .Receiver}}
+{{range $i, $mock := .Mocks -}}
+{{- $h := .Helpers}}
+{{- $calls := index $h $.CallsField}}
+{{- $lock := index $h $.LockField}}
+
+{{- if not $.SkipEnsure}}
+// Ensure that {{.MockName}} and {{.MockName}}Replayer implement {{.SrcPkgQualifier}}{{.InterfaceName}}.
+var (
+	_ {{.SrcPkgQualifier}}{{.InterfaceName}} = {{.EnsureOf .MockName}}
+	_ {{.SrcPkgQualifier}}{{.InterfaceName}} = {{.EnsureOf (print .MockName "Replayer")}}
+{{- range .InterfaceAliases}}
+	_ {{$mock.SrcPkgQualifier}}{{.}} = {{$mock.EnsureOf $mock.MockName}}
+	_ {{$mock.SrcPkgQualifier}}{{.}} = {{$mock.EnsureOf (print $mock.MockName "Replayer")}}
+{{- end}}
+)
+
+{{end -}}
+// {{.MockName}} is a recording wrapper around {{.SrcPkgQualifier}}{{.InterfaceName}}.
+// Calls are delegated to {{$h.Impl}} and recorded along with their results, to
+// be written with {{$h.WriteFixture}} and replayed by {{.MockName}}Replayer.
+// Functions, channels and interfaces other than errors are not recorded.
+type {{.MockName}} struct {
+	// {{$h.Impl}} is the implementation calls are delegated to.
+	{{$h.Impl}} {{.SrcPkgQualifier}}{{.InterfaceName}}
+
+	{{$lock}}  {{$sync}}.Mutex
+	{{$calls}} []{{.MockName}}Call
+	{{$h.recordErr}} error
+}
+
+// {{.MockName}}Call is a call recorded by {{.MockName}}, with its arguments
+// and results encoded as JSON.
+type {{.MockName}}Call struct {
+	Method  string            ` + "`" + `json:"method"` + "`" + `
+	Args    []{{$json}}.RawMessage ` + "`" + `json:"args"` + "`" + `
+	Results []{{$json}}.RawMessage ` + "`" + `json:"results"` + "`" + `
+}
+
+{{- range .Methods}}
+
+func ({{$m}} *{{$mock.MockName}}) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
+	{{if .Returns}}{{.ReturnArgNameList}} := {{end}}{{$m}}.{{$h.Impl}}.{{.Name}}({{.ArgCallList}})
+	{{$m}}.{{$h.record}}("{{.Name}}", []interface{}{
+	{{- range $j, $p := .Params}}{{if $j}}, {{end}}{{if .Var.Encodable}}{{.Name}}{{else}}nil{{end}}{{end -}}
+	}, []interface{}{
+	{{- range $j, $p := .Returns}}{{if $j}}, {{end}}{{if eq .TypeString "error"}}{{$m}}.{{$h.recordError}}({{.Name}}){{else if .Var.Encodable}}{{.Name}}{{else}}nil{{end}}{{end -}}
+	})
+	{{- if .Returns}}
+	return {{.ReturnArgNameList}}
+	{{- end}}
+}
+{{- end}}
+
+// {{$h.WriteFixture}} writes the calls recorded so far as JSON, for
+// New{{.MockName}}Replayer to replay. It fails if arguments or results
+// of the calls could not be encoded.
+func ({{$m}} *{{.MockName}}) {{$h.WriteFixture}}(w {{$io}}.Writer) error {
+	{{$m}}.{{$lock}}.Lock()
+	defer {{$m}}.{{$lock}}.Unlock()
+	if {{$m}}.{{$h.recordErr}} != nil {
+		return {{$m}}.{{$h.recordErr}}
+	}
+	enc := {{$json}}.NewEncoder(w)
+	enc.SetIndent("", "\t")
+	return enc.Encode({{$m}}.{{$calls}})
+}
+
+// {{$h.record}} encodes and records a call to the given method.
+func ({{$m}} *{{.MockName}}) {{$h.record}}(method string, args, results []interface{}) {
+	call := {{.MockName}}Call{
+		Method:  method,
+		Args:    make([]{{$json}}.RawMessage, len(args)),
+		Results: make([]{{$json}}.RawMessage, len(results)),
+	}
+	var err error
+	for i := 0; i < len(args) && err == nil; i++ {
+		call.Args[i], err = {{$json}}.Marshal(args[i])
+	}
+	for i := 0; i < len(results) && err == nil; i++ {
+		call.Results[i], err = {{$json}}.Marshal(results[i])
+	}
+
+	{{$m}}.{{$lock}}.Lock()
+	defer {{$m}}.{{$lock}}.Unlock()
+	if err != nil && {{$m}}.{{$h.recordErr}} == nil {
+		{{$m}}.{{$h.recordErr}} = {{$fmt}}.Errorf("recording %s: %w", method, err)
+	}
+	{{$m}}.{{$calls}} = append({{$m}}.{{$calls}}, call)
+}
+
+// {{$h.recordError}} records an error by its message.
+func ({{$m}} *{{.MockName}}) {{$h.recordError}}(err error) *string {
+	if err == nil {
+		return nil
+	}
+	msg := err.Error()
+	return &msg
+}
+
+// {{.MockName}}Replayer is a stub of {{.SrcPkgQualifier}}{{.InterfaceName}} replaying the calls
+// recorded by {{.MockName}}. Each call returns the results of the first
+// recorded call of the method with the same arguments which wasn't
+// replayed yet, and panics if there is none. Errors are replayed with
+// their message only, while the results which are not recorded are zero.
+type {{.MockName}}Replayer struct {
+	{{$lock}}  {{$sync}}.Mutex
+	{{$calls}} []{{.MockName}}Call
+	{{$h.replayed}} []bool
+}
+
+// New{{.MockName}}Replayer returns a replayer of the calls in the fixture
+// written by {{.MockName}}.WriteFixture.
+func New{{.MockName}}Replayer(r {{$io}}.Reader) (*{{.MockName}}Replayer, error) {
+	var calls []{{.MockName}}Call
+	if err := {{$json}}.NewDecoder(r).Decode(&calls); err != nil {
+		return nil, err
+	}
+	// The arguments are compacted, as the fixture may be indented, to be
+	// compared with those of the calls.
+	for _, call := range calls {
+		for i, arg := range call.Args {
+			var buf {{$bytes}}.Buffer
+			if err := {{$json}}.Compact(&buf, arg); err != nil {
+				return nil, err
+			}
+			call.Args[i] = buf.Bytes()
+		}
+	}
+	return &{{.MockName}}Replayer{ {{- $calls}}: calls, {{$h.replayed}}: make([]bool, len(calls))}, nil
+}
+
+{{- range .Methods}}
+
+func ({{$m}} *{{$mock.MockName}}Replayer) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
+	{{- $method := .Name}}
+	{{if .ReplaysResults}}results := {{end}}{{$m}}.{{$h.replay}}("{{.Name}}", []interface{}{
+	{{- range $j, $p := .Params}}{{if $j}}, {{end}}{{if .Var.Encodable}}{{.Name}}{{else}}nil{{end}}{{end -}}
+	})
+	{{- range $j, $p := .Returns}}
+	{{- if eq .TypeString "error"}}
+	{{.Name}} := {{$m}}.{{$h.replayError}}("{{$method}}", results[{{$j}}])
+	{{- else}}
+	var {{.Name}} {{.TypeString}}
+	{{- if .Var.Encodable}}
+	{{$m}}.{{$h.decode}}("{{$method}}", results[{{$j}}], &{{.Name}})
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	{{- if .Returns}}
+	return {{.ReturnArgNameList}}
+	{{- end}}
+}
+{{- end}}
+
+// {{$h.replay}} returns the results of the first recorded call to method with
+// the given arguments which wasn't replayed yet.
+func ({{$m}} *{{.MockName}}Replayer) {{$h.replay}}(method string, args []interface{}) []{{$json}}.RawMessage {
+	encoded := make([]{{$json}}.RawMessage, len(args))
+	for i := range args {
+		var err error
+		if encoded[i], err = {{$json}}.Marshal(args[i]); err != nil {
+			panic({{$fmt}}.Sprintf("{{.MockName}}Replayer: encoding the arguments of %s: %v", method, err))
+		}
+	}
+
+	{{$m}}.{{$lock}}.Lock()
+	defer {{$m}}.{{$lock}}.Unlock()
+	for i, call := range {{$m}}.{{$calls}} {
+		if {{$m}}.{{$h.replayed}}[i] || call.Method != method || len(call.Args) != len(encoded) {
+			continue
+		}
+		match := true
+		for j := range encoded {
+			match = match && {{$bytes}}.Equal(call.Args[j], encoded[j])
+		}
+		if match {
+			{{$m}}.{{$h.replayed}}[i] = true
+			return call.Results
+		}
+	}
+	panic({{$fmt}}.Sprintf("{{.MockName}}Replayer: no recorded call of %s with the arguments %s left to replay", method, encoded))
+}
+
+// {{$h.replayError}} decodes an error recorded by its message.
+func ({{$m}} *{{.MockName}}Replayer) {{$h.replayError}}(method string, data {{$json}}.RawMessage) error {
+	var msg *string
+	{{$m}}.{{$h.decode}}(method, data, &msg)
+	if msg == nil {
+		return nil
+	}
+	return {{$errors}}.New(*msg)
+}
+
+// {{$h.decode}} decodes a recorded result of method into v.
+func ({{$m}} *{{.MockName}}Replayer) {{$h.decode}}(method string, data {{$json}}.RawMessage, v interface{}) {
+	if err := {{$json}}.Unmarshal(data, v); err != nil {
+		panic({{$fmt}}.Sprintf("{{.MockName}}Replayer: decoding the results of %s: %v", method, err))
+	}
+}
+
+{{end}}
+{{- template "provider" .}}
+`
+
+// stubTemplate is the template for stubs, which neither record nor lock
+// the calls of their methods.
+// language=GoTemplate
+var stubTemplate = `{{template "header" .}}
+{{- $m := .Receiver}}
+{{range $i, $mock := .Mocks -}}
+
+{{- if not $.SkipEnsure}}
+{{template "ensure" .}}
+
+{{end -}}
+// {{.MockName}} is a stub implementation of {{.SrcPkgQualifier}}{{.InterfaceName}}.
+// Its methods call their Func, if set, and return the zero values
+// otherwise, without recording or locking the calls, so that calling them
+// doesn't allocate.
+{{- if .Delegated}}
+// The methods it doesn't stub are delegated to the embedded
+// {{.SrcPkgQualifier}}{{.InterfaceName}}, to be set before calling them.
+{{- end}}
+type {{.MockName}}{{.TypeParams}} struct {
+{{- if .Delegated}}
+	{{.SrcPkgQualifier}}{{.InterfaceName}}{{.TypeArgs}}
+{{end}}
+{{- range .Methods}}
+	{{.FuncName}} func({{.ArgList}}) {{.ReturnArgTypeList}}{{end}}
+}
+
+{{- range .Methods}}
+
+func ({{$m}} *{{$mock.MockName}}{{$mock.TypeArgs}}) {{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}} {
+{{- if .Returns}}
+	if {{$m}}.{{.FuncName}} != nil {
+		return {{$m}}.{{.FuncName}}({{.ArgCallList}})
+	}
+	var (
+	{{- range .Returns}}
+		{{.Name}} {{.TypeString}}
+	{{- end}}
+	)
+	{{- $method := .}}
+	{{- if $.NotImplemented}}
+	{{- range .Returns}}
+	{{- if eq .TypeString "error"}}
+	{{.Name}} = {{PkgQualifier $.Imports "github.com/gmhafiz/mirip/runtime"}}.NotImplemented("{{$mock.MockName}}.{{$method.Name}}")
+	{{- end}}
+	{{- end}}
+	{{- end}}
+	return {{.ReturnArgNameList}}
+{{- else}}
+	if {{$m}}.{{.FuncName}} != nil {
+		{{$m}}.{{.FuncName}}({{.ArgCallList}})
+	}
+{{- end}}
+}
+{{- end}}
+
+{{end}}
+{{- template "provider" .}}
+`
+
+// fakeBuiltinTemplate is the template for the fakes of interfaces of the
+// standard library, whose methods behave like simple implementations
+// rather than calling a Func.
+// language=GoTemplate
+var fakeBuiltinTemplate = `{{template "header" .}}
+{{- $m := .Receiver}}
+{{range $i, $mock := .Mocks -}}
+
+{{- if not $.SkipEnsure}}
+{{template "ensure" .}}
+
+{{end -}}
+{{- $q := .SrcPkgQualifier}}
+{{- if eq .Builtin "io.Reader"}}
+// {{.MockName}} is a fake {{$q}}Reader reading Data, then returning Err, or
+// {{$q}}EOF if Err is nil.
+type {{.MockName}} struct {
+	Data []byte
+	Err  error
+
+	off int
+}
+
+func ({{$m}} *{{.MockName}}) Read(p []byte) (int, error) {
+	if {{$m}}.off >= len({{$m}}.Data) {
+		if {{$m}}.Err != nil {
+			return 0, {{$m}}.Err
+		}
+		return 0, {{$q}}EOF
+	}
+	n := copy(p, {{$m}}.Data[{{$m}}.off:])
+	{{$m}}.off += n
+	return n, nil
+}
+{{- else if eq .Builtin "io/fs.FS"}}
+{{- $fstest := PkgQualifier $.Imports "testing/fstest"}}
+// {{.MockName}} is a fake {{$q}}FS of the files of MapFS, keyed by their
+// slash-separated paths such as "testdata/users.json", which also
+// implements {{$q}}ReadFileFS, {{$q}}ReadDirFS, {{$q}}StatFS, {{$q}}SubFS and
+// {{$q}}GlobFS.
+type {{.MockName}} struct {
+	{{$fstest}}.MapFS
+}
+
+// {{.MockName}}Of returns a {{.MockName}} of files with the given contents,
+// keyed by path.
+func {{.MockName}}Of(files map[string]string) *{{.MockName}} {
+	mapFS := make({{$fstest}}.MapFS, len(files))
+	for name, content := range files {
+		mapFS[name] = &{{$fstest}}.MapFile{Data: []byte(content)}
+	}
+	return &{{.MockName}}{MapFS: mapFS}
+}
+{{- else if eq .Builtin "net/http.RoundTripper"}}
+{{- $bytes := PkgQualifier $.Imports "bytes"}}
+{{- $io := PkgQualifier $.Imports "io"}}
+{{- $strconv := PkgQualifier $.Imports "strconv"}}
+{{- $strings := PkgQualifier $.Imports "strings"}}
+{{- $sync := PkgQualifier $.Imports "sync"}}
+// {{.MockName}} is a fake {{$q}}RoundTripper answering the requests with
+// the response of Responses keyed by their method and URL, such as
+// "GET https://example.com/users", or else by their URL, and with a 404
+// Not Found otherwise. It records the requests, returned by Requests.
+type {{.MockName}} struct {
+	Responses map[string]{{.MockName}}Response
+
+	lock     {{$sync}}.Mutex
+	requests []*{{$q}}Request
+}
+
+// {{.MockName}}Response is a canned response of {{.MockName}}, 200 OK unless
+// Status is set. Err, if set, is returned instead of a response.
+type {{.MockName}}Response struct {
+	Status int
+	Header {{$q}}Header
+	Body   string
+	Err    error
+}
+
+func ({{$m}} *{{.MockName}}) RoundTrip(req *{{$q}}Request) (*{{$q}}Response, error) {
+	// The request is recorded with its body read, which RoundTrip must
+	// close.
+	recorded := req.Clone(req.Context())
+	if req.Body != nil {
+		body, err := {{$io}}.ReadAll(req.Body)
+		_ = req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		recorded.Body = {{$io}}.NopCloser({{$bytes}}.NewReader(body))
+	}
+	{{$m}}.lock.Lock()
+	{{$m}}.requests = append({{$m}}.requests, recorded)
+	{{$m}}.lock.Unlock()
+
+	canned, ok := {{$m}}.Responses[req.Method+" "+req.URL.String()]
+	if !ok {
+		canned, ok = {{$m}}.Responses[req.URL.String()]
+	}
+	if !ok {
+		canned = {{.MockName}}Response{Status: {{$q}}StatusNotFound}
+	}
+	if canned.Err != nil {
+		return nil, canned.Err
+	}
+	status := canned.Status
+	if status == 0 {
+		status = {{$q}}StatusOK
+	}
+	header := canned.Header.Clone()
+	if header == nil {
+		header = make({{$q}}Header)
+	}
+	return &{{$q}}Response{
+		Status:        {{$strconv}}.Itoa(status) + " " + {{$q}}StatusText(status),
+		StatusCode:    status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          {{$io}}.NopCloser({{$strings}}.NewReader(canned.Body)),
+		ContentLength: int64(len(canned.Body)),
+		Request:       req,
+	}, nil
+}
+
+// Requests returns the requests made to the fake, in order, with their
+// bodies read.
+func ({{$m}} *{{.MockName}}) Requests() []*{{$q}}Request {
+	{{$m}}.lock.Lock()
+	defer {{$m}}.lock.Unlock()
+	return append([]*{{$q}}Request(nil), {{$m}}.requests...)
+}
+{{- end}}
+
+{{end}}
+{{- template "provider" .}}
+`
+
+// testgenTemplate is the template for table-driven test skeletons of a
+// function using mocked interfaces. Unlike the other templates, its
+// output is meant to be edited.
+// language=GoTemplate
+var testgenTemplate = `package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+
+func Test{{.FuncName | Exported}}(t *testing.T) {
+	tests := []struct {
+		name string
+	{{- range .Params}}{{if not .Mock}}
+		{{.Name}} {{.TypeString}}
+	{{- end}}{{end}}
+	{{- if .ReturnsError}}
+		wantErr bool
+	{{- end}}
+	{{- range $p := .Params}}{{if .Mock}}{{range .Mock.Methods}}
+		want{{$p.Name | Exported}}{{.Name}}Calls int
+	{{- end}}{{end}}{{end}}
+	}{
+		// TODO: add test cases.
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+		{{- range $p := .Params}}{{if .Mock}}
+			{{.Name}} := &{{.Mock.MockName}}{
+			{{- range .Mock.Methods}}
+				{{.FuncName}}: func({{.ArgList}}) {{.ReturnArgList}} {
+					// TODO: stub {{$p.Mock.MockName}}.{{.Name}} for the test case.
+					{{- if .Returns}}
+					return
+					{{- end}}
+				},
+			{{- end}}
+			}
+		{{- end}}{{end}}
+
+			{{if .Results}}{{range $i, $r := .Results}}{{if $i}}, {{end}}{{$r}}{{end}} := {{end}}{{.FuncName -}}
+			({{range $i, $p := .Params}}{{if $i}}, {{end}}{{if not .Mock}}tt.{{end}}{{.CallName}}{{end}})
+		{{- if .ReturnsError}}
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("{{.FuncName}}() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		{{- end}}
+		{{- range .Results}}{{if ne . "err"}}
+			_ = {{.}} // TODO: assert {{.}}.
+		{{- end}}{{end}}
+		{{- range $p := .Params}}{{if .Mock}}{{range .Mock.Methods}}
+			if n := len({{$p.Name}}.{{.CallsName}}()); n != tt.want{{$p.Name | Exported}}{{.Name}}Calls {
+				t.Errorf("{{$p.Mock.MockName}}.{{.Name}} calls = %d, want %d", n, tt.want{{$p.Name | Exported}}{{.Name}}Calls)
+			}
+		{{- end}}{{end}}{{end}}
+		})
+	}
+}
+`
+
+// exampleTemplate is the template for the Example functions of mocks.
+// language=GoTemplate
+var exampleTemplate = `// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+
+package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+
+{{- range $mock := .Mocks}}
+
+func Example{{.MockName}}() {
+	mock := &{{.MockName}}{
+	{{- range .Methods}}
+		{{.FuncName}}: func({{.ArgList}}) {{.ReturnArgList}} {
+			{{- if .Returns}}
+			return
+			{{- end}}
+		},
+	{{- end}}
+	}
+{{- range .Methods}}
+{{if .Params}}
+	{
+		var (
+		{{- range .Params}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+		mock.{{.Name}}({{.ArgCallList}})
+	}
+{{- else}}
+	mock.{{.Name}}()
+{{- end}}
+{{- end}}
+{{- if .Methods}}
+{{range .Methods}}
+	fmt.Println("{{.Name}} calls:", {{if $.Light}}mock.{{.CountName}}(){{else}}len(mock.{{.CallsName}}()){{end}})
+{{- end}}
+	// Output:
+{{- range .Methods}}
+	// {{.Name}} calls: 1
+{{- end}}
+{{- end}}
+}
+{{- end}}
+`
+
+// conformanceTemplate is the template for the conformance tests of
+// interfaces.
+// language=GoTemplate
+var conformanceTemplate = `// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+
+package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+{{- $testing := PkgQualifier .Imports "testing"}}
+
+{{- range .Mocks}}
+
+// {{.InterfaceName}}Conformance holds the checks of the results of the
+// methods called with zero arguments by Test{{.InterfaceName}}Conformance,
+// such as whether nil arguments are rejected with an error. The methods
+// without a check only have to not panic.
+type {{.InterfaceName}}Conformance struct {
+{{- range .Methods}}
+	{{.Name}} func(t *{{$testing}}.T{{range .Returns}}, {{.Name}} {{.TypeString}}{{end}})
+{{- end}}
+}
+
+// Test{{.InterfaceName}}Conformance verifies that the implementations of
+// {{.SrcPkgQualifier}}{{.InterfaceName}} returned by newImpl honour its contract, from the
+// tests of the implementations: each method is called with zero arguments
+// on a new implementation, which must not panic, and its results must
+// pass the check of the method, if any.
+func Test{{.InterfaceName}}Conformance(t *{{$testing}}.T, newImpl func() {{.SrcPkgQualifier}}{{.InterfaceName}}, checks {{.InterfaceName}}Conformance) {
+{{- range .Methods}}
+	t.Run("{{.Name}}", func(t *{{$testing}}.T) {
+		impl, check := newImpl(), checks.{{.Name}}
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("{{.Name}} panicked: %v", r)
+			}
+		}()
+	{{- if .Params}}
+		var (
+		{{- range .Params}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+	{{- end}}
+		{{if .Returns}}{{.ReturnArgNameList}} := {{end}}impl.{{.Name}}({{.ArgCallList}})
+		if check != nil {
+			check(t{{range .Returns}}, {{.Name}}{{end}})
+		}
+	})
+{{- end}}
+}
+{{- end}}
+`
+
+// benchTemplate is the template for the benchmarks of interfaces.
+// language=GoTemplate
+var benchTemplate = `// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+
+package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+{{- $testing := PkgQualifier .Imports "testing"}}
+
+{{- range .Mocks}}
+
+// Bench{{.InterfaceName}} measures each method of {{.SrcPkgQualifier}}{{.InterfaceName}} called with zero
+// arguments on the implementation returned by newImpl, in a sub-benchmark
+// of the method.
+func Bench{{.InterfaceName}}(b *{{$testing}}.B, newImpl func() {{.SrcPkgQualifier}}{{.InterfaceName}}) {
+{{- range .Methods}}
+	b.Run("{{.Name}}", func(b *{{$testing}}.B) {
+		impl := newImpl()
+	{{- if .Params}}
+		var (
+		{{- range .Params}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+	{{- end}}
+		b.ReportAllocs()
+		b.ResetTimer()
+		for i := 0; i < b.N; i++ {
+			impl.{{.Name}}({{.ArgCallList}})
+		}
+	})
+{{- end}}
+}
+
+{{- if eq $.Kind "stub"}}
+{{- $mock := .}}
+
+// Benchmark{{.MockName}} measures the methods of {{.MockName}}, returning
+// zero values, after checking that they don't allocate.
+func Benchmark{{.MockName}}(b *{{$testing}}.B) {
+	var impl {{.SrcPkgQualifier}}{{.InterfaceName}} = &{{.MockName}}{}
+{{- range .Methods}}
+	{
+	{{- if .Params}}
+		var (
+		{{- range .Params}}
+			{{.Name}} {{.TypeString}}
+		{{- end}}
+		)
+	{{- end}}
+		if n := {{$testing}}.AllocsPerRun(100, func() { impl.{{.Name}}({{.ArgCallList}}) }); n != 0 {
+			b.Fatalf("{{$mock.MockName}}.{{.Name}} allocates %v times per call", n)
+		}
+	}
+{{- end}}
+	Bench{{.InterfaceName}}(b, func() {{.SrcPkgQualifier}}{{.InterfaceName}} {
+		return &{{.MockName}}{}
+	})
+}
+{{- else}}
+
+// Benchmark{{.MockName}} measures the methods of {{.MockName}}, returning
+// zero values. It includes the recording of the calls by the mock.
+func Benchmark{{.MockName}}(b *{{$testing}}.B) {
+	Bench{{.InterfaceName}}(b, func() {{.SrcPkgQualifier}}{{.InterfaceName}} {
+		return &{{.MockName}}{
+		{{- range .Methods}}
+			{{.FuncName}}: func({{.ArgList}}) {{.ReturnArgList}} {
+				{{- if .Returns}}
+				return
+				{{- end}}
+			},
+		{{- end}}
+		}
+	})
+}
+{{- end}}
+{{- if $.Impl}}
+
+// Benchmark{{$.ImplName}} measures the methods of the implementation of
+// {{.SrcPkgQualifier}}{{.InterfaceName}} returned by {{$.Impl}}.
+func Benchmark{{$.ImplName}}(b *{{$testing}}.B) {
+	Bench{{.InterfaceName}}(b, func() {{.SrcPkgQualifier}}{{.InterfaceName}} {
+		return {{$.Impl}}()
+	})
+}
+{{- end}}
+{{- end}}
+`
+
+// ifaceTemplate is the template for interfaces extracted from the method
+// sets of concrete types.
+// language=GoTemplate
+var ifaceTemplate = `// Code generated by mirip; DO NOT EDIT.
+// github.com/gmhafiz/mirip
+
+package {{.PkgName}}
+
+import (
+{{- range .Imports}}
+	{{. | ImportStatement}}
+{{- end}}
+)
+
+{{- range .Interfaces}}
+
+// {{.InterfaceName}} is the interface of the exported methods of {{$.SrcPkgQualifier}}{{.TypeName}}.
+type {{.InterfaceName}} interface {
+{{- range .Methods}}
+	{{.Name}}({{.ArgList}}) {{.ReturnArgTypeList}}
+{{- end}}
+}
+{{- end}}
+`
+
+// docTemplate is the template for the doc.go file of mocks packages. It
+// isn't marked as generated, as it is only written once, to be edited.
+// language=GoTemplate
+var docTemplate = `{{range .Summary}}// {{.}}
+{{end -}}
+//
+// Regenerate them with:
+//
+//	{{.Command}}
+package {{.PkgName}}
 `
@@ -11,6 +11,44 @@ type MethodData struct {
 	Name    string
 	Params  []ParamData
 	Returns []ParamData
+
+	// FuncName and CallsName are the names of the Func field and the
+	// Calls accessor of the method in mocks, and SnapshotName the name of
+	// the accessor copying the calls. Light mocks have CountName, the name
+	// of the accessor of the number of calls, instead of both accessors.
+	FuncName     string
+	CallsName    string
+	SnapshotName string
+	CountName    string
+
+	// SeqName and ChanName are the names of the helpers stubbing the
+	// method with the values of the iterator or the channel it returns,
+	// if any.
+	SeqName  string
+	ChanName string
+
+	// AssertName is the name of the assertion of the arguments of the
+	// calls of the method, if any.
+	AssertName string
+
+	// CallTypeName is the name of the exported type of the recorded calls
+	// of the method, declared with the type parameters of the mock, and
+	// CallType that type along with their type arguments, if any. The
+	// calls are of an anonymous struct type otherwise.
+	CallTypeName string
+	CallType     string
+
+	// ContextDeadlineName and ContextValueName are the names of the
+	// assertions of the contexts of the calls of the method, and
+	// ContextValuesName the name of the accessor of the values of the
+	// context keys, if any.
+	ContextDeadlineName string
+	ContextValueName    string
+	ContextValuesName   string
+
+	// DoneContext is the name of the context parameter of the method
+	// whose error it returns once the context is done, if any.
+	DoneContext string
 }
 
 // ArgList is the string representation of method parameters, ex:
@@ -23,6 +61,19 @@ func (m MethodData) ArgList() string {
 	return strings.Join(params, ", ")
 }
 
+// ArgTypeList is the string representation of the method parameter
+// types, ex: 'string, int, ...bar.Baz'.
+func (m MethodData) ArgTypeList() string {
+	params := make([]string, len(m.Params))
+	for i, p := range m.Params {
+		params[i] = p.TypeString()
+		if p.Variadic {
+			params[i] = "..." + params[i][2:]
+		}
+	}
+	return strings.Join(params, ", ")
+}
+
 // ArgCallList is the string representation of method call parameters,
 // ex: 's, n, foo'. In case of a last variadic parameter, it will be of
 // the format 's, n, foos...'
@@ -47,6 +98,19 @@ func (m MethodData) ReturnArgTypeList() string {
 	return strings.Join(params, ", ")
 }
 
+// ReturnArgList is the string representation of method return values
+// with their names, ex: '(foo bar.Baz)', '(s string, err error)'.
+func (m MethodData) ReturnArgList() string {
+	if len(m.Returns) == 0 {
+		return ""
+	}
+	params := make([]string, len(m.Returns))
+	for i, p := range m.Returns {
+		params[i] = p.MethodArg()
+	}
+	return fmt.Sprintf("(%s)", strings.Join(params, ", "))
+}
+
 // ReturnArgNameList is the string representation of values being
 // returned from the method, ex: 'foo', 's, err'.
 func (m MethodData) ReturnArgNameList() string {
@@ -57,11 +121,109 @@ func (m MethodData) ReturnArgNameList() string {
 	return strings.Join(params, ", ")
 }
 
+// ReturnsError returns whether the last return value of the method is
+// an error.
+func (m MethodData) ReturnsError() bool {
+	return len(m.Returns) > 0 && m.Returns[len(m.Returns)-1].TypeString() == "error"
+}
+
+// SeqReturn returns the types of the values yielded by the iter.Seq or
+// iter.Seq2 returned by the method, if it returns one, alone or along with
+// an error.
+func (m MethodData) SeqReturn() []string {
+	switch {
+	case len(m.Returns) == 1, len(m.Returns) == 2 && m.ReturnsError():
+		return m.Returns[0].Var.SeqTypes()
+	}
+	return nil
+}
+
+// ChanReturn returns the element type of the channel returned by the
+// method, if it returns one which values can be received from, alone or
+// along with an error. It is empty otherwise.
+func (m MethodData) ChanReturn() string {
+	switch {
+	case len(m.Returns) == 1, len(m.Returns) == 2 && m.ReturnsError():
+		elem, _ := m.Returns[0].Var.ChanElem()
+		return elem
+	}
+	return ""
+}
+
+// Fluent returns whether the method returns the mock itself when it has
+// no Func.
+func (m MethodData) Fluent() bool {
+	for _, r := range m.Returns {
+		if r.Self {
+			return true
+		}
+	}
+	return false
+}
+
+// ReturnsTime returns whether the method has a time.Time result.
+func (m MethodData) ReturnsTime() bool {
+	for _, r := range m.Returns {
+		if r.Var.IsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// ReplaysResults returns whether the replayer of kind recorder replays a
+// result of the method, an error or an encodable value.
+func (m MethodData) ReplaysResults() bool {
+	for _, r := range m.Returns {
+		if r.TypeString() == "error" || r.Var.Encodable() {
+			return true
+		}
+	}
+	return false
+}
+
+// EncodableParams are the parameters of the method which can be encoded,
+// but contexts.
+func (m MethodData) EncodableParams() []ParamData {
+	var params []ParamData
+	for _, p := range m.Params {
+		if !p.Var.IsContext() && p.Var.Encodable() {
+			params = append(params, p)
+		}
+	}
+	return params
+}
+
+// LeadingReturns is the list of return values of the method, without the
+// last one.
+func (m MethodData) LeadingReturns() []ParamData {
+	if len(m.Returns) == 0 {
+		return nil
+	}
+	return m.Returns[:len(m.Returns)-1]
+}
+
 // ParamData is the data which represents a parameter to some method of
 // an interface.
 type ParamData struct {
 	Var      *registry.Var
 	Variadic bool
+
+	// Helper is the name of the type declared for the anonymous struct
+	// or interface type of the parameter, if any.
+	Helper string
+
+	// Self is set for the results of the type of the mocked interface
+	// returning the mock itself, for fluent interfaces.
+	Self bool
+
+	// SeqName is the name of the helper collecting the values yielded by
+	// the iter.Seq or iter.Seq2 parameter in a call, if it is one.
+	SeqName string
+
+	// FixtureName is the name of the field of the result in the fixtures
+	// of mocks, for the fixture stub.
+	FixtureName string
 }
 
 // Name returns the name of the parameter.
@@ -88,6 +250,16 @@ func (p ParamData) CallName() string {
 	return p.Name()
 }
 
+// CallTypeString returns the string representation of the type of the
+// parameter in the calls recorded by mocks, which is its helper type if
+// any.
+func (p ParamData) CallTypeString() string {
+	if p.Helper != "" {
+		return p.Helper
+	}
+	return p.TypeString()
+}
+
 // TypeString returns the string representation of the type of the
 // parameter.
 func (p ParamData) TypeString() string {
@@ -96,19 +268,200 @@ func (p ParamData) TypeString() string {
 
 // MockData is the data used to generate a mock for some interface.
 type MockData struct {
-	InterfaceName string
-	MockName      string
-	Methods       []MethodData
+	InterfaceName   string
+	SrcPkgQualifier string
+	MockName        string
+	Methods         []MethodData
+
+	// TypeParams are the type parameters of the mock of a generic
+	// interface, ex: '[K comparable, V any]', and TypeArgs their use as
+	// type arguments, ex: '[K, V]'.
+	TypeParams string
+	TypeArgs   string
+
+	// InterfaceAliases are the aliases of the interface declared in its
+	// package, which the mock is asserted to implement too, and EnsureNil
+	// asserts that a nil pointer to the mock implements them, rather than
+	// the address of a new mock.
+	InterfaceAliases []string
+	EnsureNil        bool
+
+	// TypeSet is set for the interfaces with a type set, such as those
+	// embedding comparable, which mocks only satisfy as type arguments and
+	// aren't asserted to implement.
+	TypeSet bool
+
+	// Delegated are the methods of the interface which the mock of an
+	// interface set doesn't mock, delegated to the interface embedded in
+	// the mock, if any.
+	Delegated []string
+
+	// Builtin is the interface of the standard library faked by kind
+	// fake-builtin, in the format 'path.Name'.
+	Builtin string
+
+	// Helpers are the names of the fields and methods generated regardless
+	// of the methods of the interface, keyed by their usual names, which
+	// are numbered when the interface has methods of the same names.
+	Helpers map[string]string
+}
+
+// ReturnsTime returns whether a method of the mock has a time.Time
+// result.
+func (m MockData) ReturnsTime() bool {
+	for _, method := range m.Methods {
+		if method.ReturnsTime() {
+			return true
+		}
+	}
+	return false
+}
+
+// AnyResults are the typed results of the setter of the results of a
+// method of type any or []any, such as 'vOut T, errOut error' for a
+// method returning (any, error), for tests not to convert them.
+type AnyResults struct {
+	// TypeParams are the type parameters of the setter, along with those
+	// of the mock, ex: '[T any]', and ArgList its parameters.
+	TypeParams string
+	ArgList    string
+
+	// Slices are the results of type []any, converted from []T into the
+	// variables of the same names suffixed with Any, and CallList the
+	// results given to the Return method of the builder.
+	Slices   []ParamData
+	CallList string
+}
+
+// AnyResults returns the typed results of the setter of the results of
+// type any or []any of the given method, nil if it has none.
+func (m MockData) AnyResults(method MethodData) *AnyResults {
+	var anys []int
+	for i, r := range method.Returns {
+		if r.Var.IsAny() || r.Var.IsAnySlice() {
+			anys = append(anys, i)
+		}
+	}
+	if len(anys) == 0 {
+		return nil
+	}
+
+	// The type parameters are named T, or T1, T2 and so on, unlike those
+	// of the mock.
+	taken := make(map[string]bool)
+	for _, arg := range strings.Split(strings.Trim(m.TypeArgs, "[]"), ", ") {
+		taken[arg] = true
+	}
+	base := "T"
+	for taken[base] || taken[base+"1"] {
+		base += "T"
+	}
+
+	var params []string
+	args := make([]string, len(method.Returns))
+	calls := make([]string, len(method.Returns))
+	res := &AnyResults{}
+	for i, r := range method.Returns {
+		args[i], calls[i] = r.MethodArg(), r.Name()
+	}
+	for n, i := range anys {
+		r := method.Returns[i]
+		typeParam := base
+		if len(anys) > 1 {
+			typeParam = fmt.Sprintf("%s%d", base, n+1)
+		}
+		params = append(params, typeParam+" any")
+		if r.Var.IsAnySlice() {
+			args[i] = r.Name() + " []" + typeParam
+			calls[i] = r.Name() + "Any"
+			res.Slices = append(res.Slices, r)
+			continue
+		}
+		args[i] = r.Name() + " " + typeParam
+	}
+
+	res.TypeParams = "[" + strings.Join(params, ", ") + "]"
+	if m.TypeParams != "" {
+		res.TypeParams = strings.TrimSuffix(m.TypeParams, "]") + ", " + strings.Join(params, ", ") + "]"
+	}
+	res.ArgList = strings.Join(args, ", ")
+	res.CallList = strings.Join(calls, ", ")
+	return res
+}
+
+// EnsureOf returns the value of the generated type of the given name
+// asserted to implement the interface, ex: '&StoreMock{}',
+// '(*StoreMock)(nil)'.
+func (m MockData) EnsureOf(name string) string {
+	if m.EnsureNil {
+		return "(*" + name + m.TypeArgs + ")(nil)"
+	}
+	return "&" + name + m.TypeArgs + "{}"
 }
 
 // Data is the template data used to render the Mirip template.
 type Data struct {
-	PkgName         string
-	SrcPkgQualifier string
-	Imports         []*registry.Package
-	Mocks           []MockData
-	StubImpl        bool
-	SkipEnsure      bool
+	Kind       string
+	PkgName    string
+	Imports    []*registry.Package
+	Mocks      []MockData
+	Provider   string
+	Registry   bool
+	Shared     bool
+	VerifyAll  bool
+	Flavor     string
+	WithResets bool
+	Coverage   bool
+	Light      bool
+	Assert     string
+	Stub       string
+	SkipEnsure bool
+
+	// Clock adds the Clock field giving the time.Time results of the
+	// methods without a Func to the mocks of methods returning them, and
+	// NotImplemented makes these methods return the error of the runtime
+	// for their error results.
+	Clock          bool
+	NotImplemented bool
+
+	// AssertContext adds the assertions of the contexts of calls, and
+	// ContextKeys are the qualified keys of the context values returned
+	// by the accessors of the values of calls.
+	AssertContext bool
+	ContextKeys   []string
+
+	// RecordSwitch adds the switch of the recording of calls to mocks,
+	// Builder the builders of mocks, and Golden the helpers saving their
+	// calls to golden files and comparing them.
+	RecordSwitch bool
+	Builder      bool
+	Golden       bool
+
+	// Report makes the mocks record their calls for the report of the
+	// runtime.
+	Report bool
+
+	// Receiver is the name of the receivers of the methods of mocks, and
+	// CallsField and LockField the names of the fields recording calls.
+	// LockPerMethod makes LockField a struct of a lock per method.
+	Receiver      string
+	CallsField    string
+	LockField     string
+	LockPerMethod bool
+
+	// Impl is the qualified function returning the implementation which
+	// the benchmarks measure along with the mocks, and ImplName the name
+	// of its benchmark.
+	Impl     string
+	ImplName string
+
+	// Stamp are the lines of the provenance of the generated code in the
+	// header, without the comment marker.
+	Stamp []string
+
+	// Constraint is the expression of the go:build line of the header,
+	// if any.
+	Constraint string
 }
 
 // MocksSomeMethod returns true of any one of the Mocks has at least 1
@@ -122,3 +475,46 @@ func (d Data) MocksSomeMethod() bool {
 
 	return false
 }
+
+// DocData is the template data used to render the doc.go file of a
+// mocks package. Summary holds the lines of its first paragraph.
+type DocData struct {
+	PkgName string
+	Summary []string
+	Command string
+}
+
+// TestData is the template data used to render a test skeleton for a
+// function.
+type TestData struct {
+	PkgName      string
+	Imports      []*registry.Package
+	FuncName     string
+	Params       []TestParamData
+	Results      []string
+	ReturnsError bool
+}
+
+// TestParamData is the data which represents a parameter of the function
+// being tested. Mock is set when the parameter is a mocked interface.
+type TestParamData struct {
+	ParamData
+	Mock *MockData
+}
+
+// InterfaceData is the template data used to render interfaces extracted
+// from concrete types.
+type InterfaceData struct {
+	PkgName         string
+	SrcPkgQualifier string
+	Imports         []*registry.Package
+	Interfaces      []ExtractData
+}
+
+// ExtractData is the data used to generate an interface from the method
+// set of a concrete type.
+type ExtractData struct {
+	TypeName      string
+	InterfaceName string
+	Methods       []MethodData
+}
@@ -0,0 +1,133 @@
+// Package mirip generates mocks of Go interfaces in memory, as the mirip
+// command does, for tools such as test harnesses and code review bots.
+package mirip
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"runtime"
+
+	"github.com/gmhafiz/mirip/internal/mirip"
+)
+
+// Config specifies details about how interfaces should be mocked, as
+// the flags of the mirip command do. SrcDir is the only field which needs
+// be specified.
+type Config = mirip.Config
+
+// Warning is a problem which was worked around while generating the mocks
+// of an interface, given to Config.Warn.
+type Warning = mirip.Warning
+
+// Timings accumulates the time spent in each phase of the generation,
+// given as Config.Timings.
+type Timings = mirip.Timings
+
+// Loader loads the source packages in place of go/packages, given as
+// Config.Loader, for front-ends such as the export data of a build system
+// or the snapshots of a language server to feed the generator without it
+// reading the files of the packages.
+type Loader = mirip.Loader
+
+// LoaderFunc is a function used as a Loader.
+type LoaderFunc = mirip.LoaderFunc
+
+// Settings of Config, documented along with its fields.
+const (
+	KindMock  = mirip.KindMock
+	KindChaos = mirip.KindChaos
+
+	ProviderFunc = mirip.ProviderFunc
+	ProviderWire = mirip.ProviderWire
+	ProviderFx   = mirip.ProviderFx
+
+	FlavorGinkgo  = mirip.FlavorGinkgo
+	FlavorTestify = mirip.FlavorTestify
+
+	StubZero   = mirip.StubZero
+	StubRandom = mirip.StubRandom
+
+	QualifierPath   = mirip.QualifierPath
+	QualifierNumber = mirip.QualifierNumber
+
+	ConflictNumber     = mirip.ConflictNumber
+	ConflictUnderscore = mirip.ConflictUnderscore
+	ConflictLetter     = mirip.ConflictLetter
+
+	ParamNamesType     = mirip.ParamNamesType
+	ParamNamesPosition = mirip.ParamNamesPosition
+
+	FileCaseSnake = mirip.FileCaseSnake
+	FileCaseKebab = mirip.FileCaseKebab
+	FileCaseLower = mirip.FileCaseLower
+
+	InterfacePlaceholder  = mirip.InterfacePlaceholder
+	PackagePlaceholder    = mirip.PackagePlaceholder
+	KindPlaceholder       = mirip.KindPlaceholder
+	DefaultConflictSuffix = mirip.DefaultConflictSuffix
+)
+
+// Generate renders the mocks of the given interfaces of the source
+// package, in the format 'interface' or 'interface:alias', without
+// writing any file. It returns the content of the files keyed by the path
+// they would be written to, following OutFile or OutDir, which defaults to
+// one '<interface>_mock.go' file per interface in SrcDir.
+func Generate(ctx context.Context, cfg Config, targets ...string) (map[string][]byte, error) {
+	outFiles, contents, err := render(ctx, cfg, targets)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make(map[string][]byte, len(outFiles))
+	for i, outFile := range outFiles {
+		files[outFile] = contents[i]
+	}
+	return files, nil
+}
+
+// render renders the mocks of the targets, returning the paths of the
+// output files in the order of the targets along with their content.
+func render(ctx context.Context, cfg Config, targets []string) ([]string, [][]byte, error) {
+	if len(targets) == 0 {
+		return nil, nil, errors.New("must specify one interface")
+	}
+	if cfg.OutFile == "" && cfg.OutDir == "" {
+		cfg.OutDir = cfg.SrcDir
+	}
+
+	mockers, err := mirip.NewAllContext(ctx, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	m := mockers[0]
+
+	// Interfaces whose names are the same in the file case share a file.
+	var outFiles []string
+	byOutFile := make(map[string][]string)
+	for _, np := range targets {
+		outFile := m.OutFile(np)
+		if _, ok := byOutFile[outFile]; !ok {
+			outFiles = append(outFiles, outFile)
+		}
+		byOutFile[outFile] = append(byOutFile[outFile], np)
+	}
+
+	bufs := make([]bytes.Buffer, len(outFiles))
+	jobs := make([]mirip.Job, len(outFiles))
+	for i, outFile := range outFiles {
+		jobs[i] = mirip.Job{Out: &bufs[i], NamePairs: byOutFile[outFile]}
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	if err := m.MockAll(runtime.NumCPU(), jobs...); err != nil {
+		return nil, nil, err
+	}
+
+	contents := make([][]byte, len(outFiles))
+	for i := range bufs {
+		contents[i] = bufs[i].Bytes()
+	}
+	return outFiles, contents, nil
+}
@@ -0,0 +1,302 @@
+// Package miriptest runs the golden corpus of mirip, the interfaces of its
+// generate package along with the mocks checked in next to them, for the
+// authors of templates and of the tools built on mirip to check their
+// customizations against the cases mirip itself is checked with.
+package miriptest
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+
+	"golang.org/x/tools/go/packages"
+
+	"github.com/gmhafiz/mirip"
+	"github.com/gmhafiz/mirip/internal/diff"
+)
+
+// CorpusPkg is the import path of the package of the corpus.
+const CorpusPkg = "github.com/gmhafiz/mirip/generate"
+
+// Case is a go:generate directive of a corpus, generating the mocks of
+// its interfaces into the file of the given name.
+type Case struct {
+	// Name is the output file of the directive relative to the directory
+	// of the corpus, such as decoder_mock.go, naming the case.
+	Name string
+
+	// File is the absolute path of the file declaring the directive.
+	File string
+
+	// Args are the arguments of mirip in the directive.
+	Args []string
+
+	// Config is the config given by the arguments, without a Stamp, and
+	// Targets the interfaces they mock.
+	Config  mirip.Config
+	Targets []string
+}
+
+// Corpus returns the cases of the corpus of mirip, whose package is found
+// by the go command from the current directory, in the order of their
+// files. The module of the current directory must require mirip.
+func Corpus(ctx context.Context) ([]Case, error) {
+	pkgs, err := packages.Load(&packages.Config{Mode: packages.NeedName | packages.NeedFiles, Context: ctx}, CorpusPkg)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) != 1 || len(pkgs[0].Errors) != 0 || len(pkgs[0].GoFiles) == 0 {
+		return nil, fmt.Errorf("couldn't find the corpus %s from the current directory", CorpusPkg)
+	}
+	return CorpusDir(filepath.Dir(pkgs[0].GoFiles[0]))
+}
+
+// CorpusDir returns the cases of the go:generate directives running mirip
+// in the Go files of dir, in the order of the files, such as a corpus of
+// one's own laid out as the one of mirip. The test files are skipped. The
+// directives must write their mocks with -out, and take their flags
+// before the source dir and the interfaces.
+func CorpusDir(dir string) ([]Case, error) {
+	absDir, err := filepath.Abs(dir)
+	if err != nil {
+		return nil, err
+	}
+	entries, err := os.ReadDir(absDir)
+	if err != nil {
+		return nil, err
+	}
+
+	var cases []Case
+	fset := token.NewFileSet()
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".go") || strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		filename := filepath.Join(absDir, name)
+		file, err := parser.ParseFile(fset, filename, nil, parser.ParseComments|parser.SkipObjectResolution)
+		if err != nil {
+			return nil, err
+		}
+		for _, group := range file.Comments {
+			for _, comment := range group.List {
+				args, ok := directive(comment.Text)
+				if !ok {
+					continue
+				}
+				c, err := newCase(filename, args)
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", fset.Position(comment.Pos()), err)
+				}
+				cases = append(cases, c)
+			}
+		}
+	}
+	return cases, nil
+}
+
+// directive returns the arguments of the go:generate directive of the
+// comment, if it runs mirip, either installed or with go run.
+func directive(text string) ([]string, bool) {
+	if !strings.HasPrefix(text, "//go:generate ") {
+		return nil, false
+	}
+	fields := strings.Fields(strings.TrimPrefix(text, "//go:generate "))
+	if len(fields) > 2 && fields[0] == "go" && fields[1] == "run" {
+		fields = fields[2:]
+	}
+	if len(fields) == 0 {
+		return nil, false
+	}
+	program, _, _ := strings.Cut(fields[0], "@")
+	if strings.TrimSuffix(filepath.Base(filepath.FromSlash(program)), ".exe") != "mirip" {
+		return nil, false
+	}
+	return fields[1:], true
+}
+
+// newCase returns the case of the arguments of a directive of the file,
+// relative to its directory as go generate runs them. Only the flags
+// settings the code of the mocks are supported, those of the command
+// alone, such as -rm, being ignored.
+func newCase(filename string, args []string) (Case, error) {
+	dir := filepath.Dir(filename)
+	c := Case{File: filename, Args: args}
+	cfg := &c.Config
+
+	fs := flag.NewFlagSet("mirip", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.StringVar(&c.Name, "out", "", "")
+	fs.StringVar(&cfg.PkgName, "pkg", "", "")
+	fs.StringVar(&cfg.FileCase, "file-case", mirip.FileCaseSnake, "")
+	fs.StringVar(&cfg.Prefix, "prefix", "", "")
+	fs.StringVar(&cfg.Suffix, "suffix", "", "")
+	fs.StringVar(&cfg.Kind, "kind", mirip.KindMock, "")
+	fs.StringVar(&cfg.Provider, "provide", "", "")
+	fs.StringVar(&cfg.Flavor, "flavor", "", "")
+	fs.StringVar(&cfg.Stub, "stub", "", "")
+	fs.StringVar(&cfg.Assert, "assert", "", "")
+	fs.StringVar(&cfg.Qualifiers, "qualifiers", mirip.QualifierPath, "")
+	fs.StringVar(&cfg.SrcAlias, "src-alias", "", "")
+	fs.StringVar(&cfg.RuntimeAlias, "runtime-alias", "", "")
+	fs.StringVar(&cfg.ConflictSuffix, "conflict-suffix", mirip.DefaultConflictSuffix, "")
+	fs.StringVar(&cfg.Conflicts, "conflicts", mirip.ConflictNumber, "")
+	fs.StringVar(&cfg.ParamNames, "param-names", mirip.ParamNamesType, "")
+	fs.StringVar(&cfg.Receiver, "receiver", "m", "")
+	fs.StringVar(&cfg.CallsField, "calls-field", "calls", "")
+	fs.StringVar(&cfg.LockField, "lock-field", "lock", "")
+	fs.BoolVar(&cfg.Fluent, "fluent", false, "")
+	fs.BoolVar(&cfg.Clock, "clock", false, "")
+	fs.BoolVar(&cfg.NotImplemented, "not-implemented", false, "")
+	fs.BoolVar(&cfg.Report, "report", false, "")
+	fs.BoolVar(&cfg.LockPerMethod, "lock-per-method", false, "")
+	fs.BoolVar(&cfg.Light, "light", false, "")
+	fs.BoolVar(&cfg.RecordSwitch, "toggle-recording", false, "")
+	fs.BoolVar(&cfg.Builder, "builder", false, "")
+	fs.BoolVar(&cfg.VerifyAll, "verify-all", false, "")
+	fs.BoolVar(&cfg.Golden, "golden", false, "")
+	fs.BoolVar(&cfg.CallTypes, "call-types", false, "")
+	fs.BoolVar(&cfg.Registry, "registry", false, "")
+	fs.BoolVar(&cfg.Shared, "shared", false, "")
+	fs.BoolVar(&cfg.AssertContext, "assert-context", false, "")
+	fs.Func("context-key", "", func(key string) error {
+		cfg.ContextKeys = append(cfg.ContextKeys, key)
+		return nil
+	})
+	fs.BoolVar(&cfg.HonorContext, "honor-context", false, "")
+	fs.BoolVar(&cfg.SkipEnsure, "skip-ensure", false, "")
+	fs.BoolVar(&cfg.EnsureAliases, "ensure-aliases", false, "")
+	fs.BoolVar(&cfg.AnonymousTypes, "name-anonymous", false, "")
+	fs.BoolVar(&cfg.Strict, "strict", false, "")
+	for _, name := range []string{"rm", "no-stamp", "quiet", "v"} {
+		fs.Bool(name, false, "")
+	}
+	fs.String("builder-spec", "", "")
+	if err := fs.Parse(args); err != nil {
+		return Case{}, err
+	}
+
+	if c.Name == "" {
+		return Case{}, errors.New("the directive writes no file with -out")
+	}
+	if fs.NArg() < 2 {
+		return Case{}, errors.New("the directive names no source dir and interface")
+	}
+	cfg.SrcDir = filepath.Join(dir, fs.Arg(0))
+	cfg.OutFile = filepath.Join(dir, c.Name)
+	c.Name = filepath.ToSlash(filepath.Clean(c.Name))
+	c.Targets = fs.Args()[1:]
+	return c, nil
+}
+
+// Generate renders the mocks of the case with the given config, usually
+// its Config edited, such as with the Template of one's own, returning
+// the content of the files keyed by their name relative to the directory
+// of the corpus.
+func (c Case) Generate(ctx context.Context, cfg mirip.Config) (map[string][]byte, error) {
+	files, err := mirip.Generate(ctx, cfg, c.Targets...)
+	if err != nil {
+		return nil, err
+	}
+
+	named := make(map[string][]byte, len(files))
+	for path, content := range files {
+		name, err := filepath.Rel(filepath.Dir(c.File), path)
+		if err != nil {
+			return nil, err
+		}
+		named[filepath.ToSlash(name)] = content
+	}
+	return named, nil
+}
+
+// Options configures Run.
+type Options struct {
+	// Config edits the config of each case, such as to set its Template.
+	Config func(c Case, cfg *mirip.Config)
+
+	// GoldenDir is the directory of the expected files of the cases,
+	// named as their output files, by default the directory of the
+	// corpus, whose mocks are those generated by the built-in templates.
+	GoldenDir string
+
+	// Update writes the generated files to GoldenDir instead of comparing
+	// them, for the expected files to be reviewed and checked in.
+	Update bool
+}
+
+// Run generates the mocks of each case in a subtest of t named after it,
+// failing with the diff of every generated file differing from its
+// expected file. The stamps of the expected files are ignored, for them
+// to be compared whatever the version of mirip which generated them, and
+// the files written by Update have none.
+func Run(t *testing.T, cases []Case, opts Options) {
+	t.Helper()
+	for _, c := range cases {
+		c := c
+		t.Run(c.Name, func(t *testing.T) {
+			cfg := c.Config
+			if opts.Config != nil {
+				opts.Config(c, &cfg)
+			}
+			files, err := c.Generate(context.Background(), cfg)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			names := make([]string, 0, len(files))
+			for name := range files {
+				names = append(names, name)
+			}
+			sort.Strings(names)
+			for _, name := range names {
+				golden := filepath.Join(filepath.Dir(c.File), filepath.FromSlash(name))
+				if opts.GoldenDir != "" {
+					golden = filepath.Join(opts.GoldenDir, filepath.FromSlash(name))
+				}
+				if opts.Update {
+					if err := (mirip.FileSink{}).Write(golden, files[name]); err != nil {
+						t.Fatal(err)
+					}
+					continue
+				}
+
+				want, err := os.ReadFile(golden)
+				if err != nil {
+					t.Error(err)
+					continue
+				}
+				if want = unstamped(want); !bytes.Equal(want, files[name]) {
+					t.Errorf("%s differs from the one generated:\n%s", golden, diff.Unified(golden, name, want, files[name], false))
+				}
+			}
+		})
+	}
+}
+
+// unstamped returns the content of a file generated by mirip without the
+// stamp of its header, as it is generated without a Stamp.
+func unstamped(content []byte) []byte {
+	var out []byte
+	header := true
+	for _, line := range bytes.SplitAfter(content, []byte("\n")) {
+		if header && bytes.HasPrefix(line, []byte("package ")) {
+			header = false
+		}
+		if header && (bytes.Equal(bytes.TrimSpace(line), []byte("//")) || bytes.HasPrefix(line, []byte("// mirip:"))) {
+			continue
+		}
+		out = append(out, line...)
+	}
+	return out
+}
@@ -0,0 +1,21 @@
+package miriptest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/gmhafiz/mirip/miriptest"
+)
+
+// TestCorpus checks that the mocks of the corpus are those generated by
+// the built-in templates, through the fixture given to the other authors.
+func TestCorpus(t *testing.T) {
+	cases, err := miriptest.Corpus(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cases) == 0 {
+		t.Fatalf("no case in the corpus %s", miriptest.CorpusPkg)
+	}
+	miriptest.Run(t, cases, miriptest.Options{})
+}
@@ -0,0 +1,259 @@
+package mirip
+
+import (
+	"encoding/base64"
+	"errors"
+	"math"
+	"strconv"
+	"unicode/utf8"
+)
+
+// The runtime formats and encodes the arguments of calls without
+// reflection, which fmt and encoding/json rely on: the values of the
+// basic types, the slices and maps of the common ones, and those
+// implementing the interfaces below are supported.
+type (
+	stringer interface {
+		String() string
+	}
+	jsonMarshaler interface {
+		MarshalJSON() ([]byte, error)
+	}
+	textMarshaler interface {
+		MarshalText() ([]byte, error)
+	}
+)
+
+// errUnsupported is the error of the values the runtime can't encode.
+var errUnsupported = errors.New("the value can't be encoded without reflection, unless it implements json.Marshaler or encoding.TextMarshaler")
+
+// EncodeError is returned by WriteReportJSON for an argument of a call
+// which can't be encoded.
+type EncodeError struct {
+	Mock   string
+	Method string
+	Arg    string
+	Err    error
+}
+
+func (e *EncodeError) Error() string {
+	return "mirip: argument " + e.Arg + " of " + e.Mock + "." + e.Method + ": " + e.Err.Error()
+}
+
+func (e *EncodeError) Unwrap() error {
+	return e.Err
+}
+
+// appendValue appends the value of an argument in a table of calls to b:
+// quoted for strings, in the format of fmt for the other supported
+// values, and as <?> for the others, such as structs without a String
+// method or the values of named basic types.
+func appendValue(b []byte, value interface{}) []byte {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "<nil>"...)
+	case string:
+		return strconv.AppendQuote(b, v)
+	case []byte:
+		b = append(b, '[')
+		for i, c := range v {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = strconv.AppendUint(b, uint64(c), 10)
+		}
+		return append(b, ']')
+	case error:
+		return append(b, v.Error()...)
+	case stringer:
+		return append(b, v.String()...)
+	case []string:
+		b = append(b, '[')
+		for i, s := range v {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = append(b, s...)
+		}
+		return append(b, ']')
+	case []interface{}:
+		b = append(b, '[')
+		for i, e := range v {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = appendValue(b, e)
+		}
+		return append(b, ']')
+	case []int:
+		b = append(b, '[')
+		for i, n := range v {
+			if i > 0 {
+				b = append(b, ' ')
+			}
+			b = strconv.AppendInt(b, int64(n), 10)
+		}
+		return append(b, ']')
+	}
+	if s, ok := appendBasic(b, value); ok {
+		return s
+	}
+	return append(b, "<?>"...)
+}
+
+// appendBasic appends the value of a basic type, but strings, to b in the
+// format of fmt, and reports whether it is one.
+func appendBasic(b []byte, value interface{}) ([]byte, bool) {
+	switch v := value.(type) {
+	case bool:
+		return strconv.AppendBool(b, v), true
+	case int:
+		return strconv.AppendInt(b, int64(v), 10), true
+	case int8:
+		return strconv.AppendInt(b, int64(v), 10), true
+	case int16:
+		return strconv.AppendInt(b, int64(v), 10), true
+	case int32:
+		return strconv.AppendInt(b, int64(v), 10), true
+	case int64:
+		return strconv.AppendInt(b, v, 10), true
+	case uint:
+		return strconv.AppendUint(b, uint64(v), 10), true
+	case uint8:
+		return strconv.AppendUint(b, uint64(v), 10), true
+	case uint16:
+		return strconv.AppendUint(b, uint64(v), 10), true
+	case uint32:
+		return strconv.AppendUint(b, uint64(v), 10), true
+	case uint64:
+		return strconv.AppendUint(b, v, 10), true
+	case uintptr:
+		return strconv.AppendUint(b, uint64(v), 10), true
+	case float32:
+		return strconv.AppendFloat(b, float64(v), 'g', -1, 32), true
+	case float64:
+		return strconv.AppendFloat(b, v, 'g', -1, 64), true
+	}
+	return b, false
+}
+
+// appendJSON appends the JSON encoding of the value of an argument to b,
+// like encoding/json for the supported values.
+func appendJSON(b []byte, value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case nil:
+		return append(b, "null"...), nil
+	case string:
+		return appendJSONString(b, v), nil
+	case []byte:
+		b = append(b, '"')
+		b = append(b, base64.StdEncoding.EncodeToString(v)...)
+		return append(b, '"'), nil
+	case float32:
+		return appendJSONFloat(b, float64(v), 32)
+	case float64:
+		return appendJSONFloat(b, v, 64)
+	case jsonMarshaler:
+		raw, err := v.MarshalJSON()
+		if err != nil {
+			return b, err
+		}
+		return append(b, raw...), nil
+	case textMarshaler:
+		text, err := v.MarshalText()
+		if err != nil {
+			return b, err
+		}
+		return appendJSONString(b, string(text)), nil
+	case error:
+		return appendJSONString(b, v.Error()), nil
+	case []string:
+		if v == nil {
+			return append(b, "null"...), nil
+		}
+		b = append(b, '[')
+		for i, s := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = appendJSONString(b, s)
+		}
+		return append(b, ']'), nil
+	case []int:
+		if v == nil {
+			return append(b, "null"...), nil
+		}
+		b = append(b, '[')
+		for i, n := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			b = strconv.AppendInt(b, int64(n), 10)
+		}
+		return append(b, ']'), nil
+	case []interface{}:
+		if v == nil {
+			return append(b, "null"...), nil
+		}
+		b = append(b, '[')
+		for i, e := range v {
+			if i > 0 {
+				b = append(b, ',')
+			}
+			var err error
+			if b, err = appendJSON(b, e); err != nil {
+				return b, err
+			}
+		}
+		return append(b, ']'), nil
+	}
+	if s, ok := appendBasic(b, value); ok {
+		return s, nil
+	}
+	return b, errUnsupported
+}
+
+// appendJSONFloat appends the float to b, failing for the values JSON
+// can't represent.
+func appendJSONFloat(b []byte, f float64, bits int) ([]byte, error) {
+	if math.IsInf(f, 0) || math.IsNaN(f) {
+		return b, errors.New("unsupported value: " + strconv.FormatFloat(f, 'g', -1, bits))
+	}
+	return strconv.AppendFloat(b, f, 'g', -1, bits), nil
+}
+
+// appendJSONString appends the string to b as a JSON string, replacing
+// the invalid UTF-8 sequences.
+func appendJSONString(b []byte, s string) []byte {
+	const hex = "0123456789abcdef"
+	b = append(b, '"')
+	for i := 0; i < len(s); {
+		c := s[i]
+		if c < utf8.RuneSelf {
+			switch {
+			case c == '"' || c == '\\':
+				b = append(b, '\\', c)
+			case c == '\n':
+				b = append(b, '\\', 'n')
+			case c == '\r':
+				b = append(b, '\\', 'r')
+			case c == '\t':
+				b = append(b, '\\', 't')
+			case c < 0x20:
+				b = append(b, '\\', 'u', '0', '0', hex[c>>4], hex[c&0xf])
+			default:
+				b = append(b, c)
+			}
+			i++
+			continue
+		}
+		r, size := utf8.DecodeRuneInString(s[i:])
+		if r == utf8.RuneError && size == 1 {
+			b = append(b, "\ufffd"...)
+		} else {
+			b = append(b, s[i:i+size]...)
+		}
+		i += size
+	}
+	return append(b, '"')
+}
@@ -0,0 +1,3 @@
+module github.com/gmhafiz/mirip/runtime
+
+go 1.19
@@ -0,0 +1,32 @@
+// Package mirip is the runtime of the code generated by mirip, imported by
+// the mocks only for the features needing it. It is a module of its own,
+// depending on the standard library alone, so that the mocks importing it
+// add neither mirip nor its dependencies to the module graph, and it uses
+// no reflection: neither the reflect package nor fmt and encoding/json,
+// which are built on it.
+package mirip
+
+import "errors"
+
+// ErrNotImplemented is the error returned by the methods of mocks without
+// a Func generated with -not-implemented, so that the tests relying on
+// the behavior of stubs by accident fail rather than get a nil error.
+var ErrNotImplemented = errors.New("not implemented")
+
+// NotImplemented returns the error of the method of a mock, in the format
+// 'Mock.Method', without a Func, which wraps ErrNotImplemented.
+func NotImplemented(method string) error {
+	return notImplementedError{method: method}
+}
+
+type notImplementedError struct {
+	method string
+}
+
+func (e notImplementedError) Error() string {
+	return "mirip: " + e.method + " is " + ErrNotImplemented.Error()
+}
+
+func (e notImplementedError) Unwrap() error {
+	return ErrNotImplemented
+}
@@ -0,0 +1,165 @@
+package mirip
+
+import (
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+// Call is a call to a method of a mock generated with -report.
+type Call struct {
+	// Seq is the order of the call among the calls to every mock of the
+	// test binary.
+	Seq    uint64 `json:"seq"`
+	Mock   string `json:"mock"`
+	Method string `json:"method"`
+	Args   []Arg  `json:"args"`
+}
+
+// Arg is an argument of a call.
+type Arg struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+// Reporter is implemented by the mocks generated with -report, which
+// record their calls for Report.
+type Reporter interface {
+	MiripCalls() []Call
+}
+
+// seq is the sequence number of the last call to a mock.
+var seq uint64
+
+// NextSeq returns the sequence number of a new call to a mock, which the
+// mocks generated with -report record along with the call.
+func NextSeq() uint64 {
+	return atomic.AddUint64(&seq, 1)
+}
+
+// TB is the part of testing.TB used by Report.
+type TB interface {
+	Helper()
+	Log(args ...interface{})
+}
+
+// Report logs a table of the calls to the mocks, in the order they
+// happened, such as when a test fails:
+//
+//	t.Cleanup(func() {
+//		if t.Failed() {
+//			mirip.Report(t, store, queue)
+//		}
+//	})
+func Report(t TB, mocks ...Reporter) {
+	t.Helper()
+	var b strings.Builder
+	_ = WriteReport(&b, mocks...)
+	t.Log("calls to the mocks:\n" + b.String())
+}
+
+// WriteReport writes a table of the calls to the mocks to w, a line per
+// call in the order they happened.
+func WriteReport(w io.Writer, mocks ...Reporter) error {
+	rows := [][4]string{{"SEQ", "MOCK", "METHOD", "ARGS"}}
+	for _, call := range Calls(mocks...) {
+		args := make([]string, len(call.Args))
+		for i, arg := range call.Args {
+			args[i] = arg.Name + "=" + string(appendValue(nil, arg.Value))
+		}
+		rows = append(rows, [4]string{strconv.FormatUint(call.Seq, 10), call.Mock, call.Method, strings.Join(args, ", ")})
+	}
+
+	// The columns are padded by two spaces, like text/tabwriter, which
+	// relies on fmt.
+	var widths [3]int
+	for _, row := range rows {
+		for i := range widths {
+			if n := utf8.RuneCountInString(row[i]) + 2; n > widths[i] {
+				widths[i] = n
+			}
+		}
+	}
+	var b strings.Builder
+	for _, row := range rows {
+		for i, width := range widths {
+			b.WriteString(row[i])
+			b.WriteString(strings.Repeat(" ", width-utf8.RuneCountInString(row[i])))
+		}
+		b.WriteString(row[3])
+		b.WriteString("\n")
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// WriteReportJSON writes the calls to the mocks to w as a JSON array, in
+// the order they happened. The arguments which can't be encoded without
+// reflection fail it, such as the structs which don't implement
+// json.Marshaler.
+func WriteReportJSON(w io.Writer, mocks ...Reporter) error {
+	b := []byte("[")
+	for i, call := range Calls(mocks...) {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, "\n  {\n    \"seq\": "...)
+		b = strconv.AppendUint(b, call.Seq, 10)
+		b = append(b, ",\n    \"mock\": "...)
+		b = appendJSONString(b, call.Mock)
+		b = append(b, ",\n    \"method\": "...)
+		b = appendJSONString(b, call.Method)
+		b = append(b, ",\n    \"args\": "...)
+		if call.Args == nil {
+			b = append(b, "null"...)
+		} else {
+			b = append(b, '[')
+		}
+		for j, arg := range call.Args {
+			if j > 0 {
+				b = append(b, ',')
+			}
+			b = append(b, "\n      {\n        \"name\": "...)
+			b = appendJSONString(b, arg.Name)
+			b = append(b, ",\n        \"value\": "...)
+			var err error
+			if b, err = appendJSON(b, arg.Value); err != nil {
+				return &EncodeError{Mock: call.Mock, Method: call.Method, Arg: arg.Name, Err: err}
+			}
+			b = append(b, "\n      }"...)
+		}
+		if len(call.Args) != 0 {
+			b = append(b, "\n    "...)
+		}
+		if call.Args != nil {
+			b = append(b, ']')
+		}
+		b = append(b, "\n  }"...)
+	}
+	if len(b) > 1 {
+		b = append(b, '\n')
+	}
+	b = append(b, "]\n"...)
+	_, err := w.Write(b)
+	return err
+}
+
+// Calls returns the calls to the mocks, in the order they happened.
+func Calls(mocks ...Reporter) []Call {
+	var calls []Call
+	for _, mock := range mocks {
+		calls = append(calls, mock.MiripCalls()...)
+	}
+	sort.Sort(bySeq(calls))
+	return calls
+}
+
+// bySeq sorts calls in the order they happened.
+type bySeq []Call
+
+func (c bySeq) Len() int           { return len(c) }
+func (c bySeq) Less(i, j int) bool { return c[i].Seq < c[j].Seq }
+func (c bySeq) Swap(i, j int)      { c[i], c[j] = c[j], c[i] }
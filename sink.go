@@ -0,0 +1,53 @@
+package mirip
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+)
+
+// Sink receives the files generated by GenerateTo, such as a virtual file
+// system, the store of a test of a generation pipeline, or the edits of a
+// code-mod tool.
+type Sink interface {
+	// Write writes the content of the file of the given path, the one
+	// it would be written to by the mirip command.
+	Write(path string, content []byte) error
+}
+
+// SinkFunc is a function used as a Sink.
+type SinkFunc func(path string, content []byte) error
+
+// Write calls f(path, content).
+func (f SinkFunc) Write(path string, content []byte) error {
+	return f(path, content)
+}
+
+// FileSink is the Sink writing the files to the file system, creating
+// their directories, as the mirip command does.
+type FileSink struct{}
+
+// Write writes the file, creating its directory if it doesn't exist.
+func (FileSink) Write(path string, content []byte) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return err
+	}
+	return os.WriteFile(path, content, 0600)
+}
+
+// GenerateTo renders the mocks of the given interfaces of the source
+// package as Generate does, and gives the files to the sink in the order
+// of the interfaces, stopping at the first error of the sink. No file is
+// given to the sink unless all the mocks were rendered.
+func GenerateTo(ctx context.Context, sink Sink, cfg Config, targets ...string) error {
+	outFiles, contents, err := render(ctx, cfg, targets)
+	if err != nil {
+		return err
+	}
+	for i, outFile := range outFiles {
+		if err := sink.Write(outFile, contents[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}